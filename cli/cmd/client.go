@@ -59,7 +59,7 @@ func (client *HTTPClient) DoJSON(req *http.Request, dest interface{}) error {
 		return fmt.Errorf("failed to read response body from `%s`: %w", req.URL, err)
 	}
 
-	if res.StatusCode != http.StatusOK {
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
 		var errResp core.ErrorResponse
 		err := json.Unmarshal(body, &errResp)
 		if err != nil {
@@ -3,7 +3,9 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/google/uuid"
@@ -27,7 +29,7 @@ type gaugeSurveyAnswer struct {
 func init() {
 	jobsCmd := &cobra.Command{
 		Use:   "jobs <command>",
-		Short: "Set of commands to list, create and delete harvest jobs",
+		Short: "Set of commands to list, create, delete, pause, resume and run harvest jobs",
 	}
 	listCmd := &cobra.Command{
 		Use:     "list",
@@ -111,6 +113,20 @@ func init() {
 					break
 				}
 			}
+			var validation core.JobValidation
+			err = Client.PostTo("jobs/validate", &descr, &validation)
+			if err != nil {
+				fmt.Printf("Error: %v", err)
+				os.Exit(1)
+			}
+			if !validation.Valid {
+				printJobProblems(validation.Problems)
+				submit := false
+				err = survey.AskOne(&survey.Confirm{Message: "Submit job anyway?", Default: false}, &submit)
+				if err != nil || !submit {
+					os.Exit(1)
+				}
+			}
 			var res core.JobDescription
 			err = Client.PostTo("jobs", &descr, &res)
 			if err != nil {
@@ -136,6 +152,111 @@ func init() {
 			}
 		},
 	}
-	jobsCmd.AddCommand(listCmd, addCmd, deleteCmd)
+	pauseCmd := &cobra.Command{
+		Use:   "pause <jobId>",
+		Short: "Stops harvesting job until it's resumed, job is not deleted",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var res core.JobDescription
+			err := Client.PostTo(fmt.Sprintf("jobs/%s/pause", args[0]), nil, &res)
+			if err != nil {
+				fmt.Printf("Error: %v", err)
+				os.Exit(1)
+			} else {
+				fmt.Println("Success")
+			}
+		},
+	}
+	resumeCmd := &cobra.Command{
+		Use:   "resume <jobId>",
+		Short: "Resumes harvesting of paused job",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var res core.JobDescription
+			err := Client.PostTo(fmt.Sprintf("jobs/%s/resume", args[0]), nil, &res)
+			if err != nil {
+				fmt.Printf("Error: %v", err)
+				os.Exit(1)
+			} else {
+				fmt.Println("Success")
+			}
+		},
+	}
+	var runCode string
+	runCmd := &cobra.Command{
+		Use:   "run <jobId> [--code XXX]",
+		Short: "Harvests job immediately and saves measurements, without waiting for its schedule",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := fmt.Sprintf("jobs/%s/run", args[0])
+			if runCode != "" {
+				path += "?code=" + url.QueryEscape(runCode)
+			}
+			var res core.Status
+			err := Client.PostTo(path, nil, &res)
+			if err != nil {
+				fmt.Printf("Error: %v", err)
+				os.Exit(1)
+			} else if res.Error != "" {
+				fmt.Printf("Harvest error: %s\nSaved %d measurements\n", res.Error, res.Count)
+				os.Exit(1)
+			} else {
+				fmt.Printf("Saved %d measurements\n", res.Count)
+			}
+		},
+	}
+	runCmd.Flags().StringVarP(&runCode, "code", "c", "", "Gauge code to harvest. Defaults to all job gauges, required for one-by-one jobs")
+
+	var fromS, toS string
+	var codes []string
+	backfillCmd := &cobra.Command{
+		Use:   "backfill <jobId> --from XXX --to YYY [--code ZZZ]",
+		Short: "Starts harvesting historical measurements for job. Times are in UTC",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			from, err := time.ParseInLocation(timeLayout, fromS, time.UTC)
+			if err != nil {
+				fmt.Printf("Error: failed to parse backfill start '%s': %v", fromS, err)
+				os.Exit(1)
+			}
+			to, err := time.ParseInLocation(timeLayout, toS, time.UTC)
+			if err != nil {
+				fmt.Printf("Error: failed to parse backfill end '%s': %v", toS, err)
+				os.Exit(1)
+			}
+			req := core.BackfillRequest{From: core.HTime{Time: from}, To: core.HTime{Time: to}, Codes: codes}
+			var res core.BackfillStatus
+			err = Client.PostTo(fmt.Sprintf("jobs/%s/backfill", args[0]), &req, &res)
+			if err != nil {
+				fmt.Printf("Error: %v", err)
+				os.Exit(1)
+			} else {
+				printBackfillStatus(res)
+			}
+		},
+	}
+	backfillCmd.Flags().StringVar(&fromS, "from", "", "Start of backfill period, YYYY-MM-DD HH:MM")
+	backfillCmd.Flags().StringVar(&toS, "to", "", "End of backfill period, YYYY-MM-DD HH:MM")
+	backfillCmd.Flags().StringSliceVarP(&codes, "code", "c", []string{}, "Gauge code to backfill. Defaults to all job gauges")
+	backfillCmd.MarkFlagRequired("from") // nolint:errcheck
+	backfillCmd.MarkFlagRequired("to")   // nolint:errcheck
+
+	backfillStatusCmd := &cobra.Command{
+		Use:   "backfill-status <jobId>",
+		Short: "Prints progress of job's last backfill",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var res core.BackfillStatus
+			err := Client.GetTo(fmt.Sprintf("jobs/%s/backfill", args[0]), &res)
+			if err != nil {
+				fmt.Printf("Error: %v", err)
+				os.Exit(1)
+			} else {
+				printBackfillStatus(res)
+			}
+		},
+	}
+
+	jobsCmd.AddCommand(listCmd, addCmd, deleteCmd, pauseCmd, resumeCmd, runCmd, backfillCmd, backfillStatusCmd)
 	rootCmd.AddCommand(jobsCmd)
 }
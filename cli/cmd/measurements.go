@@ -14,11 +14,13 @@ import (
 const timeLayout = "2006-01-02 15:04"
 
 func init() {
+	var units string
 	measurementsCmd := &cobra.Command{
 		Use:     "measurements <command>",
 		Aliases: []string{"m"},
 		Short:   "Prints harvested and stored measurements",
 	}
+	measurementsCmd.PersistentFlags().StringVarP(&units, "units", "u", "", "Convert values to unit system: si or imperial")
 
 	queryCmd := &cobra.Command{
 		Use:   "query <script> [code] [--from XXX] [--to YYY]",
@@ -35,6 +37,9 @@ func init() {
 				fmt.Printf("Error: %v", err)
 				os.Exit(1)
 			}
+			if units != "" {
+				q += "&units=" + url.QueryEscape(units)
+			}
 			path := fmt.Sprintf("measurements/%s?%s", strings.Join(args, "/"), q)
 			err = Client.GetTo(path, &result)
 			if err != nil {
@@ -57,6 +62,9 @@ func init() {
 			}
 			var result []core.Measurement
 			path := fmt.Sprintf("measurements/latest?scripts=%s", strings.Join(scripts, ","))
+			if units != "" {
+				path += "&units=" + url.QueryEscape(units)
+			}
 			err = Client.GetTo(path, &result)
 			if err != nil {
 				fmt.Printf("Error: %v", err)
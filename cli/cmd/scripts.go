@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -28,6 +30,25 @@ func init() {
 			}
 		},
 	}
-	scriptsCmd.AddCommand(listCmd)
+	schemaCmd := &cobra.Command{
+		Use:   "schema <script>",
+		Short: "Prints JSON Schema of script options",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var result json.RawMessage
+			err := Client.GetTo(fmt.Sprintf("scripts/%s/schema", args[0]), &result)
+			if err != nil {
+				fmt.Printf("Error: %v", err)
+				os.Exit(1)
+			}
+			var out bytes.Buffer
+			if err := json.Indent(&out, result, "", "  "); err != nil {
+				fmt.Printf("Error: %v", err)
+				os.Exit(1)
+			}
+			fmt.Println(out.String())
+		},
+	}
+	scriptsCmd.AddCommand(listCmd, schemaCmd)
 	rootCmd.AddCommand(scriptsCmd)
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -9,19 +10,31 @@ import (
 )
 
 func init() {
+	var history bool
+	var historyCode string
+	var historyLimit int
 	statusCmd := &cobra.Command{
-		Use:   "status [jobId]",
+		Use:   "status [jobId] [--history]",
 		Short: "Displays information about running jobs",
-		Long:  "Displays information about running jobs.\nProvide job id or omit it to list all jobs",
+		Long:  "Displays information about running jobs.\nProvide job id or omit it to list all jobs.\nUse --history to list past harvests of the job",
 		Args:  cobra.RangeArgs(0, 1),
 		Run: func(cmd *cobra.Command, args []string) {
-			if len(args) == 0 {
+			if history {
+				if len(args) == 0 {
+					fmt.Println("Error: job id is required to display history")
+					os.Exit(1)
+				}
+				handleRuns(args[0], historyCode, historyLimit)
+			} else if len(args) == 0 {
 				handleJobs()
 			} else {
 				handleGauges(args[0])
 			}
 		},
 	}
+	statusCmd.Flags().BoolVar(&history, "history", false, "Display history of job harvests instead of latest statuses")
+	statusCmd.Flags().StringVarP(&historyCode, "code", "c", "", "Display history of this gauge only")
+	statusCmd.Flags().IntVarP(&historyLimit, "limit", "l", 20, "Number of harvests to display in history")
 	rootCmd.AddCommand(statusCmd)
 }
 
@@ -46,3 +59,19 @@ func handleGauges(jobID string) {
 		printGaugeStatuses(result)
 	}
 }
+
+func handleRuns(jobID, code string, limit int) {
+	q := url.Values{}
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	if code != "" {
+		q.Set("code", code)
+	}
+	var result []core.JobRun
+	err := Client.GetTo("jobs/"+jobID+"/runs?"+q.Encode(), &result)
+	if err != nil {
+		fmt.Printf("Error: %v", err)
+		os.Exit(1)
+	} else {
+		printJobRuns(result)
+	}
+}
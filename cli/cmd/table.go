@@ -3,6 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/tw"
@@ -36,9 +39,12 @@ func printScriptsList(data []core.ScriptDescriptor) {
 
 func printJobStatuses(data []core.JobDescription) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.Options(tablewriter.WithHeader([]string{"Job ID", "Timestamp", "Count/Error"}))
+	table.Options(tablewriter.WithHeader([]string{"Job ID", "Timestamp", "Count/Error", "Retries", "Paused"}))
 	for _, j := range data {
-		row := []string{j.ID, "", ""}
+		row := []string{j.ID, "", "", "", ""}
+		if j.Paused {
+			row[4] = "yes"
+		}
 		if j.Status != nil {
 			row[1] = j.Status.LastRun.Format("2006-01-02T15:04:05")
 			if j.Status.Error != "" {
@@ -46,6 +52,9 @@ func printJobStatuses(data []core.JobDescription) {
 			} else {
 				row[2] = j.Status.Error
 			}
+			if j.Status.Retries > 0 {
+				row[3] = fmt.Sprintf("%d", j.Status.Retries)
+			}
 		}
 		table.Append(row)
 	}
@@ -68,6 +77,41 @@ func printGaugeStatuses(data map[string]core.Status) {
 	table.Render()
 }
 
+func printJobRuns(data []core.JobRun) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Options(tablewriter.WithHeader([]string{"Started", "Code", "Duration", "Harvested", "Filters", "Saved", "Cached", "Error"}))
+	for _, r := range data {
+		filters := make([]string, len(r.Filters))
+		for i, f := range r.Filters {
+			filters[i] = fmt.Sprintf("%s %d/%d", f.Name, f.Out, f.In)
+		}
+		code := r.Code
+		if len(r.Codes) > 0 {
+			code = truncateString(strings.Join(r.Codes, ","), 20)
+		}
+		table.Append([]string{
+			r.StartedAt.Format("2006-01-02T15:04:05"),
+			code,
+			r.Duration.Round(time.Millisecond).String(),
+			fmt.Sprintf("%d", r.Harvested),
+			strings.Join(filters, ", "),
+			fmt.Sprintf("%d", r.Saved),
+			fmt.Sprintf("%d", r.Cached),
+			r.Error,
+		})
+	}
+	table.Render()
+}
+
+func printJobProblems(data []core.JobProblem) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Options(tablewriter.WithHeader([]string{"Field", "Code", "Problem"}))
+	for _, p := range data {
+		table.Append([]string{p.Field, p.Code, p.Message})
+	}
+	table.Render()
+}
+
 func printGauges(data []core.Gauge, truncURLs bool) {
 	table := tablewriter.NewWriter(os.Stdout)
 	header := []string{"#", "Code", "Name", "Flow unit", "Level unit", "Timezone", "Location", "URL"}
@@ -102,8 +146,8 @@ func printGauges(data []core.Gauge, truncURLs bool) {
 func printMeasurements(measurements []core.Measurement) {
 	table := tablewriter.NewWriter(os.Stdout)
 	table.Options(
-		tablewriter.WithHeader([]string{"Script", "Code", "Timestamp", "Flow", "Level"}),
-		tablewriter.WithFooter([]string{fmt.Sprintf("%d measurements total", len(measurements)), "", "", "", ""}),
+		tablewriter.WithHeader([]string{"Script", "Code", "Timestamp", "Flow", "Level", "Params"}),
+		tablewriter.WithFooter([]string{fmt.Sprintf("%d measurements total", len(measurements)), "", "", "", "", ""}),
 	)
 	for _, m := range measurements {
 		flow, level := "", ""
@@ -113,12 +157,18 @@ func printMeasurements(measurements []core.Measurement) {
 		if m.Level.Valid() {
 			level = fmt.Sprintf("%.2f", m.Level.Float64Value())
 		}
+		params := make([]string, 0, len(m.Params))
+		for p, v := range m.Params {
+			params = append(params, fmt.Sprintf("%s=%.2f", p, v))
+		}
+		sort.Strings(params)
 		table.Append([]string{
 			m.Script,
 			m.Code,
 			m.Timestamp.UTC().Format("02/01/2006 15:04 MST"),
 			flow,
 			level,
+			strings.Join(params, ", "),
 		})
 	}
 	table.Render()
@@ -147,3 +197,17 @@ func printJobs(jobs []core.JobDescription) {
 	}
 	table.Render()
 }
+
+func printBackfillStatus(s core.BackfillStatus) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Options(tablewriter.WithHeader([]string{"Job ID", "State", "Period", "Pages", "Saved", "Error"}))
+	table.Append([]string{
+		s.JobID,
+		string(s.State),
+		fmt.Sprintf("%s - %s", s.From.Format(timeLayout), s.To.Format(timeLayout)),
+		fmt.Sprintf("%d/%d", s.PagesDone, s.Pages),
+		fmt.Sprintf("%d", s.Saved),
+		s.Error,
+	})
+	table.Render()
+}
@@ -34,12 +34,17 @@ func init() {
 func createGaugesCmd(descriptor core.ScriptDescriptor) *cobra.Command {
 	cfg := descriptor.DefaultOptions()
 	noTruncURLs := false
+	var timeout int64
 	cmd := &cobra.Command{
 		Use:   "gauges [flags]",
 		Short: fmt.Sprintf("Lists all available gauges for script %s", descriptor.Name),
 		Run: func(cmd *cobra.Command, args []string) {
 			var result []core.Gauge
-			err := Client.PostTo(fmt.Sprintf("upstream/%s/gauges", descriptor.Name), cfg, &result)
+			q := url.Values{}
+			if timeout > 0 {
+				q.Set("timeout", fmt.Sprint(timeout))
+			}
+			err := Client.PostTo(fmt.Sprintf("upstream/%s/gauges?%s", descriptor.Name, q.Encode()), cfg, &result)
 			if err != nil {
 				fmt.Printf("Error: %v", err)
 				os.Exit(1)
@@ -55,6 +60,7 @@ func createGaugesCmd(descriptor core.ScriptDescriptor) *cobra.Command {
 	}
 	cmd.Flags().AddFlagSet(gFlags)
 	cmd.Flags().BoolVar(&noTruncURLs, "no-trunc", false, "Do not truncate URLs")
+	cmd.Flags().Int64Var(&timeout, "list-timeout", 0, "Abort listing after this many seconds")
 	return cmd
 }
 
@@ -34,6 +34,19 @@ type HealthConfig struct {
 	Headers   []string `desc:"headers to set on request, in 'Header: Value' format, similar to curl "`
 }
 
+type SchedulerConfig struct {
+	Leases   string `desc:"set to 'db' or 'redis' to run multiple gorge instances: every harvest will run on one instance only. Leave empty to run all jobs on this instance"`
+	Instance string `desc:"name of this instance, used to identify lease holder. Defaults to hostname and process id"`
+	Timeout  int64  `desc:"default harvest timeout in seconds. Can be overridden in job description"`
+	Grace    int64  `desc:"on shutdown, running harvests have this many seconds to finish before they're cancelled"`
+	// limits are applied to scheduled harvests, retries and manual runs, but not to backfills
+	Workers       int      `desc:"max number of harvests running at the same time. 0 means no limit"`
+	ScriptWorkers []string `desc:"max number of harvests of script running at the same time, in 'script=N' format"`
+	HostWorkers   []string `desc:"max number of harvests from upstream host running at the same time, in 'host=N' format. Script hosts are listed in /scripts"`
+	RunsRetention int      `desc:"number of days to keep history of harvest runs. 0 disables history"`
+	CatchUp       int64    `desc:"on start, harvests that were missed while gorge was down are run once, spread randomly over this many seconds. 0 disables catch-up"`
+}
+
 type WebhooksConfig struct {
 	Health HealthConfig
 }
@@ -51,6 +64,7 @@ type Config struct {
 	Log         LogConfig
 	HTTP        core.ClientOptions
 	Hooks       WebhooksConfig
+	Scheduler   SchedulerConfig
 }
 
 func (cfg *Config) ReadFromEnv() {
@@ -92,6 +106,12 @@ func NewConfig() *Config {
 				Threshold: 48,
 			},
 		},
+		Scheduler: SchedulerConfig{
+			Timeout:       60,
+			Grace:         30,
+			RunsRetention: 30,
+			CatchUp:       300,
+		},
 	}
 }
 
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// AdaptiveSchedule makes one-by-one and batched jobs harvest gauges as often as upstream publishes their measurements
+// Publication interval of every gauge is learned from timestamps of stored measurements
+type AdaptiveSchedule struct {
+	// MinInterval is shortest interval between harvests of gauge
+	MinInterval Duration `json:"minInterval" ts_type:"string"`
+	// MaxInterval is longest interval between harvests of gauge
+	MaxInterval Duration `json:"maxInterval" ts_type:"string"`
+}
+
+// DefaultHarvestInterval is used for gauges which publication interval is not known yet
+const DefaultHarvestInterval = time.Hour
+
+// Validate returns error if bounds are invalid. Nil schedule is valid and means that gauges are harvested every hour
+func (a *AdaptiveSchedule) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if a.MinInterval.Duration < time.Minute {
+		return fmt.Errorf("min interval must be at least 1 minute")
+	}
+	if a.MaxInterval.Duration < a.MinInterval.Duration {
+		return fmt.Errorf("max interval must not be less than min interval")
+	}
+	if a.MaxInterval.Duration > 24*time.Hour {
+		return fmt.Errorf("max interval must not be longer than 24 hours")
+	}
+	return nil
+}
+
+// Interval returns interval between harvests of gauge with given publication interval, which is 0 if it's not known
+func (a *AdaptiveSchedule) Interval(published time.Duration) time.Duration {
+	interval := published
+	if interval <= 0 {
+		interval = DefaultHarvestInterval
+	}
+	if interval < a.MinInterval.Duration {
+		interval = a.MinInterval.Duration
+	}
+	if interval > a.MaxInterval.Duration {
+		interval = a.MaxInterval.Duration
+	}
+	return interval
+}
@@ -0,0 +1,25 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveScheduleValidate(t *testing.T) {
+	var nilSchedule *AdaptiveSchedule
+	assert.NoError(t, nilSchedule.Validate())
+	assert.NoError(t, (&AdaptiveSchedule{MinInterval: Duration{5 * time.Minute}, MaxInterval: Duration{24 * time.Hour}}).Validate())
+	assert.Error(t, (&AdaptiveSchedule{MinInterval: Duration{time.Second}, MaxInterval: Duration{time.Hour}}).Validate())
+	assert.Error(t, (&AdaptiveSchedule{MinInterval: Duration{time.Hour}, MaxInterval: Duration{time.Minute}}).Validate())
+	assert.Error(t, (&AdaptiveSchedule{MinInterval: Duration{time.Hour}, MaxInterval: Duration{48 * time.Hour}}).Validate())
+}
+
+func TestAdaptiveScheduleInterval(t *testing.T) {
+	a := &AdaptiveSchedule{MinInterval: Duration{10 * time.Minute}, MaxInterval: Duration{6 * time.Hour}}
+	assert.Equal(t, time.Hour, a.Interval(0))
+	assert.Equal(t, 10*time.Minute, a.Interval(5*time.Minute))
+	assert.Equal(t, 30*time.Minute, a.Interval(30*time.Minute))
+	assert.Equal(t, 6*time.Hour, a.Interval(24*time.Hour))
+}
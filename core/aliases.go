@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrAliasCycle is returned when gauge aliases form a cycle and code cannot be resolved
+var ErrAliasCycle = errors.New("gauge aliases contain cycle")
+
+// GaugeAlias says that gauge code was renamed in upstream
+// Measurements of old code are stored and queried under new code
+type GaugeAlias struct {
+	Script  string `json:"script" db:"script"`
+	OldCode string `json:"oldCode" db:"old_code"`
+	NewCode string `json:"newCode" db:"new_code"`
+}
+
+// Bind implements go-chi Binder interface
+func (a *GaugeAlias) Bind(r *http.Request) error {
+	if a.Script == "" || a.OldCode == "" || a.NewCode == "" {
+		return NewErr(errors.New("script, oldCode and newCode are required"))
+	}
+	if a.OldCode == a.NewCode {
+		return NewErr(errors.New("oldCode and newCode must be different")).With("code", a.OldCode)
+	}
+	return nil
+}
+
+// CodeAliases maps old gauge codes of one script to new codes
+type CodeAliases map[string]string
+
+// NewCodeAliases creates map of aliases of given script
+func NewCodeAliases(script string, aliases []GaugeAlias) CodeAliases {
+	result := CodeAliases{}
+	for _, a := range aliases {
+		if a.Script == script {
+			result[a.OldCode] = a.NewCode
+		}
+	}
+	return result
+}
+
+// Resolve returns current code of gauge, following chains of renames
+// Returns error if aliases contain cycle
+func (a CodeAliases) Resolve(code string) (string, error) {
+	current := code
+	for i := 0; i <= len(a); i++ {
+		next, ok := a[current]
+		if !ok {
+			return current, nil
+		}
+		current = next
+	}
+	return "", WrapErr(ErrAliasCycle, "failed to resolve gauge code").With("code", code)
+}
+
+// ResolveSet resolves every code in set. Codes that cannot be resolved are kept as is
+func (a CodeAliases) ResolveSet(codes StringSet) StringSet {
+	if len(a) == 0 {
+		return codes
+	}
+	result := make(StringSet, len(codes))
+	for code := range codes {
+		if resolved, err := a.Resolve(code); err == nil {
+			result[resolved] = struct{}{}
+		} else {
+			result[code] = struct{}{}
+		}
+	}
+	return result
+}
+
+// RenameCodes replaces old gauge codes of measurements from the channel with new codes
+// It supports context cancelation
+func RenameCodes(ctx context.Context, in <-chan *Measurement, aliases CodeAliases) <-chan *Measurement {
+	if len(aliases) == 0 {
+		return in
+	}
+	out := make(chan *Measurement)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m, ok := <-in:
+				if !ok {
+					return
+				}
+				if code, err := aliases.Resolve(m.Code); err == nil {
+					m.Code = code
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- m:
+				}
+			}
+		}
+	}()
+	return out
+}
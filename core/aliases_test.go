@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeAliases_Resolve(t *testing.T) {
+	aliases := NewCodeAliases("s", []GaugeAlias{
+		{Script: "s", OldCode: "a", NewCode: "b"},
+		{Script: "s", OldCode: "b", NewCode: "c"},
+		{Script: "other", OldCode: "c", NewCode: "d"},
+	})
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{code: "a", expected: "c"},
+		{code: "b", expected: "c"},
+		{code: "c", expected: "c"},
+		{code: "x", expected: "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			actual, err := aliases.Resolve(tt.code)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCodeAliases_ResolveCycle(t *testing.T) {
+	aliases := CodeAliases{"a": "b", "b": "a"}
+	_, err := aliases.Resolve("a")
+	assert.True(t, errors.Is(err, ErrAliasCycle))
+}
+
+func TestCodeAliases_ResolveSet(t *testing.T) {
+	aliases := CodeAliases{"a": "b"}
+	assert.Equal(t, StringSet{"b": {}, "c": {}}, aliases.ResolveSet(StringSet{"a": {}, "b": {}, "c": {}}))
+}
+
+func TestRenameCodes(t *testing.T) {
+	ctx := context.Background()
+	in := GenFromSlice(ctx, []Measurement{
+		{GaugeID: GaugeID{Script: "s", Code: "a"}},
+		{GaugeID: GaugeID{Script: "s", Code: "c"}},
+	})
+	var codes []string
+	for m := range RenameCodes(ctx, in, CodeAliases{"a": "b"}) {
+		codes = append(codes, m.Code)
+	}
+	assert.Equal(t, []string{"b", "c"}, codes)
+}
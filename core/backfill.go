@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrBackfillNotSupported is returned when job's script cannot harvest historical measurements
+var ErrBackfillNotSupported = errors.New("script does not support backfill")
+
+// ErrBackfillRunning is returned when backfill for job is already in progress
+var ErrBackfillRunning = errors.New("backfill is already running")
+
+// Backfiller is optional interface which scripts implement when upstream allows to request measurements for explicit historical time range
+type Backfiller interface {
+	// BackfillPage returns maximal time range that can be requested from upstream at once
+	// Backfill period is split into pages of this size, and Backfill is called once per page
+	BackfillPage() time.Duration
+	// Backfill harvests measurements within [from, to] time range and writes them to recv channel, then closes both channels.
+	// Contract is the same as in Script.Harvest, Since of spec is not set
+	Backfill(ctx context.Context, recv chan<- *Measurement, errs chan<- error, spec HarvestSpec, from, to time.Time)
+}
+
+// BackfillRequest is payload of backfill endpoint
+type BackfillRequest struct {
+	From HTime `json:"from" ts_type:"string"`
+	To   HTime `json:"to" ts_type:"string"`
+	// Gauge codes to backfill. If empty, all gauges of the job are backfilled
+	Codes []string `json:"codes,omitempty" ts_type:"string[]"`
+}
+
+// Bind implements go-chi Binder interface
+func (r *BackfillRequest) Bind(req *http.Request) error {
+	if r.From.IsZero() || r.To.IsZero() {
+		return NewErr(errors.New("from and to are required"))
+	}
+	if !r.From.Before(r.To.Time) {
+		return NewErr(errors.New("from must be before to")).With("from", r.From).With("to", r.To)
+	}
+	return nil
+}
+
+// BackfillState is state of backfill process
+type BackfillState string
+
+const (
+	// BackfillRunning means that backfill is in progress
+	BackfillRunning BackfillState = "running"
+	// BackfillDone means that all pages were harvested
+	BackfillDone BackfillState = "done"
+	// BackfillFailed means that backfill was aborted due to error
+	BackfillFailed BackfillState = "failed"
+)
+
+// BackfillStatus reports progress of backfill of one job
+type BackfillStatus struct {
+	JobID     string        `json:"jobId"`
+	State     BackfillState `json:"state"`
+	From      HTime         `json:"from" ts_type:"string"`
+	To        HTime         `json:"to" ts_type:"string"`
+	Pages     int           `json:"pages"`
+	PagesDone int           `json:"pagesDone"`
+	// Count of measurements saved to database so far
+	Saved      int    `json:"saved"`
+	Error      string `json:"error,omitempty"`
+	StartedAt  HTime  `json:"startedAt" ts_type:"string"`
+	FinishedAt *HTime `json:"finishedAt,omitempty" ts_type:"string"`
+}
+
+// SplitPeriod splits [from, to] period into consecutive pages no longer than page
+// If page is not positive, the whole period is returned as single page
+func SplitPeriod(from, to time.Time, page time.Duration) [][2]time.Time {
+	if page <= 0 {
+		return [][2]time.Time{{from, to}}
+	}
+	var result [][2]time.Time
+	for start := from; start.Before(to); start = start.Add(page) {
+		end := start.Add(page)
+		if end.After(to) {
+			end = to
+		}
+		result = append(result, [2]time.Time{start, end})
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"reflect"
+	"sort"
+)
+
+// GaugeChangeKind describes how gauge changed between two catalog refreshes
+type GaugeChangeKind string
+
+const (
+	// GaugeAdded means that gauge appeared in upstream
+	GaugeAdded GaugeChangeKind = "added"
+	// GaugeRemoved means that gauge disappeared from upstream
+	GaugeRemoved GaugeChangeKind = "removed"
+	// GaugeChanged means that gauge metadata has changed
+	GaugeChanged GaugeChangeKind = "changed"
+)
+
+// GaugeChange is record in gauge catalog history
+type GaugeChange struct {
+	GaugeID
+	Kind GaugeChangeKind `json:"kind"`
+	// Names of changed fields, for "changed" kind only
+	Fields []string `json:"fields,omitempty"`
+	// Gauge state after change. For removed gauges, this is last known state
+	Gauge *Gauge `json:"gauge"`
+	// Gauge state before change, for "changed" kind only
+	Previous  *Gauge `json:"previous,omitempty"`
+	Timestamp HTime  `json:"timestamp" ts_type:"string"`
+}
+
+// ChangedFields returns json names of tracked fields (name, location, units, timezone) that differ between two gauges
+func (g *Gauge) ChangedFields(other *Gauge) []string {
+	var fields []string
+	if g.Name != other.Name {
+		fields = append(fields, "name")
+	}
+	if !reflect.DeepEqual(g.Location, other.Location) {
+		fields = append(fields, "location")
+	}
+	if g.LevelUnit != other.LevelUnit {
+		fields = append(fields, "levelUnit")
+	}
+	if g.FlowUnit != other.FlowUnit {
+		fields = append(fields, "flowUnit")
+	}
+	if len(g.ParamUnits) != 0 || len(other.ParamUnits) != 0 {
+		if !reflect.DeepEqual(g.ParamUnits, other.ParamUnits) {
+			fields = append(fields, "paramUnits")
+		}
+	}
+	if g.Timezone != other.Timezone {
+		fields = append(fields, "timezone")
+	}
+	return fields
+}
+
+// DiffGauges compares previous and current gauge lists of one script and returns changes sorted by gauge code
+// Changes have zero timestamp, it's up to caller to set it
+func DiffGauges(previous, current []Gauge) []GaugeChange {
+	prev := make(map[string]*Gauge, len(previous))
+	for i := range previous {
+		prev[previous[i].Code] = &previous[i]
+	}
+	var result []GaugeChange
+	seen := make(StringSet, len(current))
+	for i := range current {
+		g := &current[i]
+		seen[g.Code] = struct{}{}
+		old, ok := prev[g.Code]
+		if !ok {
+			result = append(result, GaugeChange{GaugeID: g.GaugeID, Kind: GaugeAdded, Gauge: g})
+		} else if fields := old.ChangedFields(g); len(fields) > 0 {
+			result = append(result, GaugeChange{GaugeID: g.GaugeID, Kind: GaugeChanged, Fields: fields, Gauge: g, Previous: old})
+		}
+	}
+	for code, old := range prev {
+		if !seen.Contains(code) {
+			result = append(result, GaugeChange{GaugeID: old.GaugeID, Kind: GaugeRemoved, Gauge: old})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
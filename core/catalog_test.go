@@ -0,0 +1,26 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffGauges(t *testing.T) {
+	previous := []Gauge{
+		{GaugeID: GaugeID{Script: "s", Code: "a"}, Name: "A"},
+		{GaugeID: GaugeID{Script: "s", Code: "b"}, Name: "B", Location: &Location{Latitude: 1, Longitude: 2}},
+		{GaugeID: GaugeID{Script: "s", Code: "c"}, Name: "C", URL: "http://c"},
+	}
+	current := []Gauge{
+		{GaugeID: GaugeID{Script: "s", Code: "b"}, Name: "B2", Location: &Location{Latitude: 1, Longitude: 2}, ParamUnits: map[Parameter]string{Temperature: "degC"}},
+		{GaugeID: GaugeID{Script: "s", Code: "c"}, Name: "C", URL: "http://c2"},
+		{GaugeID: GaugeID{Script: "s", Code: "d"}, Name: "D"},
+	}
+	changes := DiffGauges(previous, current)
+	if assert.Len(t, changes, 3) {
+		assert.Equal(t, GaugeChange{GaugeID: previous[0].GaugeID, Kind: GaugeRemoved, Gauge: &previous[0]}, changes[0])
+		assert.Equal(t, GaugeChange{GaugeID: current[0].GaugeID, Kind: GaugeChanged, Fields: []string{"name", "paramUnits"}, Gauge: &current[0], Previous: &previous[1]}, changes[1])
+		assert.Equal(t, GaugeChange{GaugeID: current[2].GaugeID, Kind: GaugeAdded, Gauge: &current[2]}, changes[2])
+	}
+}
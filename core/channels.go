@@ -87,6 +87,27 @@ func Split(ctx context.Context, in <-chan *Measurement) (<-chan *Measurement, <-
 	return left, right
 }
 
+// Count passes measurements through and counts them
+// Number of measurements is written to returned int channel once input channel is closed or context is canceled
+func Count(ctx context.Context, in <-chan *Measurement) (<-chan *Measurement, <-chan int) {
+	out := make(chan *Measurement)
+	countCh := make(chan int, 1)
+	go func() {
+		defer close(countCh)
+		defer close(out)
+		count := 0
+		for v := range Cancelable(ctx, in) {
+			select {
+			case <-ctx.Done():
+			case out <- v:
+				count++
+			}
+		}
+		countCh <- count
+	}()
+	return out, countCh
+}
+
 // GaugeSinkToSlice converts gauges channels to struct and error
 func GaugeSinkToSlice(gauges chan *Gauge, errs chan error) (Gauges, error) {
 	var result Gauges
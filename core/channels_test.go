@@ -85,3 +85,15 @@ func TestSplit(t *testing.T) {
 	assert.False(t, lok)
 	assert.False(t, rok)
 }
+
+func TestCount(t *testing.T) {
+	ms := []Measurement{
+		GenerateRandMeasurement("all_at_once", "g000", 100, 0, 0),
+		GenerateRandMeasurement("all_at_once", "g001", 200, 0, 0),
+	}
+	ctx := context.Background()
+	out, countCh := Count(ctx, GenFromSlice(ctx, ms))
+	actual := <-SinkToSlice(ctx, out)
+	assert.Len(t, actual, 2)
+	assert.Equal(t, 2, <-countCh)
+}
@@ -35,6 +35,7 @@ type HTTPClient struct {
 	PersistentJar *jar.Jar
 	UserAgent     string
 	logger        *log.Entry
+	ctx           context.Context
 }
 
 // ClientOptions are HTTPClient that can be passed as args at startup
@@ -102,6 +103,27 @@ func NewClient(opts ClientOptions, logger *log.Entry) *HTTPClient {
 	return client
 }
 
+// WithContext returns shallow copy of client which binds all its requests to given context
+// If context has a deadline, it takes precedence over client's timeout, so callers can extend or shorten it
+func (client *HTTPClient) WithContext(ctx context.Context) *HTTPClient {
+	c := *client
+	c.ctx = ctx
+	if _, ok := ctx.Deadline(); ok {
+		hc := *client.Client
+		hc.Timeout = 0
+		c.Client = &hc
+	}
+	return &c
+}
+
+// Context returns context bound to this client, or background context if none was bound
+func (client *HTTPClient) Context() context.Context {
+	if client.ctx == nil {
+		return context.Background()
+	}
+	return client.ctx
+}
+
 // EnsureCookie makes sure that cookies from given URL are present and will be sent with further requests
 // Some scripts will not return correct data unless cookies are present
 func (client *HTTPClient) EnsureCookie(fromURL string, force bool) error {
@@ -178,7 +200,7 @@ func (client *HTTPClient) doRetry(req *http.Request, retryErrors bool) (*http.Re
 				err = fmt.Errorf("req failed (%d): %s", resp.StatusCode, resp.Status)
 			}
 			return resp, err
-		}, b)
+		}, backoff.WithContext(b, req.Context()))
 
 	}
 	return client.Client.Do(req)
@@ -189,9 +211,9 @@ func (client *HTTPClient) Get(url string, opts *RequestOptions) (resp *http.Resp
 	var req *http.Request
 
 	if opts != nil && opts.IgnoreRedirectsAfter > 0 {
-		req, err = http.NewRequestWithContext(context.WithValue(context.Background(), ignoreRedirectsCtxKey, opts.IgnoreRedirectsAfter), "GET", url, nil)
+		req, err = http.NewRequestWithContext(context.WithValue(client.Context(), ignoreRedirectsCtxKey, opts.IgnoreRedirectsAfter), "GET", url, nil)
 	} else {
-		req, err = http.NewRequest("GET", url, nil)
+		req, err = http.NewRequestWithContext(client.Context(), "GET", url, nil)
 	}
 	if err != nil {
 		return
@@ -267,7 +289,7 @@ func (client *HTTPClient) GetAsDoc(url string, opts *RequestOptions) (*Doc, erro
 
 // PostForm is like http.Client.PostForm but wit extra options
 func (client *HTTPClient) PostForm(url string, data url.Values, opts *RequestOptions) (resp *http.Response, req *http.Request, err error) {
-	req, err = http.NewRequest("POST", url, strings.NewReader(data.Encode()))
+	req, err = http.NewRequestWithContext(client.Context(), "POST", url, strings.NewReader(data.Encode()))
 	if err != nil {
 		return
 	}
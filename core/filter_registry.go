@@ -0,0 +1,254 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Duration is time.Duration which marshals/unmarshals to/from string like "24h" or "15m"
+type Duration struct {
+	time.Duration
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"24h\": %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// FiltersConfig is "filters" section of job description. Keys are filter names, values are filter options
+// Default filters can be disabled by passing false, other filters are enabled by passing true or options object
+type FiltersConfig map[string]json.RawMessage
+
+// FilterContext contains data of harvest run that filters can use
+type FilterContext struct {
+	Logger *logrus.Entry
+	// Now is time when harvest run has started
+	Now time.Time
+	// Codes are gauge codes harvested during the run
+	Codes StringSet
+	// Latest are latest measurements of gauges from cache
+	Latest map[GaugeID]Measurement
+	// Outliers are outlier filter options from job options and gauge options
+	Outliers OutlierConfig
+}
+
+// FilterFactory creates filter for harvest run. Options are raw json from job's filters section, they can be empty
+type FilterFactory func(ctx FilterContext, options json.RawMessage) (MeasurementsFilter, error)
+
+// FilterDescriptor describes measurements filter that can be used by harvest jobs
+type FilterDescriptor struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Default filters are used in every harvest job, unless job disables them
+	Default bool `json:"default"`
+	// Required filters are used in every harvest job and cannot be disabled
+	Required bool          `json:"required"`
+	Factory  FilterFactory `json:"-"`
+}
+
+// FilterRegistry contains all measurements filters that can be used by harvest jobs
+// Filters are applied in order of registration
+type FilterRegistry struct {
+	mu          sync.RWMutex
+	descriptors []*FilterDescriptor
+}
+
+// NewFilterRegistry creates filter registry with given filters
+func NewFilterRegistry(descriptors ...*FilterDescriptor) *FilterRegistry {
+	r := &FilterRegistry{}
+	for _, d := range descriptors {
+		r.Register(d) // nolint:errcheck
+	}
+	return r
+}
+
+// Register adds filter to registry. Filter name must be unique
+func (r *FilterRegistry) Register(d *FilterDescriptor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.descriptors {
+		if existing.Name == d.Name {
+			return (&Error{Msg: "filter is already registered"}).With("filter", d.Name)
+		}
+	}
+	r.descriptors = append(r.descriptors, d)
+	return nil
+}
+
+// List returns all registered filters
+func (r *FilterRegistry) List() []FilterDescriptor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]FilterDescriptor, len(r.descriptors))
+	for i, d := range r.descriptors {
+		result[i] = *d
+	}
+	return result
+}
+
+// Validate checks that all filters in config are registered and their options are valid
+func (r *FilterRegistry) Validate(config FiltersConfig) error {
+	_, err := r.Build(FilterContext{Now: time.Now()}, config)
+	return err
+}
+
+// Build creates filters for harvest run: default filters that are not disabled and filters enabled in config
+func (r *FilterRegistry) Build(ctx FilterContext, config FiltersConfig) ([]MeasurementsFilter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var unknown []string
+	for name := range config {
+		if r.find(name) == nil {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, (&Error{Msg: "unknown filters"}).With("filters", unknown)
+	}
+	var result []MeasurementsFilter
+	for _, d := range r.descriptors {
+		raw, configured := config[d.Name]
+		raw = bytes.TrimSpace(raw)
+		enabled := d.Default || d.Required
+		if configured {
+			switch string(raw) {
+			case "false":
+				if d.Required {
+					return nil, (&Error{Msg: "filter cannot be disabled"}).With("filter", d.Name)
+				}
+				enabled = false
+			case "", "null", "true":
+				enabled, raw = true, nil
+			default:
+				enabled = true
+			}
+		}
+		if !enabled {
+			continue
+		}
+		f, err := d.Factory(ctx, raw)
+		if err != nil {
+			return nil, WrapErr(err, "failed to create filter").With("filter", d.Name)
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func (r *FilterRegistry) find(name string) *FilterDescriptor {
+	for _, d := range r.descriptors {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// decodeFilterOptions decodes filter options, unknown fields are not allowed
+func decodeFilterOptions(raw json.RawMessage, options interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(options); err != nil {
+		return WrapErr(err, "failed to unmarshal filter options")
+	}
+	return nil
+}
+
+// Filters is registry of filters used by harvest jobs
+// Custom filters can be registered here before scheduler starts
+var Filters = NewFilterRegistry(
+	&FilterDescriptor{
+		Name:        "partition",
+		Description: "Rejects measurements outside of db partitions range. Options: retention (months, 1-12, default 12), futureTolerance (default \"24h\")",
+		Default:     true,
+		Required:    true,
+		Factory: func(ctx FilterContext, raw json.RawMessage) (MeasurementsFilter, error) {
+			opts := struct {
+				Retention       int      `json:"retention"`
+				FutureTolerance Duration `json:"futureTolerance"`
+			}{Retention: MaxPartitionRetention, FutureTolerance: Duration{24 * time.Hour}}
+			if err := decodeFilterOptions(raw, &opts); err != nil {
+				return nil, err
+			}
+			if opts.Retention < 1 || opts.Retention > MaxPartitionRetention {
+				return nil, (&Error{Msg: "retention is out of range"}).With("retention", opts.Retention).With("max", MaxPartitionRetention)
+			}
+			return PartitionRangeFilter{Logger: ctx.Logger, Now: ctx.Now, FutureTolerance: opts.FutureTolerance.Duration, Retention: opts.Retention}, nil
+		},
+	},
+	&FilterDescriptor{
+		Name:        "codes",
+		Description: "Rejects measurements of gauges that are not part of the job",
+		Default:     true,
+		Factory: func(ctx FilterContext, raw json.RawMessage) (MeasurementsFilter, error) {
+			return CodesFilter{Codes: ctx.Codes}, nil
+		},
+	},
+	&FilterDescriptor{
+		Name:        "outliers",
+		Description: "Rejects garbage values, configured via \"outliers\" key of job options and gauge options",
+		Default:     true,
+		Factory: func(ctx FilterContext, raw json.RawMessage) (MeasurementsFilter, error) {
+			return OutlierFilter{Logger: ctx.Logger, Config: ctx.Outliers, Latest: ctx.Latest}, nil
+		},
+	},
+	&FilterDescriptor{
+		Name:        "latest",
+		Description: "Rejects measurements that are not newer than latest cached measurement of the gauge. Options: window (default \"720h\") - how old measurements of gauges without cached measurement can be",
+		Default:     true,
+		Factory: func(ctx FilterContext, raw json.RawMessage) (MeasurementsFilter, error) {
+			opts := struct {
+				Window Duration `json:"window"`
+			}{Window: Duration{30 * 24 * time.Hour}}
+			if err := decodeFilterOptions(raw, &opts); err != nil {
+				return nil, err
+			}
+			return LatestFilter{Latest: ctx.Latest, After: ctx.Now.Add(-opts.Window.Duration)}, nil
+		},
+	},
+	&FilterDescriptor{
+		Name:        "dedup",
+		Description: "Rejects repeated measurements with same gauge and timestamp",
+		Factory: func(ctx FilterContext, raw json.RawMessage) (MeasurementsFilter, error) {
+			return NewDedupFilter(), nil
+		},
+	},
+	&FilterDescriptor{
+		Name:        "window",
+		Description: "Rejects measurements outside of time window. Options: maxAge, maxFuture, e.g. \"48h\"",
+		Factory: func(ctx FilterContext, raw json.RawMessage) (MeasurementsFilter, error) {
+			var opts struct {
+				MaxAge    Duration `json:"maxAge"`
+				MaxFuture Duration `json:"maxFuture"`
+			}
+			if err := decodeFilterOptions(raw, &opts); err != nil {
+				return nil, err
+			}
+			return WindowFilter{Now: ctx.Now, MaxAge: opts.MaxAge.Duration, MaxFuture: opts.MaxFuture.Duration}, nil
+		},
+	},
+)
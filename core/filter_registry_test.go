@@ -0,0 +1,132 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func filterNames(filters []MeasurementsFilter) []string {
+	result := make([]string, len(filters))
+	for i, f := range filters {
+		result[i] = f.Name()
+	}
+	return result
+}
+
+func TestFilterRegistry_Build(t *testing.T) {
+	now := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		config   FiltersConfig
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "defaults",
+			expected: []string{"partition", "codes", "outliers", "latest"},
+		},
+		{
+			name:     "disable default",
+			config:   FiltersConfig{"latest": json.RawMessage("false")},
+			expected: []string{"partition", "codes", "outliers"},
+		},
+		{
+			name:     "enable optional",
+			config:   FiltersConfig{"dedup": json.RawMessage("true"), "window": json.RawMessage(`{"maxAge": "48h"}`)},
+			expected: []string{"partition", "codes", "outliers", "latest", "dedup", "window"},
+		},
+		{
+			name:     "tune default",
+			config:   FiltersConfig{"partition": json.RawMessage(`{"futureTolerance": "1h"}`)},
+			expected: []string{"partition", "codes", "outliers", "latest"},
+		},
+		{
+			name:   "disable required",
+			config: FiltersConfig{"partition": json.RawMessage("false")},
+			err:    true,
+		},
+		{
+			name:   "retention beyond partman retention",
+			config: FiltersConfig{"partition": json.RawMessage(`{"retention": 13}`)},
+			err:    true,
+		},
+		{
+			name:   "unknown filter",
+			config: FiltersConfig{"foo": json.RawMessage("true")},
+			err:    true,
+		},
+		{
+			name:   "bad options",
+			config: FiltersConfig{"window": json.RawMessage(`{"maxAge": 10}`)},
+			err:    true,
+		},
+		{
+			name:   "unknown option",
+			config: FiltersConfig{"latest": json.RawMessage(`{"foo": "1h"}`)},
+			err:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := Filters.Build(FilterContext{Now: now}, tt.config)
+			if tt.err {
+				assert.Error(t, err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, filterNames(filters))
+			}
+		})
+	}
+}
+
+func TestFilterRegistry_Options(t *testing.T) {
+	now := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
+	filters, err := Filters.Build(FilterContext{Now: now}, FiltersConfig{
+		"partition": json.RawMessage(`{"futureTolerance": "1h", "retention": 6}`),
+		"latest":    json.RawMessage(`{"window": "48h"}`),
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, PartitionRangeFilter{Now: now, FutureTolerance: time.Hour, Retention: 6}, filters[0])
+		assert.Equal(t, LatestFilter{After: now.Add(-48 * time.Hour)}, filters[3])
+	}
+}
+
+func TestFilterRegistry_Register(t *testing.T) {
+	r := NewFilterRegistry()
+	custom := &FilterDescriptor{
+		Name: "custom",
+		Factory: func(ctx FilterContext, raw json.RawMessage) (MeasurementsFilter, error) {
+			return NewDedupFilter(), nil
+		},
+	}
+	assert.NoError(t, r.Register(custom))
+	assert.Error(t, r.Register(custom))
+	assert.Len(t, r.List(), 1)
+	filters, err := r.Build(FilterContext{}, nil)
+	if assert.NoError(t, err) {
+		assert.Empty(t, filters)
+	}
+	filters, err = r.Build(FilterContext{}, FiltersConfig{"custom": nil})
+	if assert.NoError(t, err) {
+		assert.Len(t, filters, 1)
+	}
+}
+
+func TestDedupFilter(t *testing.T) {
+	f := NewDedupFilter()
+	m := Measurement{GaugeID: GaugeID{"s", "c"}, Timestamp: unixHTime(1000)}
+	assert.True(t, f.Filter(m))
+	assert.False(t, f.Filter(m))
+	assert.True(t, f.Filter(Measurement{GaugeID: GaugeID{"s", "d"}, Timestamp: unixHTime(1000)}))
+	assert.True(t, f.Filter(Measurement{GaugeID: GaugeID{"s", "c"}, Timestamp: unixHTime(2000)}))
+}
+
+func TestWindowFilter(t *testing.T) {
+	f := WindowFilter{Now: time.Unix(10000, 0), MaxAge: time.Hour, MaxFuture: time.Minute}
+	assert.True(t, f.Filter(Measurement{Timestamp: unixHTime(10000 - 3600)}))
+	assert.False(t, f.Filter(Measurement{Timestamp: unixHTime(10000 - 3601)}))
+	assert.True(t, f.Filter(Measurement{Timestamp: unixHTime(10060)}))
+	assert.False(t, f.Filter(Measurement{Timestamp: unixHTime(10061)}))
+}
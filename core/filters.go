@@ -30,28 +30,44 @@ func formatStats(m map[string]filterStats) string {
 }
 
 // MeasurementsFilter is used to skip unwanted measurements based on code, timestamp, etc...
+// Filters can be defined outside of core and registered in FilterRegistry
 type MeasurementsFilter interface {
-	// Returns true if measurement matches filter's criteria
-	filter(measurement Measurement) bool
-	name() string
+	// Filter returns true if measurement matches filter's criteria
+	Filter(measurement Measurement) bool
+	// Name is used in filter stats
+	Name() string
 }
 
 // FilterMeasurements filters channel of measurements using any number of filters
 // It also supports context cancelation
 func FilterMeasurements(ctx context.Context, in <-chan *Measurement, logger *logrus.Entry, filters ...MeasurementsFilter) <-chan *Measurement {
+	out, _ := FilterMeasurementsWithStats(ctx, in, logger, filters...)
+	return out
+}
+
+// FilterMeasurementsWithStats is same as FilterMeasurements, but it also writes stats of every filter, in order of filters,
+// to returned stats channel once input channel is closed or context is canceled
+func FilterMeasurementsWithStats(ctx context.Context, in <-chan *Measurement, logger *logrus.Entry, filters ...MeasurementsFilter) (<-chan *Measurement, <-chan []FilterStats) {
 	out := make(chan *Measurement)
+	statsCh := make(chan []FilterStats, 1)
 
 	stats := make(map[string]filterStats, len(filters))
 	for _, f := range filters {
-		stats[f.name()] = filterStats{}
+		stats[f.Name()] = filterStats{}
 	}
 
 	go func() {
+		defer close(statsCh)
 		defer close(out)
 		defer func() {
 			if logger != nil {
 				logger.Debugf("filter stats %s", formatStats(stats))
 			}
+			result := make([]FilterStats, len(filters))
+			for i, f := range filters {
+				result[i] = FilterStats{Name: f.Name(), In: stats[f.Name()].inCnt, Out: stats[f.Name()].outCnt}
+			}
+			statsCh <- result
 		}()
 		for {
 			select {
@@ -63,15 +79,15 @@ func FilterMeasurements(ctx context.Context, in <-chan *Measurement, logger *log
 				}
 				accept := true
 				for _, f := range filters {
-					fStats := stats[f.name()]
+					fStats := stats[f.Name()]
 					fStats.incoming()
-					stats[f.name()] = fStats
-					if !f.filter(*m) {
+					stats[f.Name()] = fStats
+					if !f.Filter(*m) {
 						accept = false
 						break
 					} else {
 						fStats.outgoing()
-						stats[f.name()] = fStats
+						stats[f.Name()] = fStats
 					}
 				}
 				if accept {
@@ -84,7 +100,7 @@ func FilterMeasurements(ctx context.Context, in <-chan *Measurement, logger *log
 			}
 		}
 	}()
-	return out
+	return out, statsCh
 }
 
 // LatestFilter returns measurements filter that accepts only measurements that are either
@@ -95,7 +111,8 @@ type LatestFilter struct {
 	After  time.Time
 }
 
-func (f LatestFilter) filter(m Measurement) bool {
+// Filter implements MeasurementsFilter interface
+func (f LatestFilter) Filter(m Measurement) bool {
 	l, ok := f.Latest[m.GaugeID]
 	if ok {
 		return m.Timestamp.After(l.Timestamp.Time)
@@ -103,7 +120,8 @@ func (f LatestFilter) filter(m Measurement) bool {
 	return m.Timestamp.After(f.After)
 }
 
-func (f LatestFilter) name() string {
+// Name implements MeasurementsFilter interface
+func (f LatestFilter) Name() string {
 	return "latest"
 }
 
@@ -112,26 +130,39 @@ type CodesFilter struct {
 	Codes StringSet
 }
 
-func (f CodesFilter) filter(m Measurement) bool {
+// Filter implements MeasurementsFilter interface
+func (f CodesFilter) Filter(m Measurement) bool {
 	return f.Codes.Contains(m.Code)
 }
 
-func (f CodesFilter) name() string {
+// Name implements MeasurementsFilter interface
+func (f CodesFilter) Name() string {
 	return "codes"
 }
 
+// MaxPartitionRetention is number of months of measurements that can be saved
+// partman keeps 13 months of partitions, so one month is left for slow maintenance
+const MaxPartitionRetention = 12
+
 // PartitionRangeFilter filters old measurements from partitions that should already be archived (see 'retention' column of partman.part_config table)
 // It also filters garbage measurements from the future (see 'premake' column of partman.part_config table)
 // If any of such measurements gets saved, partman will throw constraint violation during maintetance (see https://github.com/pgpartman/pg_partman/issues/247)
 // FutureTolerance controls how far in the future a measurement timestamp is allowed; measurements beyond it are treated as upstream bugs.
+// Retention is number of months that partitions are kept, defaults to MaxPartitionRetention
 type PartitionRangeFilter struct {
 	Logger          *logrus.Entry
 	Now             time.Time
 	FutureTolerance time.Duration
+	Retention       int
 }
 
-func (f PartitionRangeFilter) filter(m Measurement) bool {
-	if m.Timestamp.Before(f.Now.AddDate(0, -12, 0)) {
+// Filter implements MeasurementsFilter interface
+func (f PartitionRangeFilter) Filter(m Measurement) bool {
+	retention := f.Retention
+	if retention <= 0 || retention > MaxPartitionRetention {
+		retention = MaxPartitionRetention
+	}
+	if m.Timestamp.Before(f.Now.AddDate(0, -retention, 0)) {
 		if f.Logger != nil {
 			f.Logger.Warnf(
 				"filtered too old measurement: script=%s code=%s timestamp=%s",
@@ -152,6 +183,79 @@ func (f PartitionRangeFilter) filter(m Measurement) bool {
 	return true
 }
 
-func (f PartitionRangeFilter) name() string {
+// Name implements MeasurementsFilter interface
+func (f PartitionRangeFilter) Name() string {
 	return "partition"
 }
+
+// PeriodFilter accepts only measurements with timestamps within [From, To] period
+// It's used during backfill, because upstreams tend to return whole days or pages
+type PeriodFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+// Filter implements MeasurementsFilter interface
+func (f PeriodFilter) Filter(m Measurement) bool {
+	return !m.Timestamp.Before(f.From) && !m.Timestamp.After(f.To)
+}
+
+// Name implements MeasurementsFilter interface
+func (f PeriodFilter) Name() string {
+	return "period"
+}
+
+// DedupFilter accepts only first measurement for each gauge and timestamp
+// Some upstreams return same values multiple times, e.g. when pages overlap
+type DedupFilter struct {
+	seen map[GaugeID]map[int64]struct{}
+}
+
+// NewDedupFilter creates new DedupFilter
+func NewDedupFilter() *DedupFilter {
+	return &DedupFilter{seen: map[GaugeID]map[int64]struct{}{}}
+}
+
+// Filter implements MeasurementsFilter interface
+func (f *DedupFilter) Filter(m Measurement) bool {
+	byTime, ok := f.seen[m.GaugeID]
+	if !ok {
+		byTime = map[int64]struct{}{}
+		f.seen[m.GaugeID] = byTime
+	}
+	ts := m.Timestamp.UnixNano()
+	if _, dup := byTime[ts]; dup {
+		return false
+	}
+	byTime[ts] = struct{}{}
+	return true
+}
+
+// Name implements MeasurementsFilter interface
+func (f *DedupFilter) Name() string {
+	return "dedup"
+}
+
+// WindowFilter accepts only measurements not older than MaxAge and not further in the future than MaxFuture
+// Zero MaxAge or MaxFuture means no limit
+type WindowFilter struct {
+	Now       time.Time
+	MaxAge    time.Duration
+	MaxFuture time.Duration
+}
+
+// Filter implements MeasurementsFilter interface
+func (f WindowFilter) Filter(m Measurement) bool {
+	if f.MaxAge > 0 && m.Timestamp.Before(f.Now.Add(-f.MaxAge)) {
+		return false
+	}
+	if f.MaxFuture > 0 && m.Timestamp.After(f.Now.Add(f.MaxFuture)) {
+		return false
+	}
+	return true
+}
+
+// Name implements MeasurementsFilter interface
+func (f WindowFilter) Name() string {
+	return "window"
+}
@@ -79,7 +79,7 @@ func TestNewLatestFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel() // marks each test case as capable of running in parallel with each other
-			assert.Equal(t, tt.expected, f.filter(tt.input))
+			assert.Equal(t, tt.expected, f.Filter(tt.input))
 		})
 	}
 }
@@ -110,7 +110,30 @@ func TestNewCodesFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel() // marks each test case as capable of running in parallel with each other
-			assert.Equal(t, tt.expected, f.filter(tt.input))
+			assert.Equal(t, tt.expected, f.Filter(tt.input))
+		})
+	}
+}
+
+func TestPeriodFilter(t *testing.T) {
+	f := PeriodFilter{
+		From: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name     string
+		input    time.Time
+		expected bool
+	}{
+		{name: "before", input: time.Date(1999, time.December, 31, 23, 59, 0, 0, time.UTC), expected: false},
+		{name: "from", input: f.From, expected: true},
+		{name: "inside", input: time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC), expected: true},
+		{name: "to", input: f.To, expected: true},
+		{name: "after", input: time.Date(2000, time.January, 2, 0, 1, 0, 0, time.UTC), expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, f.Filter(Measurement{Timestamp: HTime{tt.input}}))
 		})
 	}
 }
@@ -162,7 +185,7 @@ func TestPartitionRangeFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel() // marks each test case as capable of running in parallel with each other
-			assert.Equal(t, tt.expected, f.filter(tt.input))
+			assert.Equal(t, tt.expected, f.Filter(tt.input))
 		})
 	}
 }
@@ -218,6 +241,17 @@ func TestFilterMeasurements(t *testing.T) {
 		actual := <-resCh
 		assert.Equal(t, expected, actual)
 	})
+	t.Run("stats", func(t *testing.T) {
+		ctx := context.Background()
+		gen := GenFromSlice(ctx, input)
+		out, statsCh := FilterMeasurementsWithStats(ctx, gen, nil, fCodes, fLatest)
+		actual := <-SinkToSlice(ctx, out)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, []FilterStats{
+			{Name: "codes", In: 4, Out: 3},
+			{Name: "latest", In: 3, Out: 1},
+		}, <-statsCh)
+	})
 	t.Run("canceled", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		gen := GenFromSlice(ctx, input)
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// GaugeSelector makes job harvest all upstream gauges that match it, instead of gauges listed by hand
+// Empty selector matches all gauges. Gauges must match every given condition
+type GaugeSelector struct {
+	// BBox is [minLongitude, minLatitude, maxLongitude, maxLatitude]. Gauges without location do not match it
+	BBox []float64 `json:"bbox,omitempty"`
+	// Name is regular expression that gauge name must match
+	Name string `json:"name,omitempty"`
+	// Units match gauges that have at least one of them as level, flow or parameter unit, e.g. ["m3/s", "cfs"]
+	Units []string `json:"units,omitempty"`
+}
+
+// Validate returns error if bounding box or name expression are invalid. Nil selector is valid
+func (s *GaugeSelector) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.BBox != nil {
+		if len(s.BBox) != 4 {
+			return fmt.Errorf("bbox must have 4 elements: min longitude, min latitude, max longitude, max latitude")
+		}
+		if s.BBox[0] > s.BBox[2] || s.BBox[1] > s.BBox[3] {
+			return fmt.Errorf("bbox min coordinates must not be greater than max coordinates")
+		}
+	}
+	if _, err := regexp.Compile(s.Name); err != nil {
+		return WrapErr(err, "bad name expression")
+	}
+	return nil
+}
+
+// Select returns gauges map for job description with codes of matching gauges
+// Options of gauges that are present in previous map are kept, other gauges have no options
+func (s *GaugeSelector) Select(gauges []Gauge, previous map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	name, err := regexp.Compile(s.Name)
+	if err != nil {
+		return nil, WrapErr(err, "bad name expression")
+	}
+	result := map[string]json.RawMessage{}
+	for i := range gauges {
+		g := &gauges[i]
+		if !s.inBBox(g) || !name.MatchString(g.Name) || !s.hasUnits(g) {
+			continue
+		}
+		result[g.Code] = previous[g.Code]
+	}
+	return result, nil
+}
+
+func (s *GaugeSelector) inBBox(g *Gauge) bool {
+	if len(s.BBox) != 4 {
+		return true
+	}
+	if g.Location == nil {
+		return false
+	}
+	return g.Location.Longitude >= s.BBox[0] && g.Location.Latitude >= s.BBox[1] &&
+		g.Location.Longitude <= s.BBox[2] && g.Location.Latitude <= s.BBox[3]
+}
+
+func (s *GaugeSelector) hasUnits(g *Gauge) bool {
+	if len(s.Units) == 0 {
+		return true
+	}
+	for _, u := range s.Units {
+		if u == "" {
+			continue
+		}
+		if g.LevelUnit == u || g.FlowUnit == u {
+			return true
+		}
+		for _, pu := range g.ParamUnits {
+			if pu == u {
+				return true
+			}
+		}
+	}
+	return false
+}
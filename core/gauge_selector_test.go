@@ -0,0 +1,67 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaugeSelectorValidate(t *testing.T) {
+	var nilSelector *GaugeSelector
+	assert.NoError(t, nilSelector.Validate())
+	assert.NoError(t, (&GaugeSelector{}).Validate())
+	assert.NoError(t, (&GaugeSelector{BBox: []float64{-10, 40, 5, 60}, Name: "^River"}).Validate())
+	assert.Error(t, (&GaugeSelector{BBox: []float64{-10, 40, 5}}).Validate())
+	assert.Error(t, (&GaugeSelector{BBox: []float64{5, 40, -10, 60}}).Validate())
+	assert.Error(t, (&GaugeSelector{Name: "("}).Validate())
+}
+
+func TestGaugeSelectorSelect(t *testing.T) {
+	gauges := []Gauge{
+		{GaugeID: GaugeID{Code: "a"}, Name: "Thames at Kingston", FlowUnit: "m3/s", Location: &Location{Longitude: -0.3, Latitude: 51.4}},
+		{GaugeID: GaugeID{Code: "b"}, Name: "Thames at Oxford", LevelUnit: "m", Location: &Location{Longitude: -1.2, Latitude: 51.7}},
+		{GaugeID: GaugeID{Code: "c"}, Name: "Severn at Bewdley", FlowUnit: "m3/s"},
+		{GaugeID: GaugeID{Code: "d"}, Name: "Tay at Perth", ParamUnits: map[Parameter]string{"temperature": "degC"}, Location: &Location{Longitude: -3.4, Latitude: 56.4}},
+	}
+	previous := map[string]json.RawMessage{"a": json.RawMessage(`{"foo":1}`), "x": json.RawMessage(`{}`)}
+
+	tests := []struct {
+		name     string
+		selector GaugeSelector
+		expected map[string]json.RawMessage
+	}{
+		{
+			name:     "empty",
+			expected: map[string]json.RawMessage{"a": json.RawMessage(`{"foo":1}`), "b": nil, "c": nil, "d": nil},
+		},
+		{
+			name:     "bbox",
+			selector: GaugeSelector{BBox: []float64{-2, 50, 0, 52}},
+			expected: map[string]json.RawMessage{"a": json.RawMessage(`{"foo":1}`), "b": nil},
+		},
+		{
+			name:     "name",
+			selector: GaugeSelector{Name: "^Thames"},
+			expected: map[string]json.RawMessage{"a": json.RawMessage(`{"foo":1}`), "b": nil},
+		},
+		{
+			name:     "units",
+			selector: GaugeSelector{Units: []string{"m3/s", "degC"}},
+			expected: map[string]json.RawMessage{"a": json.RawMessage(`{"foo":1}`), "c": nil, "d": nil},
+		},
+		{
+			name:     "all conditions",
+			selector: GaugeSelector{BBox: []float64{-2, 50, 0, 52}, Name: "Thames", Units: []string{"m"}},
+			expected: map[string]json.RawMessage{"b": nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.selector.Select(gauges, previous)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
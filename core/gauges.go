@@ -34,6 +34,9 @@ type Gauge struct {
 	LevelUnit string `json:"levelUnit,omitempty"`
 	// Water flow/discharge unit, e.g. "cfs"/"m3/s"
 	FlowUnit string `json:"flowUnit,omitempty"`
+	// Units of other parameters, such as water temperature, keyed by parameter code, e.g. {"temperature": "degC"}
+	// Measurements of this gauge are expected to contain only params listed here
+	ParamUnits map[Parameter]string `json:"paramUnits,omitempty" ts_type:"{ [key: string]: string }"`
 	// Station location, if known
 	Location *Location `json:"location,omitempty"`
 	// IANA time zone identifier, one of listed in https://github.com/evansiroky/timezone-boundary-builder in timezone-names.json
@@ -57,3 +57,14 @@ func (m HarvestMode) TSName() string {
 type BatchableOptions interface {
 	GetBatchSize() int
 }
+
+// GaugeOptionsBatchable is implemented by options of batched scripts that honour options of every gauge in batch
+// Such scripts get options of every gauge in GaugeOptions of HarvestSpec
+// Gauges of other batched scripts are grouped into batches by their options, so that every gauge in batch has same options
+type GaugeOptionsBatchable interface {
+	BatchableOptions
+	HonoursGaugeOptions() bool
+}
+
+// GaugeOptions maps gauge codes to script options merged from job-level and gauge-level options
+type GaugeOptions map[string]interface{}
@@ -0,0 +1,24 @@
+package core
+
+// PoolStats describes harvests that are running or waiting for free slot in one of scheduler's worker pools
+type PoolStats struct {
+	// Max number of harvests running at the same time, 0 means no limit
+	Limit int `json:"limit"`
+	// Number of harvests running now
+	Running int `json:"running"`
+	// Number of harvests waiting for free slot now
+	Queued int `json:"queued"`
+	// Number of harvests started since gorge start
+	Started int64 `json:"started"`
+	// Average time that harvests waited for free slot
+	AvgWait Duration `json:"avgWait" ts_type:"string"`
+	// Longest time that harvest waited for free slot
+	MaxWait Duration `json:"maxWait" ts_type:"string"`
+}
+
+// HarvestStats describes scheduler's worker pools: global one, and pools of scripts and upstream hosts
+type HarvestStats struct {
+	Global  PoolStats            `json:"global"`
+	Scripts map[string]PoolStats `json:"scripts"`
+	Hosts   map[string]PoolStats `json:"hosts"`
+}
@@ -0,0 +1,36 @@
+package core
+
+// FilterStats contains number of measurements that came to filter and passed it during one harvest
+type FilterStats struct {
+	Name string `json:"name"`
+	In   int    `json:"in"`
+	Out  int    `json:"out"`
+}
+
+// JobRun is record of one harvest of job, or of one gauge of one-by-one job
+// Unlike Status, which is overwritten by every harvest, runs are kept in history
+type JobRun struct {
+	ID    int64  `json:"id"`
+	JobID string `json:"jobId"`
+	// Code is set for harvests of one gauge of one-by-one job
+	Code string `json:"code,omitempty"`
+	// Codes are set for harvests of batch of batched job
+	Codes  []string `json:"codes,omitempty"`
+	Script string   `json:"script"`
+	// When harvest started
+	StartedAt HTime `json:"startedAt" ts_type:"string"`
+	// How long harvest took, including time spent waiting for free worker
+	Duration Duration `json:"duration" ts_type:"string"`
+	// Number of retry, 0 for scheduled runs
+	Attempt int `json:"attempt,omitempty"`
+	// Number of measurements received from script
+	Harvested int `json:"harvested"`
+	// Number of measurements before and after each filter, in order of filters
+	Filters []FilterStats `json:"filters"`
+	// Number of measurements saved to database
+	Saved int `json:"saved"`
+	// Number of measurements sent to cache
+	Cached int `json:"cached"`
+	// Error, if harvest failed
+	Error string `json:"error,omitempty"`
+}
@@ -1,8 +1,10 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -17,35 +19,108 @@ type JobDescription struct {
 	// a map with keys being gauge codes and value being pieces of json representing harvest options for that gauge
 	// pass `{}` or `null` if no options are given for the gauge
 	Gauges map[string]json.RawMessage `json:"gauges" structs:"codes" ts_type:"{[key: string]: any} | null"`
+	// harvest all upstream gauges that match selector. Gauges are selected when job is added and refreshed periodically,
+	// options of gauges that are already in the job are kept
+	AllGauges *GaugeSelector `json:"allGauges,omitempty" structs:"allGauges,omitempty"`
 	// cron expression, ignored for OneByOne scripts. AllAtOnce script will run on this cron schedule
 	Cron string `json:"cron" structs:"cron"`
+	// harvest one-by-one and batched gauges as often as they're updated in upstream, within bounds. Gauges are harvested every hour if not set
+	Adaptive *AdaptiveSchedule `json:"adaptive,omitempty" structs:"adaptive,omitempty"`
 	// harvest options for the entire script. For example, upstream credentials
 	Options json.RawMessage `json:"options" structs:"options,omitempty" ts_type:"{[key: string]: any} | null"`
+	// how to save measurements that are already stored. Use "revise" for upstreams that correct provisional values later
+	SaveMode SaveMode `json:"saveMode,omitempty" structs:"saveMode,omitempty"`
+	// enables, disables or tunes measurement filters, see Filters registry
+	Filters FiltersConfig `json:"filters,omitempty" structs:"filters,omitempty" ts_type:"{[key: string]: any} | null"`
+	// how to retry failed harvests before next scheduled run. Failed harvests are not retried if not set
+	Retry *RetryPolicy `json:"retry,omitempty" structs:"retry,omitempty"`
+	// harvest is cancelled if it takes longer than this. Server default is used if not set
+	Timeout *Duration `json:"timeout,omitempty" structs:"timeout,omitempty" ts_type:"string | null"`
+	// paused jobs are kept, but not harvested until resumed
+	Paused bool `json:"paused,omitempty" structs:"paused,omitempty"`
 	// When used as input this must be nil
 	Status *Status `json:"status,omitempty"`
 }
 
+// JobProblem is one problem found in job description by validation
+type JobProblem struct {
+	// Field is json name of job description field that has the problem, e.g. "cron" or "gauges"
+	Field string `json:"field"`
+	// Code is gauge code, if the problem concerns one gauge of the job
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// JobValidation is result of job description dry-run
+type JobValidation struct {
+	Valid    bool         `json:"valid"`
+	Problems []JobProblem `json:"problems"`
+}
+
 // JobScheduler is responsible for running harvest jobs on schedule
 type JobScheduler interface {
 	Start()
 	Stop()
 	AddJob(description JobDescription) error
+	// ValidateJob runs all checks that AddJob does, without scheduling anything
+	// It also checks that job's gauges can be found in upstream. Returns empty list if job is valid
+	ValidateJob(ctx context.Context, description JobDescription) []JobProblem
+	// SelectGauges returns copy of job description which gauges are upstream gauges matching its AllGauges selector
+	SelectGauges(ctx context.Context, description JobDescription) (JobDescription, error)
 	DeleteJob(jobID string) error
+	// UpdateJob replaces scheduled job with new description. If new description is invalid, old job keeps running
+	UpdateJob(description JobDescription) error
+	// PauseJob detaches cron entries of the job, so it's not harvested until resumed
+	PauseJob(jobID string) error
+	// ResumeJob reattaches cron entries of paused job, keeping their schedule
+	// Jobs that were paused before scheduler was started are scheduled anew
+	ResumeJob(description JobDescription) error
+	// RunJob harvests job (or only one gauge of it, if code is not empty) immediately and returns status of this run
+	RunJob(jobID, code string) (*Status, error)
+	// HarvestStats returns number of running and queued harvests and their wait times
+	HarvestStats() HarvestStats
 	// ListNext returns map where values are times when scripts will run next time
 	// If jobID is empty, ListNext lists next times for all running scripts. And map keys are script ids
 	// If jobID is not empty, this will return next times for all codes of this one-by-one job, and map keys are gauge codes
 	ListNext(jobID string) map[string]HTime
+	// Backfill starts harvesting historical measurements of the job in background and returns its initial status
+	// Job's script must implement Backfiller interface
+	Backfill(job JobDescription, req BackfillRequest) (*BackfillStatus, error)
+	// GetBackfill returns status of last backfill of the job, or nil if job was never backfilled since start
+	GetBackfill(jobID string) *BackfillStatus
+}
+
+// Problems returns problems of job description that can be found without script registry
+func (j *JobDescription) Problems() []JobProblem {
+	var problems []JobProblem
+	if _, err := uuid.Parse(j.ID); err != nil {
+		problems = append(problems, JobProblem{Field: "id", Message: "job id must be valid uuid"})
+	}
+	if err := j.SaveMode.Validate(); err != nil {
+		problems = append(problems, JobProblem{Field: "saveMode", Message: fmt.Sprintf("invalid save mode: %v", err)})
+	}
+	if err := Filters.Validate(j.Filters); err != nil {
+		problems = append(problems, JobProblem{Field: "filters", Message: fmt.Sprintf("invalid filters: %v", err)})
+	}
+	if err := j.Retry.Validate(); err != nil {
+		problems = append(problems, JobProblem{Field: "retry", Message: fmt.Sprintf("invalid retry policy: %v", err)})
+	}
+	if j.Timeout != nil && j.Timeout.Duration <= 0 {
+		problems = append(problems, JobProblem{Field: "timeout", Message: "job timeout must be positive"})
+	}
+	if err := j.Adaptive.Validate(); err != nil {
+		problems = append(problems, JobProblem{Field: "adaptive", Message: fmt.Sprintf("invalid adaptive schedule: %v", err)})
+	}
+	if err := j.AllGauges.Validate(); err != nil {
+		problems = append(problems, JobProblem{Field: "allGauges", Message: fmt.Sprintf("invalid gauge selector: %v", err)})
+	}
+	return problems
 }
 
 // Bind implements go-chi Binder interface
 func (j *JobDescription) Bind(r *http.Request) error {
-	_, err := uuid.Parse(j.ID)
-	if err != nil {
-		e := &Error{
-			Err: nil,
-			Msg: "job id must be valid uuid",
-		}
-		return e.With("jobId", j.ID)
+	if problems := j.Problems(); len(problems) > 0 {
+		return (&Error{Msg: problems[0].Message}).With("jobId", j.ID).With("field", problems[0].Field)
 	}
 	return nil
 }
@@ -72,6 +147,8 @@ type Status struct {
 	Error string `json:"error,omitempty"`
 	// Number of measurements harvested during last execution (0 in case of error)
 	Count int `json:"count"`
+	// Number of retries made after last scheduled execution failed
+	Retries int `json:"retries,omitempty"`
 	// When did this job run successfully (collected some measurements) last time
 	// Is less or equal than Timestamp, or nil pointer if never ran successfully
 	LastSuccess *HTime `json:"lastSuccess,omitempty" ts_type:"string"`
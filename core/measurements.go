@@ -60,6 +60,20 @@ type Measurement struct {
 	Level nulltype.NullFloat64 `json:"level" ts_type:"number | null"`
 	// Flow is null when gauge doesn't provide it or is temporary broken
 	Flow nulltype.NullFloat64 `json:"flow" ts_type:"number | null"`
+	// Params are values of other parameters, such as water temperature, keyed by parameter code
+	// Units are given in gauge's ParamUnits
+	Params Params `json:"params,omitempty" ts_type:"{ [key: string]: number }"`
+	// Quality is upstream's data quality code, e.g. "e" (estimated) in USGS. Empty if upstream doesn't provide it
+	Quality string `json:"quality,omitempty"`
+	// Provisional is true when upstream says that values are not approved yet and can be revised later
+	Provisional bool `json:"provisional,omitempty"`
+	// FlowDerived is true when flow is not reported by upstream, but calculated from level using rating curve
+	FlowDerived bool `json:"flowDerived,omitempty" db:"flow_derived"`
+}
+
+// HasValues returns true if measurement has at least one value: level, flow or any of params
+func (m *Measurement) HasValues() bool {
+	return m.Level.Valid() || m.Flow.Valid() || len(m.Params) > 0
 }
 
 // Measurements is helper for sorting
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// OutliersOptionsKey is key of outlier filter options in job-level and gauge-level options
+// It's reserved, so scripts cannot have option with this name
+const OutliersOptionsKey = "outliers"
+
+// OutlierOptions configure outlier filter. They can be set in job options and overridden in gauge options
+type OutlierOptions struct {
+	MinLevel           *float64 `json:"minLevel,omitempty" desc:"Levels below this value are rejected"`
+	MaxLevel           *float64 `json:"maxLevel,omitempty" desc:"Levels above this value are rejected"`
+	MinFlow            *float64 `json:"minFlow,omitempty" desc:"Flows below this value are rejected"`
+	MaxFlow            *float64 `json:"maxFlow,omitempty" desc:"Flows above this value are rejected"`
+	RejectNegativeFlow bool     `json:"rejectNegativeFlow,omitempty" desc:"Reject negative flows. Do not set it for tidal gauges"`
+	MaxRate            float64  `json:"maxRate,omitempty" desc:"Maximal relative change of level or flow per hour compared to latest measurement, e.g. 10 rejects values that grow more than 11 times within an hour"`
+}
+
+// OutlierConfig is outlier filter options of one job
+type OutlierConfig struct {
+	// Default options are used for gauges that have no own options
+	Default *OutlierOptions
+	// Gauges options are job options overridden by gauge options, keyed by gauge code
+	Gauges map[string]OutlierOptions
+}
+
+// Get returns options of the gauge
+func (c OutlierConfig) Get(code string) OutlierOptions {
+	if opts, ok := c.Gauges[code]; ok {
+		return opts
+	}
+	if c.Default != nil {
+		return *c.Default
+	}
+	return OutlierOptions{}
+}
+
+// ParseOutlierConfig reads outlier options from job options and gauge options
+// Returns zero config if none of them has outlier options
+func ParseOutlierConfig(options json.RawMessage, gauges map[string]json.RawMessage) (OutlierConfig, error) {
+	var result OutlierConfig
+	defaultRaw, err := outliersOptions(options)
+	if err != nil {
+		return result, err
+	}
+	if defaultRaw != nil {
+		result.Default = &OutlierOptions{}
+		if err := json.Unmarshal(defaultRaw, result.Default); err != nil {
+			return result, WrapErr(err, "failed to unmarshal outlier options")
+		}
+	}
+	for code, gOpts := range gauges {
+		raw, err := outliersOptions(gOpts)
+		if err != nil {
+			return result, WrapErr(err, "failed to parse gauge options").With("code", code)
+		}
+		if raw == nil {
+			continue
+		}
+		// decode job options again instead of copying default, so that bounds pointers are not shared
+		var opts OutlierOptions
+		if defaultRaw != nil {
+			json.Unmarshal(defaultRaw, &opts) // nolint:errcheck
+		}
+		if err := json.Unmarshal(raw, &opts); err != nil {
+			return result, WrapErr(err, "failed to unmarshal outlier options").With("code", code)
+		}
+		if result.Gauges == nil {
+			result.Gauges = map[string]OutlierOptions{}
+		}
+		result.Gauges[code] = opts
+	}
+	return result, nil
+}
+
+// outliersOptions returns raw outlier options from options object, or nil if they're not present
+func outliersOptions(options json.RawMessage) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(options)) == 0 {
+		return nil, nil
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(options, &obj); err != nil {
+		return nil, WrapErr(err, "failed to unmarshal options")
+	}
+	raw, ok := obj[OutliersOptionsKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	return raw, nil
+}
+
+// withoutOutliersOptions removes outlier options from options object, so that the rest can be decoded into script options
+func withoutOutliersOptions(options json.RawMessage) (json.RawMessage, error) {
+	if !bytes.Contains(options, []byte(OutliersOptionsKey)) {
+		return options, nil
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(options, &obj); err != nil || obj == nil {
+		// let script options decoder report the error
+		return options, nil
+	}
+	if _, ok := obj[OutliersOptionsKey]; !ok {
+		return options, nil
+	}
+	delete(obj, OutliersOptionsKey)
+	return json.Marshal(obj)
+}
+
+// OutlierFilter rejects garbage values: values out of gauge bounds, negative flows and sudden spikes compared to latest measurement of the gauge
+// Every check is opt-in, so filter with zero config accepts all measurements
+type OutlierFilter struct {
+	Logger *logrus.Entry
+	Config OutlierConfig
+	Latest map[GaugeID]Measurement
+}
+
+// Filter implements MeasurementsFilter interface
+func (f OutlierFilter) Filter(m Measurement) bool {
+	opts := f.Config.Get(m.Code)
+	reason := ""
+	if m.Level.Valid() && !opts.inBounds(m.Level.Float64Value(), opts.MinLevel, opts.MaxLevel) {
+		reason = "level out of bounds"
+	} else if m.Flow.Valid() && !opts.inBounds(m.Flow.Float64Value(), opts.MinFlow, opts.MaxFlow) {
+		reason = "flow out of bounds"
+	} else if m.Flow.Valid() && m.Flow.Float64Value() < 0 && opts.RejectNegativeFlow {
+		reason = "negative flow"
+	} else if latest, ok := f.Latest[m.GaugeID]; ok && opts.MaxRate > 0 {
+		hours := math.Max(1, math.Abs(m.Timestamp.Sub(latest.Timestamp.Time).Hours()))
+		if m.Level.Valid() && latest.Level.Valid() && isSpike(m.Level.Float64Value(), latest.Level.Float64Value(), opts.MaxRate*hours) {
+			reason = "level spike"
+		} else if m.Flow.Valid() && latest.Flow.Valid() && isSpike(m.Flow.Float64Value(), latest.Flow.Float64Value(), opts.MaxRate*hours) {
+			reason = "flow spike"
+		}
+	}
+	if reason == "" {
+		return true
+	}
+	if f.Logger != nil {
+		f.Logger.Warnf(
+			"filtered outlier measurement (%s): script=%s code=%s timestamp=%s level=%v flow=%v",
+			reason, m.Script, m.Code, m.Timestamp.Time.UTC().Format(time.RFC3339), m.Level, m.Flow,
+		)
+	}
+	return false
+}
+
+// Name implements MeasurementsFilter interface
+func (f OutlierFilter) Name() string {
+	return "outliers"
+}
+
+func (o OutlierOptions) inBounds(v float64, min, max *float64) bool {
+	return (min == nil || v >= *min) && (max == nil || v <= *max)
+}
+
+// isSpike returns true if relative change between latest and current value exceeds maxChange
+func isSpike(value, latest, maxChange float64) bool {
+	if latest == 0 {
+		return false
+	}
+	return math.Abs(value-latest)/math.Abs(latest) > maxChange
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mattn/go-nulltype"
+	"github.com/stretchr/testify/assert"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestOutlierFilter(t *testing.T) {
+	f := OutlierFilter{
+		Config: OutlierConfig{
+			Default: &OutlierOptions{MinLevel: floatPtr(-10), MaxFlow: floatPtr(1000), RejectNegativeFlow: true, MaxRate: 10},
+			Gauges: map[string]OutlierOptions{
+				"a001": {},
+			},
+		},
+		Latest: map[GaugeID]Measurement{
+			{"all_at_once", "a000"}: {
+				GaugeID:   GaugeID{"all_at_once", "a000"},
+				Timestamp: unixHTime(36000),
+				Level:     nulltype.NullFloat64Of(1),
+				Flow:      nulltype.NullFloat64Of(10),
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    Measurement
+		expected bool
+	}{
+		{
+			name: "good",
+			input: Measurement{
+				GaugeID:   GaugeID{"all_at_once", "a000"},
+				Timestamp: unixHTime(39600),
+				Level:     nulltype.NullFloat64Of(2),
+				Flow:      nulltype.NullFloat64Of(20),
+			},
+			expected: true,
+		},
+		{
+			name: "sentinel level",
+			input: Measurement{
+				GaugeID:   GaugeID{"all_at_once", "a000"},
+				Timestamp: unixHTime(39600),
+				Level:     nulltype.NullFloat64Of(-9999),
+			},
+			expected: false,
+		},
+		{
+			name: "flow above max",
+			input: Measurement{
+				GaugeID:   GaugeID{"all_at_once", "a002"},
+				Timestamp: unixHTime(39600),
+				Flow:      nulltype.NullFloat64Of(1001),
+			},
+			expected: false,
+		},
+		{
+			name: "negative flow",
+			input: Measurement{
+				GaugeID:   GaugeID{"all_at_once", "a002"},
+				Timestamp: unixHTime(39600),
+				Flow:      nulltype.NullFloat64Of(-1),
+			},
+			expected: false,
+		},
+		{
+			name: "allowed negative flow",
+			input: Measurement{
+				GaugeID:   GaugeID{"all_at_once", "a001"},
+				Timestamp: unixHTime(39600),
+				Flow:      nulltype.NullFloat64Of(-1),
+			},
+			expected: true,
+		},
+		{
+			name: "flow spike",
+			input: Measurement{
+				GaugeID:   GaugeID{"all_at_once", "a000"},
+				Timestamp: unixHTime(39600),
+				Flow:      nulltype.NullFloat64Of(1000),
+			},
+			expected: false,
+		},
+		{
+			name: "flow grows slowly",
+			input: Measurement{
+				GaugeID:   GaugeID{"all_at_once", "a000"},
+				Timestamp: unixHTime(36000 + 20*3600),
+				Flow:      nulltype.NullFloat64Of(1000),
+			},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, f.Filter(tt.input))
+		})
+	}
+}
+
+func TestOutlierFilterDefaults(t *testing.T) {
+	f := OutlierFilter{}
+	assert.True(t, f.Filter(Measurement{GaugeID: GaugeID{"s", "c"}, Level: nulltype.NullFloat64Of(-9999), Flow: nulltype.NullFloat64Of(100)}))
+	assert.True(t, f.Filter(Measurement{GaugeID: GaugeID{"s", "c"}, Flow: nulltype.NullFloat64Of(-100)}), "negative flows are accepted unless rejected explicitly")
+}
+
+func TestParseOutlierConfig(t *testing.T) {
+	config, err := ParseOutlierConfig(
+		json.RawMessage(`{"version": 2, "outliers": {"maxFlow": 100, "maxRate": 5}}`),
+		map[string]json.RawMessage{
+			"g000": nil,
+			"g001": json.RawMessage(`{}`),
+			"g002": json.RawMessage(`{"outliers": {"maxFlow": 200}}`),
+		},
+	)
+	if assert.NoError(t, err) {
+		assert.Equal(t, OutlierOptions{MaxFlow: floatPtr(100), MaxRate: 5}, config.Get("g000"))
+		assert.Equal(t, OutlierOptions{MaxFlow: floatPtr(100), MaxRate: 5}, config.Get("g001"))
+		assert.Equal(t, OutlierOptions{MaxFlow: floatPtr(200), MaxRate: 5}, config.Get("g002"))
+	}
+
+	config, err = ParseOutlierConfig(json.RawMessage(`{"version": 2}`), map[string]json.RawMessage{"g000": json.RawMessage(`{}`)})
+	if assert.NoError(t, err) {
+		assert.Equal(t, OutlierConfig{}, config)
+	}
+
+	_, err = ParseOutlierConfig(json.RawMessage(`{"outliers": {"maxFlow": "foo"}}`), nil)
+	assert.Error(t, err)
+}
+
+func TestParseJSONOptionsIgnoresOutliers(t *testing.T) {
+	registry := setup()
+	_, err := registry.ParseJSONOptions("all_at_once", json.RawMessage(`{"outliers": {"maxFlow": 100}}`))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Parameter is code of physical value measured by gauge, in addition to water level and flow
+type Parameter string
+
+const (
+	// Temperature is water temperature
+	Temperature Parameter = "temperature"
+	// Rainfall is amount of precipitation
+	Rainfall Parameter = "rainfall"
+	// Turbidity is water turbidity
+	Turbidity Parameter = "turbidity"
+	// Velocity is surface water velocity
+	Velocity Parameter = "velocity"
+)
+
+// Parameters is list of all known parameters
+var Parameters = []Parameter{Temperature, Rainfall, Turbidity, Velocity}
+
+// Valid returns true if parameter is one of known parameters
+func (p Parameter) Valid() bool {
+	for _, k := range Parameters {
+		if k == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Params holds values of extra parameters of single measurement
+// Missing key means that gauge doesn't provide this parameter or it's temporary broken
+type Params map[Parameter]float64
+
+// Scan implements sql.Scanner interface
+func (p *Params) Scan(src interface{}) error {
+	var raw []byte
+	switch src := src.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		raw = src
+	case string:
+		raw = []byte(src)
+	default:
+		return fmt.Errorf("failed to scan Params: invalid source of type %T: %v", src, src)
+	}
+	if len(raw) == 0 {
+		*p = nil
+		return nil
+	}
+	var res Params
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return fmt.Errorf("failed to scan Params: %w", err)
+	}
+	if len(res) == 0 {
+		res = nil
+	}
+	*p = res
+	return nil
+}
+
+// Value implements driver Valuer interface.
+// Empty params are stored as NULL
+func (p Params) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
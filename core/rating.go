@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/mattn/go-nulltype"
+)
+
+// PowerLaw is rating curve equation Q = C * (h - H0) ^ B
+type PowerLaw struct {
+	C  float64 `json:"c"`
+	H0 float64 `json:"h0"`
+	B  float64 `json:"b"`
+}
+
+// RatingPoint is point of rating table
+type RatingPoint struct {
+	Level float64 `json:"level"`
+	Flow  float64 `json:"flow"`
+}
+
+// RatingCurve converts gauge levels to flows. It's defined either by power law or by rating table with linear interpolation between points
+// Levels and flows are in gauge units
+type RatingCurve struct {
+	ID int64 `json:"id"`
+	GaugeID
+	// Curve is valid for measurements within [ValidFrom, ValidTo) period, nil means unbounded
+	ValidFrom *HTime        `json:"validFrom,omitempty" ts_type:"string"`
+	ValidTo   *HTime        `json:"validTo,omitempty" ts_type:"string"`
+	Power     *PowerLaw     `json:"power,omitempty"`
+	Table     []RatingPoint `json:"table,omitempty"`
+}
+
+// Bind implements go-chi Binder interface
+func (c *RatingCurve) Bind(r *http.Request) error {
+	if c.Script == "" || c.Code == "" {
+		return NewErr(errors.New("script and code are required"))
+	}
+	if c.ValidFrom != nil && c.ValidTo != nil && !c.ValidFrom.Before(c.ValidTo.Time) {
+		return NewErr(errors.New("validFrom must be before validTo"))
+	}
+	if (c.Power == nil) == (len(c.Table) == 0) {
+		return NewErr(errors.New("either power or table must be set"))
+	}
+	if c.Power != nil && (c.Power.C <= 0 || c.Power.B <= 0) {
+		return NewErr(errors.New("power law coefficients c and b must be positive"))
+	}
+	if len(c.Table) > 0 {
+		if len(c.Table) < 2 {
+			return NewErr(errors.New("rating table must have at least 2 points"))
+		}
+		for i := 1; i < len(c.Table); i++ {
+			if c.Table[i].Level <= c.Table[i-1].Level || c.Table[i].Flow < c.Table[i-1].Flow {
+				return NewErr(errors.New("rating table levels must be increasing and flows must not decrease")).With("point", i)
+			}
+		}
+	}
+	return nil
+}
+
+// IsValid returns true if curve can be applied to measurement with given timestamp
+func (c *RatingCurve) IsValid(t HTime) bool {
+	return (c.ValidFrom == nil || !t.Before(c.ValidFrom.Time)) && (c.ValidTo == nil || t.Before(c.ValidTo.Time))
+}
+
+// Flow returns flow for given level. Second value is false if level is out of rating table range
+func (c *RatingCurve) Flow(level float64) (float64, bool) {
+	if c.Power != nil {
+		if level <= c.Power.H0 {
+			return 0, true
+		}
+		return c.Power.C * math.Pow(level-c.Power.H0, c.Power.B), true
+	}
+	n := len(c.Table)
+	if n < 2 || level < c.Table[0].Level || level > c.Table[n-1].Level {
+		return 0, false
+	}
+	i := sort.Search(n, func(i int) bool { return c.Table[i].Level >= level })
+	if c.Table[i].Level == level {
+		return c.Table[i].Flow, true
+	}
+	lo, hi := c.Table[i-1], c.Table[i]
+	return lo.Flow + (hi.Flow-lo.Flow)*(level-lo.Level)/(hi.Level-lo.Level), true
+}
+
+// RatingCurves are rating curves of one script, keyed by gauge code
+type RatingCurves map[string][]RatingCurve
+
+// NewRatingCurves groups rating curves by gauge code
+func NewRatingCurves(curves []RatingCurve) RatingCurves {
+	result := RatingCurves{}
+	for _, c := range curves {
+		result[c.Code] = append(result[c.Code], c)
+	}
+	return result
+}
+
+// Find returns rating curve of gauge that is valid at given time, or nil
+// If multiple curves are valid, the one that became valid most recently wins
+func (rc RatingCurves) Find(code string, t HTime) *RatingCurve {
+	var result *RatingCurve
+	for i := range rc[code] {
+		c := &rc[code][i]
+		if !c.IsValid(t) {
+			continue
+		}
+		if result == nil || result.ValidFrom == nil || (c.ValidFrom != nil && c.ValidFrom.After(result.ValidFrom.Time)) {
+			result = c
+		}
+	}
+	return result
+}
+
+// DeriveFlows sets flow of measurements that have level but no flow, using rating curves
+// Derived flows are marked with FlowDerived flag. Flows reported by upstream are never replaced
+// It supports context cancelation
+func DeriveFlows(ctx context.Context, in <-chan *Measurement, curves RatingCurves) <-chan *Measurement {
+	if len(curves) == 0 {
+		return in
+	}
+	out := make(chan *Measurement)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m, ok := <-in:
+				if !ok {
+					return
+				}
+				if !m.Flow.Valid() && m.Level.Valid() {
+					if c := curves.Find(m.Code, m.Timestamp); c != nil {
+						if flow, ok := c.Flow(m.Level.Float64Value()); ok {
+							m.Flow = nulltype.NullFloat64Of(Precision(flow, 3))
+							m.FlowDerived = true
+						}
+					}
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- m:
+				}
+			}
+		}
+	}()
+	return out
+}
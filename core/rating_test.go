@@ -0,0 +1,109 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-nulltype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatingCurve_Flow(t *testing.T) {
+	table := RatingCurve{Table: []RatingPoint{{Level: 1, Flow: 10}, {Level: 2, Flow: 30}, {Level: 4, Flow: 50}}}
+	power := RatingCurve{Power: &PowerLaw{C: 10, H0: 1, B: 2}}
+	tests := []struct {
+		name  string
+		curve RatingCurve
+		level float64
+		flow  float64
+		ok    bool
+	}{
+		{name: "table point", curve: table, level: 2, flow: 30, ok: true},
+		{name: "table interpolation", curve: table, level: 3, flow: 40, ok: true},
+		{name: "table first point", curve: table, level: 1, flow: 10, ok: true},
+		{name: "table below range", curve: table, level: 0.5, ok: false},
+		{name: "table above range", curve: table, level: 5, ok: false},
+		{name: "power law", curve: power, level: 3, flow: 40, ok: true},
+		{name: "power law below h0", curve: power, level: 0.5, flow: 0, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flow, ok := tt.curve.Flow(tt.level)
+			assert.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.flow, flow, 0.0001)
+		})
+	}
+}
+
+func TestRatingCurve_Bind(t *testing.T) {
+	id := GaugeID{Script: "s", Code: "c"}
+	from, to := HTime{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, HTime{Time: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name  string
+		curve RatingCurve
+		valid bool
+	}{
+		{name: "power law", curve: RatingCurve{GaugeID: id, Power: &PowerLaw{C: 1, B: 1}}, valid: true},
+		{name: "table", curve: RatingCurve{GaugeID: id, ValidFrom: &from, ValidTo: &to, Table: []RatingPoint{{0, 0}, {1, 1}}}, valid: true},
+		{name: "no gauge", curve: RatingCurve{Power: &PowerLaw{C: 1, B: 1}}},
+		{name: "no curve", curve: RatingCurve{GaugeID: id}},
+		{name: "both curves", curve: RatingCurve{GaugeID: id, Power: &PowerLaw{C: 1, B: 1}, Table: []RatingPoint{{0, 0}, {1, 1}}}},
+		{name: "bad power law", curve: RatingCurve{GaugeID: id, Power: &PowerLaw{C: 0, B: 1}}},
+		{name: "short table", curve: RatingCurve{GaugeID: id, Table: []RatingPoint{{0, 0}}}},
+		{name: "unsorted table", curve: RatingCurve{GaugeID: id, Table: []RatingPoint{{1, 1}, {0, 0}}}},
+		{name: "bad period", curve: RatingCurve{GaugeID: id, ValidFrom: &to, ValidTo: &from, Power: &PowerLaw{C: 1, B: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.curve.Bind(nil)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRatingCurves_Find(t *testing.T) {
+	t2020, t2021 := HTime{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, HTime{Time: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	curves := NewRatingCurves([]RatingCurve{
+		{ID: 1, GaugeID: GaugeID{Script: "s", Code: "a"}, ValidTo: &t2021},
+		{ID: 2, GaugeID: GaugeID{Script: "s", Code: "a"}, ValidFrom: &t2020},
+		{ID: 3, GaugeID: GaugeID{Script: "s", Code: "a"}, ValidFrom: &t2021},
+	})
+	find := func(code string, t time.Time) int64 {
+		if c := curves.Find(code, HTime{Time: t}); c != nil {
+			return c.ID
+		}
+		return 0
+	}
+	assert.Equal(t, int64(1), find("a", time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, int64(2), find("a", time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, int64(3), find("a", time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, int64(0), find("b", time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestDeriveFlows(t *testing.T) {
+	ctx := context.Background()
+	curves := NewRatingCurves([]RatingCurve{
+		{GaugeID: GaugeID{Script: "s", Code: "a"}, Table: []RatingPoint{{0, 0}, {1, 10}}},
+	})
+	in := GenFromSlice(ctx, []Measurement{
+		{GaugeID: GaugeID{Script: "s", Code: "a"}, Level: nulltype.NullFloat64Of(0.5)},
+		{GaugeID: GaugeID{Script: "s", Code: "a"}, Level: nulltype.NullFloat64Of(0.5), Flow: nulltype.NullFloat64Of(7)},
+		{GaugeID: GaugeID{Script: "s", Code: "a"}, Level: nulltype.NullFloat64Of(2)},
+		{GaugeID: GaugeID{Script: "s", Code: "b"}, Level: nulltype.NullFloat64Of(0.5)},
+	})
+	var actual []Measurement
+	for m := range DeriveFlows(ctx, in, curves) {
+		actual = append(actual, *m)
+	}
+	assert.Equal(t, []Measurement{
+		{GaugeID: GaugeID{Script: "s", Code: "a"}, Level: nulltype.NullFloat64Of(0.5), Flow: nulltype.NullFloat64Of(5), FlowDerived: true},
+		{GaugeID: GaugeID{Script: "s", Code: "a"}, Level: nulltype.NullFloat64Of(0.5), Flow: nulltype.NullFloat64Of(7)},
+		{GaugeID: GaugeID{Script: "s", Code: "a"}, Level: nulltype.NullFloat64Of(2)},
+		{GaugeID: GaugeID{Script: "s", Code: "b"}, Level: nulltype.NullFloat64Of(0.5)},
+	}, actual)
+}
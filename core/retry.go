@@ -0,0 +1,51 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxRetryAttempts limits number of retries of failed harvest
+const MaxRetryAttempts = 10
+
+// RetryPolicy describes how failed harvests are retried before next scheduled run
+type RetryPolicy struct {
+	// Attempts is maximal number of retries after failed harvest
+	Attempts int `json:"attempts"`
+	// Backoff is delay before first retry. It's doubled for every next retry
+	Backoff Duration `json:"backoff" ts_type:"string"`
+	// MaxBackoff limits delay between retries. No limit if zero
+	MaxBackoff Duration `json:"maxBackoff" ts_type:"string"`
+}
+
+// Validate returns error if retry policy is invalid. Nil policy is valid and means no retries
+func (p *RetryPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.Attempts < 0 || p.Attempts > MaxRetryAttempts {
+		return fmt.Errorf("retry attempts must be between 0 and %d", MaxRetryAttempts)
+	}
+	if p.Attempts > 0 && p.Backoff.Duration <= 0 {
+		return fmt.Errorf("retry backoff must be positive")
+	}
+	if p.MaxBackoff.Duration < 0 {
+		return fmt.Errorf("max retry backoff must not be negative")
+	}
+	return nil
+}
+
+// Delay returns delay before given retry attempt, starting with 1
+func (p *RetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.Backoff.Duration
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxBackoff.Duration > 0 && delay >= p.MaxBackoff.Duration {
+			break
+		}
+	}
+	if p.MaxBackoff.Duration > 0 && delay > p.MaxBackoff.Duration {
+		delay = p.MaxBackoff.Duration
+	}
+	return delay
+}
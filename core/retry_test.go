@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyValidate(t *testing.T) {
+	var nilPolicy *RetryPolicy
+	assert.NoError(t, nilPolicy.Validate())
+	assert.NoError(t, (&RetryPolicy{}).Validate())
+	assert.NoError(t, (&RetryPolicy{Attempts: 3, Backoff: Duration{time.Minute}}).Validate())
+	assert.Error(t, (&RetryPolicy{Attempts: 3}).Validate())
+	assert.Error(t, (&RetryPolicy{Attempts: -1}).Validate())
+	assert.Error(t, (&RetryPolicy{Attempts: MaxRetryAttempts + 1, Backoff: Duration{time.Minute}}).Validate())
+	assert.Error(t, (&RetryPolicy{Attempts: 1, Backoff: Duration{time.Minute}, MaxBackoff: Duration{-time.Minute}}).Validate())
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &RetryPolicy{Attempts: 5, Backoff: Duration{time.Minute}}
+	assert.Equal(t, time.Minute, p.Delay(1))
+	assert.Equal(t, 2*time.Minute, p.Delay(2))
+	assert.Equal(t, 8*time.Minute, p.Delay(4))
+
+	p.MaxBackoff = Duration{5 * time.Minute}
+	assert.Equal(t, 4*time.Minute, p.Delay(3))
+	assert.Equal(t, 5*time.Minute, p.Delay(4))
+	assert.Equal(t, 5*time.Minute, p.Delay(5))
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"fmt"
+)
+
+// SaveMode defines what happens when harvested measurement has same timestamp as already stored one
+type SaveMode string
+
+const (
+	// SaveInsert keeps already stored measurements and ignores new values. This is default mode
+	SaveInsert SaveMode = "insert"
+	// SaveRevise replaces stored measurements with values corrected by upstream and records revisions
+	SaveRevise SaveMode = "revise"
+)
+
+// Validate returns error if save mode is unknown. Empty mode is valid and means SaveInsert
+func (m SaveMode) Validate() error {
+	switch m {
+	case "", SaveInsert, SaveRevise:
+		return nil
+	}
+	return fmt.Errorf("unknown save mode '%s'", m)
+}
+
+// MeasurementRevision is record of stored measurement being replaced by corrected values from upstream
+type MeasurementRevision struct {
+	Previous  Measurement `json:"previous"`
+	Current   Measurement `json:"current"`
+	RevisedAt HTime       `json:"revisedAt" ts_type:"string"`
+}
+
+// Revises returns true if measurement is correction of previously stored measurement with same gauge and timestamp
+// Approved values are never replaced with provisional ones
+func (m *Measurement) Revises(prev *Measurement) bool {
+	if m.GaugeID != prev.GaugeID || !m.Timestamp.Equal(prev.Timestamp.Time) {
+		return false
+	}
+	if m.Provisional && !prev.Provisional {
+		return false
+	}
+	return m.Provisional != prev.Provisional ||
+		m.Quality != prev.Quality ||
+		!sameValue(m.Level.Valid(), m.Level.Float64Value(), prev.Level.Valid(), prev.Level.Float64Value()) ||
+		!sameValue(m.Flow.Valid(), m.Flow.Float64Value(), prev.Flow.Valid(), prev.Flow.Float64Value()) ||
+		!sameParams(m.Params, prev.Params)
+}
+
+// sameValue compares values with single precision, because this is how level and flow are stored in db
+func sameValue(aValid bool, a float64, bValid bool, b float64) bool {
+	if aValid != bValid {
+		return false
+	}
+	return !aValid || float32(a) == float32(b)
+}
+
+func sameParams(a, b Params) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || !sameValue(true, v, true, w) {
+			return false
+		}
+	}
+	return true
+}
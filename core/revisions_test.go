@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattn/go-nulltype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasurement_Revises(t *testing.T) {
+	ts := HTime{Time: time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)}
+	id := GaugeID{Script: "s", Code: "c"}
+	prev := Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(1.1), Provisional: true}
+	tests := []struct {
+		name     string
+		m        Measurement
+		expected bool
+	}{
+		{
+			name:     "same values",
+			m:        Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(1.1), Provisional: true},
+			expected: false,
+		},
+		{
+			name:     "same values with single precision",
+			m:        Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(float64(float32(1.1))), Provisional: true},
+			expected: false,
+		},
+		{
+			name:     "different gauge",
+			m:        Measurement{GaugeID: GaugeID{Script: "s", Code: "d"}, Timestamp: ts, Level: nulltype.NullFloat64Of(2)},
+			expected: false,
+		},
+		{
+			name:     "corrected value",
+			m:        Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(2), Provisional: true},
+			expected: true,
+		},
+		{
+			name:     "approved",
+			m:        Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(1.1)},
+			expected: true,
+		},
+		{
+			name:     "quality changed",
+			m:        Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(1.1), Provisional: true, Quality: "e"},
+			expected: true,
+		},
+		{
+			name:     "flow added",
+			m:        Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(1.1), Flow: nulltype.NullFloat64Of(10), Provisional: true},
+			expected: true,
+		},
+		{
+			name:     "params changed",
+			m:        Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(1.1), Params: Params{Temperature: 10}, Provisional: true},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.m.Revises(&prev))
+		})
+	}
+
+	approved := Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(1.1)}
+	provisional := Measurement{GaugeID: id, Timestamp: ts, Level: nulltype.NullFloat64Of(2), Provisional: true}
+	assert.False(t, provisional.Revises(&approved), "approved values must not be replaced with provisional")
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+)
+
+// JSONSchemaDraft is JSON Schema version used for script options schemas
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema is subset of JSON Schema that is sufficient to describe script options
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+	Definitions          map[string]*JSONSchema `json:"definitions,omitempty"`
+}
+
+// OptionsSchema returns JSON Schema of script options, derived from DefaultOptions
+// Property descriptions are taken from `desc` tags, defaults are values set by DefaultOptions
+// Unknown properties are not allowed, because options are decoded with json.Decoder.DisallowUnknownFields
+func (d *ScriptDescriptor) OptionsSchema() *JSONSchema {
+	s := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}, AdditionalProperties: false}
+	if d.DefaultOptions != nil {
+		s = schemaOf(reflect.ValueOf(d.DefaultOptions()))
+	}
+	if s.Properties == nil {
+		s.Properties = map[string]*JSONSchema{}
+	}
+	outliers := schemaOf(reflect.ValueOf(OutlierOptions{}))
+	outliers.Description = "Outlier filter options, gauge-level options override job-level options"
+	s.Properties[OutliersOptionsKey] = outliers
+	return s
+}
+
+// Schema returns JSON Schema of script-specific part of job description: script-level options and gauge-level options
+// Gauge-level options are merged into script-level options, so both are described by the same definition
+func (d *ScriptDescriptor) Schema() *JSONSchema {
+	ref := &JSONSchema{Ref: "#/definitions/options"}
+	return &JSONSchema{
+		Schema:      JSONSchemaDraft,
+		Title:       d.Name,
+		Description: d.Description,
+		Type:        "object",
+		Properties: map[string]*JSONSchema{
+			"options": ref,
+			"gauges": {
+				Type:                 "object",
+				Description:          "Gauge codes with gauge-level options",
+				AdditionalProperties: &JSONSchema{OneOf: []*JSONSchema{{Type: "null"}, ref}},
+			},
+		},
+		Definitions: map[string]*JSONSchema{"options": d.OptionsSchema()},
+	}
+}
+
+func schemaOf(v reflect.Value) *JSONSchema {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return schemaOfType(v.Type())
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return schemaOfType(v.Type())
+	}
+	s := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}, AdditionalProperties: false}
+	addStructProperties(s, v)
+	return s
+}
+
+func addStructProperties(s *JSONSchema, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			addStructProperties(s, v.Field(i))
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		name, skip := jsonFieldName(f)
+		if skip {
+			continue
+		}
+		prop := schemaOf(v.Field(i))
+		prop.Description = f.Tag.Get("desc")
+		if fv := v.Field(i); !fv.IsZero() && prop.Type != "object" {
+			prop.Default = fv.Interface()
+		}
+		s.Properties[name] = prop
+	}
+}
+
+func schemaOfType(t reflect.Type) *JSONSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: "array", Items: schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: schemaOfType(t.Elem())}
+	case reflect.Struct:
+		return schemaOf(reflect.New(t).Elem())
+	}
+	return &JSONSchema{}
+}
+
+// jsonFieldName returns name of struct field as encoding/json sees it
+func jsonFieldName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, false
+	}
+	return f.Name, false
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaTestOptions struct {
+	Name     string          `desc:"Name" json:"name"`
+	Codes    []string        `desc:"Codes"`
+	Timeout  int64           `desc:"Timeout" json:"timeout,omitempty"`
+	Extra    map[string]bool `json:"extra"`
+	Ignored  string          `json:"-"`
+	internal string
+}
+
+func TestScriptDescriptor_OptionsSchema(t *testing.T) {
+	d := ScriptDescriptor{
+		Name: "test",
+		DefaultOptions: func() interface{} {
+			return &schemaTestOptions{Timeout: 60}
+		},
+	}
+	s := d.OptionsSchema()
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, false, s.AdditionalProperties)
+	assert.Equal(t, &JSONSchema{Type: "string", Description: "Name"}, s.Properties["name"])
+	assert.Equal(t, &JSONSchema{Type: "array", Description: "Codes", Items: &JSONSchema{Type: "string"}}, s.Properties["Codes"])
+	assert.Equal(t, &JSONSchema{Type: "integer", Description: "Timeout", Default: int64(60)}, s.Properties["timeout"])
+	assert.Equal(t, &JSONSchema{Type: "object", AdditionalProperties: &JSONSchema{Type: "boolean"}}, s.Properties["extra"])
+	if assert.Contains(t, s.Properties, OutliersOptionsKey) {
+		assert.Equal(t, &JSONSchema{Type: "number", Description: "Levels below this value are rejected"}, s.Properties[OutliersOptionsKey].Properties["minLevel"])
+	}
+	assert.Len(t, s.Properties, 5)
+}
+
+func TestScriptRegistry_GetSchema(t *testing.T) {
+	registry := setup()
+	s, err := registry.GetSchema("all_at_once")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "all_at_once", s.Title)
+		assert.Contains(t, s.Definitions["options"].Properties, "gauges")
+	}
+	_, err = registry.GetSchema("foo")
+	assert.Error(t, err)
+}
@@ -16,16 +16,29 @@ var ErrScriptNotFound = errors.New("script not found")
 
 // Script represents bunch of methods to harvest measurements and gauges from certain upstream source
 type Script interface {
-	ListGauges() (Gauges, error)
+	// ListGauges returns all gauges available in upstream.
+	// ctx must be passed down to HTTP client calls, so that listing can be canceled or time-bounded by caller
+	ListGauges(ctx context.Context) (Gauges, error)
 	// Harvests measurements from upstream and writes them to recv channel, then closes both channels.
 	// If unrecoverable error happens during this process, writes it into errs channel and closes both channels.
-	// codes are set of gauge codes to harvest from upstream. It's meant to be passed to upstream. The script itself should not make use of it.
-	// since is the last timestamp (usually for one-by-one scripts) that is meannt to be passed to upstream. The script itself should not make use of it.
-	Harvest(ctx context.Context, recv chan<- *Measurement, errs chan<- error, codes StringSet, since int64)
+	// spec describes gauges to harvest, see HarvestSpec
+	Harvest(ctx context.Context, recv chan<- *Measurement, errs chan<- error, spec HarvestSpec)
 	SetLogger(logger *logrus.Entry)
 	GetLogger() *logrus.Entry
 }
 
+// HarvestSpec describes gauges that Harvest must collect measurements of
+type HarvestSpec struct {
+	// Codes are set of gauge codes to harvest from upstream. It's meant to be passed to upstream. The script itself should not make use of it.
+	Codes StringSet
+	// Since maps gauge codes to timestamps of their latest cached measurements, gauges without them are not present
+	// Scripts can use it to request shorter periods from upstream
+	Since SinceMap
+	// GaugeOptions are options of every gauge in batch, set for batched scripts only
+	// Scripts which options implement GaugeOptionsBatchable can use them to honour gauge-level options
+	GaugeOptions GaugeOptions
+}
+
 // ScriptFactory creates an instance of script and provides is with options
 // It must faile if generic options cannot be cast to script's internal options
 type ScriptFactory func(name string, options interface{}) (Script, error)
@@ -38,6 +51,8 @@ type ScriptDescriptor struct {
 	Mode           HarvestMode        `json:"mode"`
 	DefaultOptions func() interface{} `json:"-"`
 	Factory        ScriptFactory      `json:"-"`
+	// Host is upstream host that script harvests from. Harvests of scripts with same host share host concurrency limit
+	Host string `json:"host,omitempty"`
 }
 
 // ScriptRegistry is where all the script we can use must be registered
@@ -94,6 +109,10 @@ func (r *ScriptRegistry) ParseJSONOptions(name string, inputs ...json.RawMessage
 	}
 	options := d.DefaultOptions()
 	for _, raw := range inputs {
+		raw, err := withoutOutliersOptions(raw)
+		if err != nil {
+			return nil, WrapErr(err, "failed to unmarshal options")
+		}
 		if len(raw) > 0 {
 			decoder := json.NewDecoder(bytes.NewReader(raw))
 			decoder.DisallowUnknownFields()
@@ -115,6 +134,20 @@ func (r *ScriptRegistry) GetMode(name string) (HarvestMode, error) {
 	return d.Mode, nil
 }
 
+// GetHost returns upstream host of a registered script, or empty string if it's unknown
+func (r *ScriptRegistry) GetHost(name string) string {
+	return r.descriptors[name].Host
+}
+
+// GetSchema returns JSON Schema of options of a registered script
+func (r *ScriptRegistry) GetSchema(name string) (*JSONSchema, error) {
+	d, exists := r.descriptors[name]
+	if !exists {
+		return nil, ErrScriptNotFound
+	}
+	return d.Schema(), nil
+}
+
 // List lists all registered scripts
 func (r *ScriptRegistry) List() []ScriptDescriptor {
 	result, i := make([]ScriptDescriptor, len(r.descriptors)), 0
@@ -22,11 +22,11 @@ type mockOptions struct {
 	Value  float64 `json:"value,omitempty"`
 }
 
-func (m *mockScript) ListGauges() (Gauges, error) {
+func (m *mockScript) ListGauges(ctx context.Context) (Gauges, error) {
 	panic("implement me")
 }
 
-func (m *mockScript) Harvest(ctx context.Context, recv chan<- *Measurement, errs chan<- error, codes StringSet, since int64) {
+func (m *mockScript) Harvest(ctx context.Context, recv chan<- *Measurement, errs chan<- error, spec HarvestSpec) {
 	panic("implement me")
 }
 
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"time"
+)
+
+// SinceMap maps gauge codes to timestamps (unix seconds) of their latest cached measurements
+// Gauges without cached measurements are not present in map
+type SinceMap map[string]int64
+
+// Oldest returns oldest timestamp of given gauges. It returns 0 if any of them has no cached measurements
+func (m SinceMap) Oldest(codes StringSet) int64 {
+	var oldest int64
+	for code := range codes {
+		ts, ok := m[code]
+		if !ok || ts <= 0 {
+			return 0
+		}
+		if oldest == 0 || ts < oldest {
+			oldest = ts
+		}
+	}
+	return oldest
+}
+
+// Window returns period of time that must be harvested to get measurements of given gauges that are newer than cached ones.
+// Result is never longer than max, which is also returned when some gauges have no cached measurements
+func (m SinceMap) Window(codes StringSet, now time.Time, max time.Duration) time.Duration {
+	oldest := m.Oldest(codes)
+	if oldest == 0 {
+		return max
+	}
+	window := now.Sub(time.Unix(oldest, 0))
+	if window > max {
+		return max
+	}
+	if window < 0 {
+		return 0
+	}
+	return window
+}
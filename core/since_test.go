@@ -0,0 +1,27 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSinceMapOldest(t *testing.T) {
+	since := SinceMap{"g000": 300, "g001": 100, "g002": 200}
+	assert.Equal(t, int64(100), since.Oldest(StringSet{"g000": {}, "g001": {}}))
+	assert.Equal(t, int64(200), since.Oldest(StringSet{"g000": {}, "g002": {}}))
+	assert.Equal(t, int64(0), since.Oldest(StringSet{"g000": {}, "g003": {}}))
+	assert.Equal(t, int64(0), SinceMap(nil).Oldest(StringSet{"g000": {}}))
+}
+
+func TestSinceMapWindow(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)
+	since := SinceMap{
+		"g000": now.Add(-20 * time.Minute).Unix(),
+		"g001": now.Add(-5 * time.Hour).Unix(),
+	}
+	assert.Equal(t, 20*time.Minute, since.Window(StringSet{"g000": {}}, now, time.Hour))
+	assert.Equal(t, time.Hour, since.Window(StringSet{"g001": {}}, now, time.Hour))
+	assert.Equal(t, time.Hour, since.Window(StringSet{"g000": {}, "g002": {}}, now, time.Hour))
+}
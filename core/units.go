@@ -0,0 +1,224 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Dimension is physical quantity measured in some unit
+type Dimension string
+
+const (
+	// DimLength is used for water level and rainfall
+	DimLength Dimension = "length"
+	// DimDischarge is used for water flow
+	DimDischarge Dimension = "discharge"
+	// DimTemperature is used for water temperature
+	DimTemperature Dimension = "temperature"
+	// DimVelocity is used for water velocity
+	DimVelocity Dimension = "velocity"
+	// DimTurbidity is used for water turbidity
+	DimTurbidity Dimension = "turbidity"
+)
+
+// Dimension returns dimension in which values of this parameter are measured
+func (p Parameter) Dimension() Dimension {
+	switch p {
+	case Temperature:
+		return DimTemperature
+	case Rainfall:
+		return DimLength
+	case Turbidity:
+		return DimTurbidity
+	case Velocity:
+		return DimVelocity
+	}
+	return ""
+}
+
+// Unit describes unit of measurement and how to convert it to SI unit of same dimension
+type Unit struct {
+	// Name is canonical unit name, e.g. "m3/s"
+	Name      string
+	Dimension Dimension
+	// Aliases are other names that scripts use for this unit, e.g. "cfs" for "ft3/s"
+	Aliases []string
+	// Value in SI unit is value * Scale + Offset
+	Scale  float64
+	Offset float64
+}
+
+// UnitSystem is set of units, one per dimension, to which measurements can be converted
+type UnitSystem string
+
+const (
+	// SI is metric unit system: m, m3/s, degC, m/s
+	SI UnitSystem = "si"
+	// Imperial is US customary unit system: ft, ft3/s, degF, ft/s
+	Imperial UnitSystem = "imperial"
+)
+
+var unitSystems = map[UnitSystem]map[Dimension]string{
+	SI: {
+		DimLength:      "m",
+		DimDischarge:   "m3/s",
+		DimTemperature: "degC",
+		DimVelocity:    "m/s",
+		DimTurbidity:   "NTU",
+	},
+	Imperial: {
+		DimLength:      "ft",
+		DimDischarge:   "ft3/s",
+		DimTemperature: "degF",
+		DimVelocity:    "ft/s",
+		DimTurbidity:   "NTU",
+	},
+}
+
+// ErrUnknownUnit is returned when unit is not found in registry
+var ErrUnknownUnit = errors.New("unknown unit")
+
+// ParseUnitSystem parses unit system name, as passed in query string
+func ParseUnitSystem(name string) (UnitSystem, error) {
+	sys := UnitSystem(strings.ToLower(name))
+	if _, ok := unitSystems[sys]; !ok {
+		return "", NewErr(fmt.Errorf("unknown unit system '%s'", name))
+	}
+	return sys, nil
+}
+
+// UnitRegistry holds all known units
+type UnitRegistry struct {
+	units map[string]*Unit
+}
+
+// NewUnitRegistry creates registry with given units. Units can be found by names and aliases
+func NewUnitRegistry(units ...Unit) *UnitRegistry {
+	r := &UnitRegistry{units: map[string]*Unit{}}
+	for i := range units {
+		u := &units[i]
+		r.units[u.Name] = u
+		for _, alias := range u.Aliases {
+			r.units[alias] = u
+		}
+	}
+	return r
+}
+
+// Units is registry of units known to gorge. Scripts must declare gauge units from this registry
+var Units = NewUnitRegistry(
+	Unit{Name: "m", Dimension: DimLength, Aliases: []string{"mASD"}, Scale: 1},
+	Unit{Name: "cm", Dimension: DimLength, Scale: 0.01},
+	Unit{Name: "mm", Dimension: DimLength, Scale: 0.001},
+	Unit{Name: "ft", Dimension: DimLength, Scale: 0.3048},
+	Unit{Name: "in", Dimension: DimLength, Scale: 0.0254},
+	Unit{Name: "m3/s", Dimension: DimDischarge, Aliases: []string{"m³/s", "m3s", "m3/sec", "cumecs"}, Scale: 1},
+	Unit{Name: "l/s", Dimension: DimDischarge, Scale: 0.001},
+	Unit{Name: "ft3/s", Dimension: DimDischarge, Aliases: []string{"cfs"}, Scale: 0.028316846592},
+	Unit{Name: "kcfs", Dimension: DimDischarge, Scale: 28.316846592},
+	Unit{Name: "degC", Dimension: DimTemperature, Aliases: []string{"°C"}, Scale: 1},
+	Unit{Name: "degF", Dimension: DimTemperature, Aliases: []string{"°F"}, Scale: 5.0 / 9.0, Offset: -32 * 5.0 / 9.0},
+	Unit{Name: "m/s", Dimension: DimVelocity, Scale: 1},
+	Unit{Name: "ft/s", Dimension: DimVelocity, Scale: 0.3048},
+	Unit{Name: "NTU", Dimension: DimTurbidity, Scale: 1},
+)
+
+// Lookup finds unit by its name or alias
+func (r *UnitRegistry) Lookup(name string) (*Unit, error) {
+	u, ok := r.units[name]
+	if !ok {
+		return nil, WrapErr(ErrUnknownUnit, "failed to lookup unit").With("unit", name)
+	}
+	return u, nil
+}
+
+// Convert converts value from one unit to another. Both units must have same dimension
+func (r *UnitRegistry) Convert(v float64, from, to string) (float64, error) {
+	f, err := r.Lookup(from)
+	if err != nil {
+		return 0, err
+	}
+	t, err := r.Lookup(to)
+	if err != nil {
+		return 0, err
+	}
+	if f.Dimension != t.Dimension {
+		return 0, NewErr(fmt.Errorf("cannot convert %s to %s", f.Dimension, t.Dimension)).With("from", from).With("to", to)
+	}
+	if f == t {
+		return v, nil
+	}
+	return (v*f.Scale + f.Offset - t.Offset) / t.Scale, nil
+}
+
+func (r *UnitRegistry) validate(name string, dim Dimension) error {
+	if name == "" {
+		return nil
+	}
+	u, err := r.Lookup(name)
+	if err != nil {
+		return err
+	}
+	if u.Dimension != dim {
+		return NewErr(fmt.Errorf("unit %s is %s, but %s is expected", name, u.Dimension, dim))
+	}
+	return nil
+}
+
+// ValidateGauge checks that gauge units are known and match dimensions of level, flow and params
+func (r *UnitRegistry) ValidateGauge(g *Gauge) error {
+	if err := r.validate(g.LevelUnit, DimLength); err != nil {
+		return WrapErr(err, "invalid level unit").With("script", g.Script).With("code", g.Code)
+	}
+	if err := r.validate(g.FlowUnit, DimDischarge); err != nil {
+		return WrapErr(err, "invalid flow unit").With("script", g.Script).With("code", g.Code)
+	}
+	for p, unit := range g.ParamUnits {
+		if !p.Valid() {
+			return NewErr(fmt.Errorf("unknown parameter '%s'", p)).With("script", g.Script).With("code", g.Code)
+		}
+		if err := r.validate(unit, p.Dimension()); err != nil {
+			return WrapErr(err, "invalid "+string(p)+" unit").With("script", g.Script).With("code", g.Code)
+		}
+	}
+	return nil
+}
+
+// ConvertMeasurement converts measurement values from units of its gauge to given unit system
+// Values that cannot be converted (e.g. when gauge unit is unknown) are reset to null and reported in returned error
+func (r *UnitRegistry) ConvertMeasurement(m *Measurement, g *Gauge, sys UnitSystem) error {
+	target := unitSystems[sys]
+	var errs []error
+	if m.Level.Valid() {
+		if v, err := r.Convert(m.Level.Float64Value(), g.LevelUnit, target[DimLength]); err == nil {
+			m.Level.Set(v)
+		} else {
+			m.Level.Reset()
+			errs = append(errs, WrapErr(err, "failed to convert level"))
+		}
+	}
+	if m.Flow.Valid() {
+		if v, err := r.Convert(m.Flow.Float64Value(), g.FlowUnit, target[DimDischarge]); err == nil {
+			m.Flow.Set(v)
+		} else {
+			m.Flow.Reset()
+			errs = append(errs, WrapErr(err, "failed to convert flow"))
+		}
+	}
+	if len(m.Params) > 0 {
+		params := make(Params, len(m.Params))
+		for p, val := range m.Params {
+			if v, err := r.Convert(val, g.ParamUnits[p], target[p.Dimension()]); err == nil {
+				params[p] = v
+			} else {
+				errs = append(errs, WrapErr(err, "failed to convert "+string(p)))
+			}
+		}
+		if len(params) == 0 {
+			params = nil
+		}
+		m.Params = params
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/mattn/go-nulltype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitRegistry_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		from     string
+		to       string
+		expected float64
+		err      bool
+	}{
+		{name: "same unit", value: 1.5, from: "m", to: "m", expected: 1.5},
+		{name: "alias", value: 1.5, from: "m³/s", to: "m3/s", expected: 1.5},
+		{name: "cm to m", value: 150, from: "cm", to: "m", expected: 1.5},
+		{name: "ft to m", value: 10, from: "ft", to: "m", expected: 3.048},
+		{name: "m to ft", value: 3.048, from: "m", to: "ft", expected: 10},
+		{name: "cfs to m3/s", value: 100, from: "cfs", to: "m3/s", expected: 2.8316846592},
+		{name: "kcfs to ft3/s", value: 1.5, from: "kcfs", to: "ft3/s", expected: 1500},
+		{name: "degF to degC", value: 50, from: "degF", to: "degC", expected: 10},
+		{name: "degC to degF", value: 100, from: "degC", to: "degF", expected: 212},
+		{name: "unknown unit", value: 1, from: "furlong", to: "m", err: true},
+		{name: "dimension mismatch", value: 1, from: "m", to: "m3/s", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Units.Convert(tt.value, tt.from, tt.to)
+			if tt.err {
+				assert.Error(t, err)
+			} else if assert.NoError(t, err) {
+				assert.InDelta(t, tt.expected, actual, 1e-9)
+			}
+		})
+	}
+}
+
+func TestUnitRegistry_ValidateGauge(t *testing.T) {
+	tests := []struct {
+		name  string
+		gauge Gauge
+		err   bool
+	}{
+		{name: "no units", gauge: Gauge{}},
+		{name: "good", gauge: Gauge{LevelUnit: "cm", FlowUnit: "cfs", ParamUnits: map[Parameter]string{Temperature: "°C", Rainfall: "mm"}}},
+		{name: "unknown level unit", gauge: Gauge{LevelUnit: "furlong"}, err: true},
+		{name: "wrong flow dimension", gauge: Gauge{FlowUnit: "m"}, err: true},
+		{name: "unknown parameter", gauge: Gauge{ParamUnits: map[Parameter]string{"salinity": "ppt"}}, err: true},
+		{name: "wrong parameter dimension", gauge: Gauge{ParamUnits: map[Parameter]string{Velocity: "degC"}}, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Units.ValidateGauge(&tt.gauge)
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUnitRegistry_ConvertMeasurement(t *testing.T) {
+	g := Gauge{LevelUnit: "ft", FlowUnit: "furlong/s", ParamUnits: map[Parameter]string{Temperature: "degF"}}
+	m := Measurement{
+		Level:  nulltype.NullFloat64Of(10),
+		Flow:   nulltype.NullFloat64Of(100),
+		Params: Params{Temperature: 50, Velocity: 1},
+	}
+	err := Units.ConvertMeasurement(&m, &g, SI)
+	assert.Error(t, err)
+	assert.InDelta(t, 3.048, m.Level.Float64Value(), 1e-9)
+	assert.False(t, m.Flow.Valid())
+	if assert.Len(t, m.Params, 1) {
+		assert.InDelta(t, 10, m.Params[Temperature], 1e-9)
+	}
+}
+
+func TestParseUnitSystem(t *testing.T) {
+	sys, err := ParseUnitSystem("SI")
+	assert.NoError(t, err)
+	assert.Equal(t, SI, sys)
+	_, err = ParseUnitSystem("foo")
+	assert.Error(t, err)
+}
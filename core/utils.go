@@ -122,12 +122,22 @@ func (f *MeasurementsFactory) GenManyPtr(size int) []*Measurement {
 }
 
 // HarvestSlice is test helper that runs script's harvest and returns result as slice
-func HarvestSlice(script Script, codes StringSet, since int64) (Measurements, error) {
+func HarvestSlice(script Script, codes StringSet) (Measurements, error) {
 	ctx := context.Background()
 	in := make(chan *Measurement)
 	errCh := make(chan error, 1)
 	out := SinkToSlice(ctx, in)
-	script.Harvest(ctx, in, errCh, codes, since)
+	script.Harvest(ctx, in, errCh, HarvestSpec{Codes: codes})
+	return <-out, <-errCh
+}
+
+// BackfillSlice is helper function to run Backfill and collect results to slice (usable in tests)
+func BackfillSlice(script Backfiller, codes StringSet, from, to time.Time) (Measurements, error) {
+	ctx := context.Background()
+	in := make(chan *Measurement)
+	errCh := make(chan error, 1)
+	out := SinkToSlice(ctx, in)
+	script.Backfill(ctx, in, errCh, HarvestSpec{Codes: codes}, from, to)
 	return <-out, <-errCh
 }
 
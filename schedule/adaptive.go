@@ -0,0 +1,186 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+const (
+	// adaptCron is schedule on which adaptive jobs are rescheduled according to recently stored measurements
+	adaptCron = "@every 6h"
+	// adaptWindow is period of stored measurements that is used to learn publication interval of gauge
+	adaptWindow = 7 * 24 * time.Hour
+	// adaptLeaseTTL is how long instance that learned publication intervals of script keeps this script for itself in coordinated mode
+	// It must be shorter than adaptCron interval, so that other instance can take over
+	adaptLeaseTTL = 5 * time.Hour
+)
+
+// adaptiveSteps are intervals that can be expressed with cron: divisors of hour and divisors of day
+var adaptiveSteps = []time.Duration{
+	time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute, 5 * time.Minute, 6 * time.Minute,
+	10 * time.Minute, 12 * time.Minute, 15 * time.Minute, 20 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 3 * time.Hour, 4 * time.Hour, 6 * time.Hour, 8 * time.Hour, 12 * time.Hour,
+	24 * time.Hour,
+}
+
+// adaptiveSpec returns cron spec that fires every interval, rounded down to cron-friendly step, but not below min
+// minute is offset that spreads gauges of job over time
+func adaptiveSpec(minute int, interval, min time.Duration) string {
+	step := adaptiveSteps[0]
+	for i := len(adaptiveSteps) - 1; i >= 0; i-- {
+		if adaptiveSteps[i] <= interval {
+			step = adaptiveSteps[i]
+			break
+		}
+	}
+	for i := 0; step < min && i < len(adaptiveSteps); i++ {
+		step = adaptiveSteps[i]
+	}
+	switch {
+	case step < time.Hour:
+		m := int(step.Minutes())
+		return fmt.Sprintf("%d-59/%d * * * *", minute%m, m)
+	case step == time.Hour:
+		return fmt.Sprintf("%d * * * *", minute)
+	case step < 24*time.Hour:
+		h := int(step.Hours())
+		return fmt.Sprintf("%d %d-23/%d * * *", minute, minute%h, h)
+	default:
+		return fmt.Sprintf("%d %d * * *", minute, minute%24)
+	}
+}
+
+// learnIntervals learns publication intervals of given gauges of script from recently stored measurements and saves them in db
+// Publication interval of gauge is median interval between its measurements, it's computed by db
+// In coordinated mode only one instance learns intervals of script, others use saved intervals
+func (s *simpleScheduler) learnIntervals(script string, codes core.StringSet) error {
+	if s.Leases != nil {
+		acquired, err := s.Leases.AcquireLease("adapt:"+script, s.Instance, adaptLeaseTTL)
+		if err != nil {
+			return core.WrapErr(err, "failed to acquire adaptive schedule lease").With("script", script)
+		} else if !acquired {
+			return nil
+		}
+	}
+	intervals, err := s.Database.ListMeasurementIntervals(script, codes.Slice(), time.Now().Add(-adaptWindow))
+	if err != nil {
+		return err
+	}
+	return s.Database.SavePublicationIntervals(script, intervals)
+}
+
+// loadIntervals replaces known publication intervals of gauges of script with intervals saved in db
+func (s *simpleScheduler) loadIntervals(script string) error {
+	intervals, err := s.Database.ListPublicationIntervals(script)
+	if err != nil {
+		return err
+	}
+	s.intervalsMu.Lock()
+	defer s.intervalsMu.Unlock()
+	if s.intervals == nil {
+		s.intervals = map[core.GaugeID]time.Duration{}
+	}
+	for id := range s.intervals {
+		if id.Script == script {
+			delete(s.intervals, id)
+		}
+	}
+	for code, interval := range intervals {
+		s.intervals[core.GaugeID{Script: script, Code: code}] = interval
+	}
+	return nil
+}
+
+// knownInterval returns shortest known publication interval of given gauges, or 0 if it's not known for any of them
+// It never queries db, so it's safe to call when job is added
+func (s *simpleScheduler) knownInterval(script string, codes core.StringSet) time.Duration {
+	s.intervalsMu.Lock()
+	defer s.intervalsMu.Unlock()
+	var result time.Duration
+	for code := range codes {
+		if interval := s.intervals[core.GaugeID{Script: script, Code: code}]; interval > 0 && (result == 0 || interval < result) {
+			result = interval
+		}
+	}
+	return result
+}
+
+// adaptiveJobSpec returns cron spec for gauges of adaptive job
+func (s *simpleScheduler) adaptiveJobSpec(job *harvestJob) string {
+	interval := job.adaptive.Interval(s.knownInterval(job.script, job.codes))
+	return adaptiveSpec(job.minute, interval, job.adaptive.MinInterval.Duration)
+}
+
+// adaptJobs learns publication intervals of gauges of adaptive jobs and reschedules entries which gauges have changed their publication intervals
+// Intervals are learned with one query per script
+func (s *simpleScheduler) adaptJobs() {
+	codes := map[string]core.StringSet{}
+	for _, entry := range s.Cron.Entries() {
+		if job, ok := entry.Job.(*harvestJob); ok && job.adaptive != nil {
+			if codes[job.script] == nil {
+				codes[job.script] = core.StringSet{}
+			}
+			for code := range job.codes {
+				codes[job.script][code] = struct{}{}
+			}
+		}
+	}
+	for script, cs := range codes {
+		if err := s.learnIntervals(script, cs); err != nil {
+			logError(s.Logger, core.WrapErr(err, "failed to learn publication intervals").With("script", script))
+		}
+		if err := s.loadIntervals(script); err != nil {
+			logError(s.Logger, core.WrapErr(err, "failed to load publication intervals").With("script", script))
+		}
+	}
+	for _, entry := range s.Cron.Entries() {
+		job, ok := entry.Job.(*harvestJob)
+		if !ok || job.adaptive == nil {
+			continue
+		}
+		spec := s.adaptiveJobSpec(job)
+		if spec == job.cron {
+			continue
+		}
+		if ok, err := s.rescheduleAdaptive(entry.ID, job, spec); err != nil {
+			logError(s.Logger, core.WrapErr(err, "failed to reschedule adaptive job").With("jobId", job.jobID).With("cron", spec))
+		} else if ok {
+			job.withLogger().Debugf("rescheduled adaptive job from '%s' to '%s'", job.cron, spec)
+		}
+	}
+}
+
+// rescheduleAdaptive replaces cron entry of adaptive job with entry that runs same job on new spec
+// Entry that was removed meanwhile (job was paused, deleted or updated) is not brought back, false is returned then
+func (s *simpleScheduler) rescheduleAdaptive(id cron.EntryID, job *harvestJob, spec string) (bool, error) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	if !s.Cron.Entry(id).Valid() {
+		return false, nil
+	}
+	adapted := *job
+	adapted.cron = spec
+	// entry is added before old one is removed, so failed reschedule keeps old entry running
+	if _, err := s.Cron.AddJob(spec, &adapted); err != nil {
+		return false, err
+	}
+	s.Cron.Remove(id)
+	return true, nil
+}
+
+// validateAdaptive returns error if job with adaptive schedule cannot be scheduled
+func validateAdaptive(description core.JobDescription, mode core.HarvestMode) error {
+	if description.Adaptive == nil {
+		return nil
+	}
+	if mode == core.AllAtOnce {
+		return (&core.Error{Msg: "adaptive schedule is supported by one-by-one and batched scripts only"}).With("jobId", description.ID)
+	}
+	if err := description.Adaptive.Validate(); err != nil {
+		return core.WrapErr(err, "invalid adaptive schedule").With("jobId", description.ID)
+	}
+	return nil
+}
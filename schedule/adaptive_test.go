@@ -0,0 +1,132 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-nulltype"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+func TestAdaptiveSpec(t *testing.T) {
+	tests := []struct {
+		minute   int
+		interval time.Duration
+		min      time.Duration
+		expected string
+	}{
+		{minute: 0, interval: 5 * time.Minute, min: time.Minute, expected: "0-59/5 * * * *"},
+		{minute: 17, interval: 7 * time.Minute, min: time.Minute, expected: "5-59/6 * * * *"},
+		{minute: 17, interval: 7 * time.Minute, min: 7 * time.Minute, expected: "7-59/10 * * * *"},
+		{minute: 30, interval: 90 * time.Minute, min: time.Minute, expected: "30 * * * *"},
+		{minute: 30, interval: 3 * time.Hour, min: time.Minute, expected: "30 0-23/3 * * *"},
+		{minute: 31, interval: 3 * time.Hour, min: time.Minute, expected: "31 1-23/3 * * *"},
+		{minute: 45, interval: 24 * time.Hour, min: time.Minute, expected: "45 21 * * *"},
+		{minute: 0, interval: 30 * time.Second, min: 0, expected: "0-59/1 * * * *"},
+	}
+	for _, tt := range tests {
+		spec := adaptiveSpec(tt.minute, tt.interval, tt.min)
+		assert.Equal(t, tt.expected, spec)
+		_, err := cron.ParseStandard(spec)
+		assert.NoError(t, err, spec)
+	}
+}
+
+func saveEvery(t *testing.T, scheduler *mockScheduler, code string, interval time.Duration, n int) {
+	var ms []core.Measurement
+	start := time.Now().Add(-time.Duration(n) * interval).Truncate(time.Minute).UTC()
+	for i := 0; i < n; i++ {
+		ms = append(ms, core.Measurement{
+			GaugeID:   core.GaugeID{Script: "one_by_one", Code: code},
+			Timestamp: core.HTime{Time: start.Add(time.Duration(i) * interval)},
+			Level:     nulltype.NullFloat64Of(1),
+		})
+	}
+	savedCh, errCh := scheduler.Database.SaveMeasurements(context.Background(), core.GenFromSlice(context.Background(), ms))
+	require.NoError(t, <-errCh)
+	require.Equal(t, n, <-savedCh)
+}
+
+func TestAdaptiveJob(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	require.NoError(t, scheduler.Database.Start())
+	saveEvery(t, scheduler, "a000", 5*time.Minute, 10)
+	saveEvery(t, scheduler, "a001", 24*time.Hour, 3)
+
+	job := core.JobDescription{
+		ID:     "8c2e6a4f-1b3d-4f5a-9c7e-2d4b6f8a0c1e",
+		Script: "one_by_one",
+		Gauges: map[string]json.RawMessage{"a000": nil, "a001": nil, "a002": nil},
+		Adaptive: &core.AdaptiveSchedule{
+			MinInterval: core.Duration{Duration: 10 * time.Minute},
+			MaxInterval: core.Duration{Duration: 6 * time.Hour},
+		},
+		Options: json.RawMessage(`{}`),
+	}
+	require.NoError(t, scheduler.AddJob(job))
+	assert.ElementsMatch(t, []string{"0 * * * *", "20 * * * *", "40 * * * *"}, entrySpecs(scheduler.Cron), "intervals are not learned when job is added")
+
+	scheduler.adaptJobs()
+	specs := map[string]string{}
+	for _, e := range scheduler.Cron.Entries() {
+		hj := e.Job.(*harvestJob)
+		code, _ := hj.codes.Only()
+		specs[code] = hj.cron
+	}
+	assert.Equal(t, map[string]string{
+		"a000": "0-59/10 * * * *", // every 5 minutes, but not more often than min
+		"a001": "20 2-23/6 * * *", // daily, but not less often than max
+		"a002": "40 * * * *",      // unknown, hourly
+	}, specs)
+
+	saveEvery(t, scheduler, "a002", 15*time.Minute, 10)
+	scheduler.adaptJobs()
+	assert.ElementsMatch(t, []string{"0-59/10 * * * *", "20 2-23/6 * * *", "10-59/15 * * * *"}, entrySpecs(scheduler.Cron))
+
+	require.NoError(t, scheduler.DeleteJob(job.ID))
+	require.NoError(t, scheduler.AddJob(job))
+	assert.ElementsMatch(t, []string{"0-59/10 * * * *", "20 2-23/6 * * *", "10-59/15 * * * *"}, entrySpecs(scheduler.Cron), "known intervals are used when job is added again")
+
+	t.Run("paused meanwhile", func(t *testing.T) {
+		entry := scheduler.Cron.Entries()[0]
+		require.NoError(t, scheduler.PauseJob(job.ID))
+		defer scheduler.ResumeJob(job) // nolint:errcheck
+		ok, err := scheduler.rescheduleAdaptive(entry.ID, entry.Job.(*harvestJob), "0 0 * * *")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Empty(t, scheduler.Cron.Entries(), "entry of paused job is not brought back")
+	})
+
+	t.Run("coordinated", func(t *testing.T) {
+		other := newMockScheduler(t)
+		other.Database, other.Cron = scheduler.Database, cron.New(cron.WithLocation(time.UTC))
+		other.Leases, other.Instance = scheduler.Database.(storage.LeaseManager), "other"
+		scheduler.Leases, scheduler.Instance = other.Leases, "this"
+		defer func() { scheduler.Leases = nil }()
+		require.NoError(t, other.AddJob(job))
+
+		scheduler.adaptJobs()
+		saveEvery(t, scheduler, "a001", 30*time.Minute, 10)
+		other.adaptJobs()
+		assert.ElementsMatch(t, []string{"0-59/10 * * * *", "20 2-23/6 * * *", "10-59/15 * * * *"}, entrySpecs(other.Cron), "intervals learned by other instance are used")
+	})
+
+	t.Run("all at once", func(t *testing.T) {
+		err := scheduler.AddJob(core.JobDescription{
+			ID:       "9d3f7b5a-2c4e-4a6b-8d9f-3e5c7a9b1d2f",
+			Script:   "all_at_once",
+			Gauges:   map[string]json.RawMessage{"g000": nil},
+			Cron:     "0 * * * *",
+			Adaptive: job.Adaptive,
+			Options:  json.RawMessage(`{"gauges": 1}`),
+		})
+		assert.Error(t, err)
+	})
+}
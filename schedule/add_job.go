@@ -15,10 +15,34 @@ func (s *simpleScheduler) AddJob(description core.JobDescription) error {
 	if err != nil {
 		return err
 	}
+	if err := validateAdaptive(description, mode); err != nil {
+		return err
+	}
 	nGauges := len(description.Gauges)
 	if nGauges == 0 {
 		return (&core.Error{Msg: "job gauge codes must be specified"}).With("description", description)
 	}
+	outliers, err := core.ParseOutlierConfig(description.Options, description.Gauges)
+	if err != nil {
+		return core.WrapErr(err, "failed to parse outlier options").With("description", description)
+	}
+	timeout := s.Timeout
+	if description.Timeout != nil {
+		timeout = description.Timeout.Duration
+	}
+	var retries *retrier
+	if description.Retry != nil {
+		retries = &s.retries
+	}
+	// paused jobs are not added to cron, their entries are kept to be resumed later
+	var detached []pausedEntry
+	addEntry := s.Cron.AddJob
+	if description.Paused {
+		addEntry = func(spec string, cmd cron.Job) (cron.EntryID, error) {
+			detached = append(detached, pausedEntry{spec: spec, job: cmd})
+			return 0, nil
+		}
+	}
 	if mode == core.AllAtOnce {
 		_, err := cron.ParseStandard(description.Cron)
 		if err != nil {
@@ -28,7 +52,7 @@ func (s *simpleScheduler) AddJob(description core.JobDescription) error {
 		if err != nil {
 			return core.WrapErr(err, "failed to parse options").With("description", description)
 		}
-		_, err = s.Cron.AddJob(description.Cron, &harvestJob{
+		_, err = addEntry(description.Cron, &harvestJob{
 			database: s.Database,
 			cache:    s.Cache,
 			logger:   s.Logger,
@@ -38,71 +62,72 @@ func (s *simpleScheduler) AddJob(description core.JobDescription) error {
 			script:   description.Script,
 			codes:    core.GaugesCodes(description.Gauges),
 			options:  options,
+			saveMode: description.SaveMode,
+			outliers: outliers,
+			filters:  description.Filters,
+			leases:   s.Leases,
+			instance: s.Instance,
+			timeout:  timeout,
+			harvests: s.harvests,
+			pool:     s.pool,
+			retry:    description.Retry,
+			retries:  retries,
+			history:  s.RunsRetention > 0,
 		})
 		if err != nil {
 			return core.WrapErr(err, "failed to schedule harvest job").With("description", description)
 		}
 	} else if mode == core.OneByOne || mode == core.Batched {
-		batchSize := 1
-		if mode == core.Batched {
-			opts, err := s.Registry.ParseJSONOptions(description.Script, description.Options)
-			if err != nil {
-				return core.WrapErr(err, "failed to parse options").With("description", description)
-			}
-			if bOpts, ok := opts.(core.BatchableOptions); ok {
-				batchSize = bOpts.GetBatchSize()
-			} else {
-				return core.WrapErr(err, "options are not batchable").With("description", description)
-			}
-		}
-		numBatches := math.Ceil(float64(nGauges) / float64(batchSize))
-		j, step := 0, 59.0/(float64(numBatches))
 		// sort codes first
-		codes := make([]string, nGauges)
+		codes := make([]string, 0, nGauges)
 		for k := range description.Gauges {
-			codes[j] = k
-			j++
+			codes = append(codes, k)
 		}
 		sort.Strings(codes)
+		batches, err := s.batchGauges(description, mode, codes)
+		if err != nil {
+			return core.WrapErr(err, "failed to split gauges into batches").With("description", description)
+		}
+		step := 59.0 / float64(len(batches))
 		// Ensure transactional behaviour when adding cronjobs for gauges:
 		// if we cannot add one gauge job, cancel the whol batch
 		var tErr error
 		var entryIDs []cron.EntryID
 
-		for i, n := 0, 0; i < len(codes); i, n = i+batchSize, n+1 {
-			j := i + batchSize
-			if j > len(codes) {
-				j = len(codes)
-			}
+		for n, batch := range batches {
 			minute := int(math.Ceil(float64(float64(n) * step)))
-			batch := core.StringSet{}
-			for b := i; b < j; b++ {
-				batch[codes[b]] = struct{}{}
+			job := &harvestJob{
+				database:     s.Database,
+				cache:        s.Cache,
+				logger:       s.Logger,
+				registry:     s.Registry,
+				jobID:        description.ID,
+				script:       description.Script,
+				codes:        batch.codes,
+				options:      batch.options,
+				gaugeOptions: batch.gaugeOptions,
+				saveMode:     description.SaveMode,
+				outliers:     outliers,
+				filters:      description.Filters,
+				leases:       s.Leases,
+				instance:     s.Instance,
+				timeout:      timeout,
+				harvests:     s.harvests,
+				pool:         s.pool,
+				retry:        description.Retry,
+				retries:      retries,
+				history:      s.RunsRetention > 0,
 			}
-			// TODO: currently, for batched options it's impossible to use gauge options
-			// for every batch, first options of first gauge are applied to every gauge in batch
-			// the solution is to change harvestJob spec
-			gaugeOpts := description.Gauges[codes[i]]
 			spec := fmt.Sprintf("%d * * * *", minute)
-			options, err := s.Registry.ParseJSONOptions(description.Script, description.Options, gaugeOpts)
-			if err != nil {
-				tErr = core.WrapErr(err, "failed to parse options").With("description", description)
-				break
+			if description.Adaptive != nil {
+				job.adaptive, job.minute = description.Adaptive, minute
+				spec = s.adaptiveJobSpec(job)
 			}
-
-			eid, err := s.Cron.AddJob(spec, &harvestJob{
-				database: s.Database,
-				cache:    s.Cache,
-				logger:   s.Logger,
-				registry: s.Registry,
-				cron:     spec,
-				jobID:    description.ID,
-				script:   description.Script,
-				codes:    batch,
-				options:  options,
-			})
+			job.cron = spec
+			eid, err := addEntry(spec, job)
 			if err != nil {
 				tErr = core.WrapErr(err, "failed to schedule harvest job").With("description", description)
+				break
 			}
 			entryIDs = append(entryIDs, eid)
 		}
@@ -115,5 +140,14 @@ func (s *simpleScheduler) AddJob(description core.JobDescription) error {
 			return tErr
 		}
 	}
+	s.setVersion(description)
+	if description.Paused {
+		s.pausedMu.Lock()
+		if s.paused == nil {
+			s.paused = map[string][]pausedEntry{}
+		}
+		s.paused[description.ID] = detached
+		s.pausedMu.Unlock()
+	}
 	return nil
 }
@@ -97,6 +97,40 @@ func TestAddJobAllAtOnce(t *testing.T) {
 	}
 }
 
+func TestAddJobOutliers(t *testing.T) {
+	scheduler, cron := setupScheduler(t)
+	defer scheduler.Stop()
+
+	err := scheduler.AddJob(core.JobDescription{
+		ID:      "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script:  "one_by_one",
+		Gauges:  map[string]json.RawMessage{"g001": []byte(`{"outliers": {"maxRate": 3}}`), "g002": []byte("{}")},
+		Options: json.RawMessage(`{"min": 200.0, "outliers": {"maxRate": 10}}`),
+	})
+
+	if assert.NoError(t, err) {
+		cron.AssertNumberOfCalls(t, "AddJob", 2)
+		job := cron.Calls[0].Arguments[1].(*harvestJob)
+		assert.Equal(t, &testscripts.OneByOneOptions{Gauges: 10, Min: 200.0, Max: 20}, job.options)
+		assert.Equal(t, 3.0, job.outliers.Get("g001").MaxRate)
+		assert.Equal(t, 10.0, job.outliers.Get("g002").MaxRate)
+	}
+}
+
+func TestAddJobBadOutliers(t *testing.T) {
+	scheduler, _ := setupScheduler(t)
+	defer scheduler.Stop()
+
+	err := scheduler.AddJob(core.JobDescription{
+		ID:      "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g001": []byte("{}")},
+		Cron:    "* * * * *",
+		Options: json.RawMessage(`{"outliers": {"maxRate": "foo"}}`),
+	})
+	assert.Error(t, err)
+}
+
 func TestAddJobOneByOne(t *testing.T) {
 	scheduler, cron := setupScheduler(t)
 	defer scheduler.Stop()
@@ -217,45 +251,50 @@ func TestAddJobBatched(t *testing.T) {
 
 	if assert.NoError(t, err) {
 		cron.AssertNumberOfCalls(t, "AddJob", 3)
+		opts := &testscripts.BatchedOptions{Gauges: 10, BatchSize: 3, Min: 200.0, Max: 20}
+		g002Opts := &testscripts.BatchedOptions{Gauges: 10, BatchSize: 3, Min: 100.0, Max: 300}
 
 		assert.Equal(t, "0 * * * *", cron.Calls[0].Arguments[0])
 		assert.Equal(t, &harvestJob{
-			database: scheduler.Database,
-			cache:    scheduler.Cache,
-			logger:   scheduler.Logger,
-			registry: scheduler.Registry,
-			cron:     "0 * * * *",
-			jobID:    "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
-			script:   "batched",
-			codes:    core.StringSet{"g001": {}, "g002": {}, "g003": {}},
-			options:  &testscripts.BatchedOptions{Gauges: 10, BatchSize: 3, Min: 200.0, Max: 20},
+			database:     scheduler.Database,
+			cache:        scheduler.Cache,
+			logger:       scheduler.Logger,
+			registry:     scheduler.Registry,
+			cron:         "0 * * * *",
+			jobID:        "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+			script:       "batched",
+			codes:        core.StringSet{"g001": {}, "g003": {}, "g004": {}},
+			options:      opts,
+			gaugeOptions: core.GaugeOptions{"g001": opts, "g003": opts, "g004": opts},
 		}, cron.Calls[0].Arguments[1])
 
 		assert.Equal(t, "20 * * * *", cron.Calls[1].Arguments[0])
 		assert.Equal(t, &harvestJob{
-			database: scheduler.Database,
-			cache:    scheduler.Cache,
-			logger:   scheduler.Logger,
-			registry: scheduler.Registry,
-			cron:     "20 * * * *",
-			script:   "batched",
-			jobID:    "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
-			codes:    core.StringSet{"g004": {}, "g005": {}, "g006": {}},
-			options:  &testscripts.BatchedOptions{Gauges: 10, BatchSize: 3, Min: 200.0, Max: 20},
+			database:     scheduler.Database,
+			cache:        scheduler.Cache,
+			logger:       scheduler.Logger,
+			registry:     scheduler.Registry,
+			cron:         "20 * * * *",
+			script:       "batched",
+			jobID:        "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+			codes:        core.StringSet{"g005": {}, "g006": {}, "g007": {}},
+			options:      opts,
+			gaugeOptions: core.GaugeOptions{"g005": opts, "g006": opts, "g007": opts},
 		}, cron.Calls[1].Arguments[1])
 
+		// gauge with different options is harvested in separate batch
 		assert.Equal(t, "40 * * * *", cron.Calls[2].Arguments[0])
 		assert.Equal(t, &harvestJob{
-			database: scheduler.Database,
-			cache:    scheduler.Cache,
-			logger:   scheduler.Logger,
-			registry: scheduler.Registry,
-			cron:     "40 * * * *",
-			script:   "batched",
-			jobID:    "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
-			codes:    core.StringSet{"g007": {}},
-			options:  &testscripts.BatchedOptions{Gauges: 10, BatchSize: 3, Min: 200.0, Max: 20},
+			database:     scheduler.Database,
+			cache:        scheduler.Cache,
+			logger:       scheduler.Logger,
+			registry:     scheduler.Registry,
+			cron:         "40 * * * *",
+			script:       "batched",
+			jobID:        "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+			codes:        core.StringSet{"g002": {}},
+			options:      g002Opts,
+			gaugeOptions: core.GaugeOptions{"g002": g002Opts},
 		}, cron.Calls[2].Arguments[1])
-
 	}
 }
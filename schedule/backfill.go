@@ -0,0 +1,287 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// backfillPageTimeout limits time spent on harvesting single backfill page
+const backfillPageTimeout = 5 * time.Minute
+
+// backfill is background process that harvests historical measurements of one job
+type backfill struct {
+	status core.BackfillStatus
+	cancel context.CancelFunc
+}
+
+// saveFunc is either SaveMeasurements or ReviseMeasurements of database manager, depending on job's save mode
+type saveFunc func(ctx context.Context, in <-chan *core.Measurement) (<-chan int, <-chan error)
+
+// backfillTask is set of codes that are harvested together by one script instance
+type backfillTask struct {
+	codes    core.StringSet
+	script   core.Backfiller
+	aliases  core.CodeAliases
+	curves   core.RatingCurves
+	outliers core.OutlierConfig
+	filters  core.FiltersConfig
+	// options of every gauge of batched script
+	gaugeOptions core.GaugeOptions
+}
+
+// Backfill implements core.JobScheduler interface
+func (s *simpleScheduler) Backfill(job core.JobDescription, req core.BackfillRequest) (*core.BackfillStatus, error) {
+	codes, err := backfillCodes(job, req.Codes)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.backfillTasks(job, codes)
+	if err != nil {
+		return nil, err
+	}
+	periods := core.SplitPeriod(req.From.UTC(), req.To.UTC(), tasks[0].script.BackfillPage())
+
+	s.backfillsMu.Lock()
+	defer s.backfillsMu.Unlock()
+	if s.backfills == nil {
+		s.backfills = map[string]*backfill{}
+	}
+	if prev, ok := s.backfills[job.ID]; ok && prev.status.State == core.BackfillRunning {
+		return nil, core.WrapErr(core.ErrBackfillRunning, "failed to start backfill").With("jobId", job.ID)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &backfill{
+		status: core.BackfillStatus{
+			JobID:     job.ID,
+			State:     core.BackfillRunning,
+			From:      core.HTime{Time: req.From.UTC()},
+			To:        core.HTime{Time: req.To.UTC()},
+			Pages:     len(tasks) * len(periods),
+			StartedAt: core.HTime{Time: time.Now().UTC()},
+		},
+		cancel: cancel,
+	}
+	s.backfills[job.ID] = b
+	status := b.status
+
+	logger := s.Logger.WithFields(logrus.Fields{"script": job.Script, "id": job.ID, "backfill": true})
+	s.backfillsWg.Add(1)
+	go s.runBackfill(ctx, logger, b, job, tasks, periods)
+
+	return &status, nil
+}
+
+// GetBackfill implements core.JobScheduler interface
+func (s *simpleScheduler) GetBackfill(jobID string) *core.BackfillStatus {
+	s.backfillsMu.Lock()
+	defer s.backfillsMu.Unlock()
+	b, ok := s.backfills[jobID]
+	if !ok {
+		return nil
+	}
+	status := b.status
+	return &status
+}
+
+func (s *simpleScheduler) cancelBackfills() {
+	s.backfillsMu.Lock()
+	defer s.backfillsMu.Unlock()
+	for _, b := range s.backfills {
+		b.cancel()
+	}
+}
+
+// backfillFilters returns filters config of job for backfill
+// Backfilled measurements are older than latest cached, so "latest" and "window" filters would reject them all
+func backfillFilters(config core.FiltersConfig) core.FiltersConfig {
+	result := core.FiltersConfig{}
+	for k, v := range config {
+		result[k] = v
+	}
+	result["latest"] = json.RawMessage("false")
+	delete(result, "window")
+	return result
+}
+
+// backfillCodes returns requested codes, or all job codes if none are requested
+func backfillCodes(job core.JobDescription, requested []string) ([]string, error) {
+	var codes []string
+	if len(requested) == 0 {
+		for code := range job.Gauges {
+			codes = append(codes, code)
+		}
+	} else {
+		for _, code := range requested {
+			if _, ok := job.Gauges[code]; !ok {
+				return nil, (&core.Error{Msg: "gauge is not part of the job"}).With("jobId", job.ID).With("code", code)
+			}
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return nil, (&core.Error{Msg: "job gauge codes must be specified"}).With("jobId", job.ID)
+	}
+	sort.Strings(codes)
+	return codes, nil
+}
+
+// backfillTasks splits codes into tasks the same way as harvest jobs are scheduled by AddJob
+func (s *simpleScheduler) backfillTasks(job core.JobDescription, codes []string) ([]backfillTask, error) {
+	mode, err := s.Registry.GetMode(job.Script)
+	if err != nil {
+		return nil, err
+	}
+	outliers, err := core.ParseOutlierConfig(job.Options, job.Gauges)
+	if err != nil {
+		return nil, core.WrapErr(err, "failed to parse outlier options").With("jobId", job.ID)
+	}
+
+	var batches []gaugeBatch
+	if mode == core.AllAtOnce {
+		options, err := s.Registry.ParseJSONOptions(job.Script, job.Options)
+		if err != nil {
+			return nil, core.WrapErr(err, "failed to parse options").With("jobId", job.ID)
+		}
+		batch := gaugeBatch{codes: core.StringSet{}, options: options}
+		for _, code := range codes {
+			batch.codes[code] = struct{}{}
+		}
+		batches = append(batches, batch)
+	} else {
+		batches, err = s.batchGauges(job, mode, codes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filters := backfillFilters(job.Filters)
+	if err := core.Filters.Validate(filters); err != nil {
+		return nil, core.WrapErr(err, "invalid filters").With("jobId", job.ID)
+	}
+
+	var tasks []backfillTask
+	for _, batch := range batches {
+		script, _, err := s.Registry.Create(job.Script, batch.options)
+		if err != nil {
+			return nil, err
+		}
+		backfiller, ok := script.(core.Backfiller)
+		if !ok {
+			return nil, core.WrapErr(core.ErrBackfillNotSupported, "failed to start backfill").With("script", job.Script)
+		}
+		tasks = append(tasks, backfillTask{codes: batch.codes, gaugeOptions: batch.gaugeOptions, script: backfiller, outliers: outliers, filters: filters})
+	}
+	return tasks, nil
+}
+
+func (s *simpleScheduler) runBackfill(ctx context.Context, logger *logrus.Entry, b *backfill, job core.JobDescription, tasks []backfillTask, periods [][2]time.Time) {
+	defer s.backfillsWg.Done()
+	defer b.cancel()
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			err = (&core.Error{Msg: "panic in backfill"}).With("panic", r)
+		}
+		s.backfillsMu.Lock()
+		defer s.backfillsMu.Unlock()
+		b.status.FinishedAt = &core.HTime{Time: time.Now().UTC()}
+		if err != nil {
+			b.status.State = core.BackfillFailed
+			b.status.Error = err.Error()
+			logError(logger, err)
+		} else {
+			b.status.State = core.BackfillDone
+			logger.Infof("backfill finished, saved %d measurements", b.status.Saved)
+		}
+	}()
+
+	logger.Infof("backfill started, %d pages", b.status.Pages)
+	// gauges can be renamed in upstream, backfill them under new codes
+	aliases, aliasesErr := s.Database.ListAliases(job.Script)
+	if aliasesErr != nil {
+		logger.Warnf("failed to load gauge aliases: %v", aliasesErr)
+	}
+	codeAliases := core.NewCodeAliases(job.Script, aliases)
+	curves, curvesErr := s.Database.ListRatingCurves(job.Script, "")
+	if curvesErr != nil {
+		logger.Warnf("failed to load rating curves: %v", curvesErr)
+	}
+	ratingCurves := core.NewRatingCurves(curves)
+	save := s.Database.SaveMeasurements
+	if job.SaveMode == core.SaveRevise {
+		save = s.Database.ReviseMeasurements
+	}
+	for _, task := range tasks {
+		task.codes, task.aliases, task.curves = codeAliases.ResolveSet(task.codes), codeAliases, ratingCurves
+		if script, ok := task.script.(core.Script); ok {
+			script.SetLogger(logger)
+		}
+		for _, period := range periods {
+			var saved int
+			saved, err = s.backfillPage(ctx, logger, job.Script, task, save, period[0], period[1])
+			s.backfillsMu.Lock()
+			b.status.Saved += saved
+			if err == nil {
+				b.status.PagesDone++
+			}
+			s.backfillsMu.Unlock()
+			if err != nil {
+				return
+			}
+			if err = ctx.Err(); err != nil {
+				err = core.WrapErr(err, "backfill canceled")
+				return
+			}
+		}
+	}
+}
+
+func (s *simpleScheduler) backfillPage(ctx context.Context, logger *logrus.Entry, scriptName string, task backfillTask, save saveFunc, from, to time.Time) (int, error) {
+	// pages share harvest slots with scheduled harvests, so that backfill doesn't flood upstream
+	if s.pool != nil {
+		release, _, err := s.pool.acquire(ctx, scriptName, s.Registry.GetHost(scriptName))
+		if err != nil {
+			return 0, core.WrapErr(err, "backfill canceled")
+		}
+		defer release()
+	}
+	ctx, cancel := context.WithTimeout(ctx, backfillPageTimeout)
+	defer cancel()
+
+	// latest measurements are not known during backfill, so outliers filter checks only bounds
+	filters, err := core.Filters.Build(core.FilterContext{
+		Logger:   logger,
+		Now:      time.Now(),
+		Codes:    task.codes,
+		Outliers: task.outliers,
+	}, task.filters)
+	if err != nil {
+		return 0, err
+	}
+	filters = append(filters, core.PeriodFilter{From: from, To: to})
+
+	in := make(chan *core.Measurement)
+	errCh := make(chan error, 1)
+	go task.script.Backfill(ctx, in, errCh, core.HarvestSpec{Codes: task.codes, GaugeOptions: task.gaugeOptions}, from, to)
+
+	filteredCh := core.FilterMeasurements(
+		ctx,
+		core.RenameCodes(ctx, in, task.aliases),
+		logger,
+		filters...,
+	)
+	savedCh, savedErrCh := save(ctx, core.DeriveFlows(ctx, filteredCh, task.curves))
+	harvestErr, saved, savedErr := <-errCh, <-savedCh, <-savedErrCh
+	if harvestErr != nil {
+		return saved, core.WrapErr(harvestErr, "backfill harvest error").With("from", from).With("to", to)
+	}
+	if savedErr != nil {
+		return saved, core.WrapErr(savedErr, "backfill db save error").With("from", from).With("to", to)
+	}
+	return saved, nil
+}
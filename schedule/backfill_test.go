@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+func TestBackfill(t *testing.T) {
+	scheduler, _ := setupScheduler(t)
+	defer scheduler.Stop()
+	require.NoError(t, scheduler.Database.Start())
+
+	job := core.JobDescription{
+		ID:      "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g000": nil, "g001": nil},
+		Options: json.RawMessage(`{"gauges": 3}`),
+	}
+	to := time.Now().UTC().Truncate(time.Hour).Add(-time.Hour)
+	from := to.Add(-47 * time.Hour)
+	status, err := scheduler.Backfill(job, core.BackfillRequest{
+		From:  core.HTime{Time: from},
+		To:    core.HTime{Time: to},
+		Codes: []string{"g001"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, core.BackfillRunning, status.State)
+	assert.Equal(t, 2, status.Pages)
+
+	assert.Eventually(t, func() bool {
+		return scheduler.GetBackfill(job.ID).State != core.BackfillRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	status = scheduler.GetBackfill(job.ID)
+	assert.Equal(t, core.BackfillDone, status.State)
+	assert.Equal(t, 2, status.PagesDone)
+	assert.Equal(t, 48, status.Saved)
+
+	measurements, err := scheduler.Database.GetMeasurements(storage.MeasurementsQuery{
+		Script: "all_at_once",
+		From:   &from,
+		To:     &to,
+	})
+	if assert.NoError(t, err) {
+		assert.Len(t, measurements, 48)
+		for _, m := range measurements {
+			assert.Equal(t, "g001", m.Code)
+		}
+	}
+}
+
+func TestBackfillJobFilters(t *testing.T) {
+	scheduler, _ := setupScheduler(t)
+	defer scheduler.Stop()
+	require.NoError(t, scheduler.Database.Start())
+
+	job := core.JobDescription{
+		ID:      "0c7f4b8e-2a6d-4c1b-9f0e-3d5a7b9c1e24",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g000": nil},
+		Options: json.RawMessage(`{"gauges": 3}`),
+		// latest filter would reject all measurements, but backfill disables it
+		Filters: core.FiltersConfig{"codes": json.RawMessage("false"), "latest": json.RawMessage("true")},
+	}
+	to := time.Now().UTC().Truncate(time.Hour).Add(-72 * time.Hour)
+	from := to.Add(-23 * time.Hour)
+	_, err := scheduler.Backfill(job, core.BackfillRequest{From: core.HTime{Time: from}, To: core.HTime{Time: to}})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return scheduler.GetBackfill(job.ID).State != core.BackfillRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	status := scheduler.GetBackfill(job.ID)
+	assert.Equal(t, core.BackfillDone, status.State)
+	// codes filter is disabled by job, so all gauges of upstream are saved
+	assert.Equal(t, 72, status.Saved)
+
+	job.Filters = core.FiltersConfig{"foo": json.RawMessage("true")}
+	_, err = scheduler.Backfill(job, core.BackfillRequest{From: core.HTime{Time: from}, To: core.HTime{Time: to}})
+	assert.Error(t, err)
+}
+
+func TestBackfillErrors(t *testing.T) {
+	scheduler, _ := setupScheduler(t)
+	defer scheduler.Stop()
+
+	req := core.BackfillRequest{
+		From: core.HTime{Time: time.Now().Add(-time.Hour)},
+		To:   core.HTime{Time: time.Now()},
+	}
+	_, err := scheduler.Backfill(core.JobDescription{
+		ID:     "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script: "one_by_one",
+		Gauges: map[string]json.RawMessage{"g000": nil},
+	}, req)
+	assert.ErrorIs(t, err, core.ErrBackfillNotSupported)
+
+	req.Codes = []string{"g001"}
+	_, err = scheduler.Backfill(core.JobDescription{
+		ID:     "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script: "all_at_once",
+		Gauges: map[string]json.RawMessage{"g000": nil},
+	}, req)
+	assert.Error(t, err)
+}
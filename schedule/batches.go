@@ -0,0 +1,85 @@
+package schedule
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// gaugeBatch is set of gauges that are harvested together
+type gaugeBatch struct {
+	codes core.StringSet
+	// options are script options of first gauge in batch
+	options interface{}
+	// gaugeOptions are script options of every gauge in batch, set for batched scripts only
+	gaugeOptions core.GaugeOptions
+}
+
+// batchGauges splits sorted gauge codes of one-by-one or batched job into batches
+// Gauges of batched scripts are grouped by their options, unless script honours options of every gauge in batch
+func (s *simpleScheduler) batchGauges(description core.JobDescription, mode core.HarvestMode, codes []string) ([]gaugeBatch, error) {
+	batchSize, honoursGaugeOptions := 1, false
+	if mode == core.Batched {
+		opts, err := s.Registry.ParseJSONOptions(description.Script, description.Options)
+		if err != nil {
+			return nil, core.WrapErr(err, "failed to parse options").With("jobId", description.ID)
+		}
+		bOpts, ok := opts.(core.BatchableOptions)
+		if !ok {
+			return nil, (&core.Error{Msg: "options are not batchable"}).With("jobId", description.ID)
+		}
+		batchSize = int(math.Max(1, float64(bOpts.GetBatchSize())))
+		if gOpts, ok := opts.(core.GaugeOptionsBatchable); ok {
+			honoursGaugeOptions = gOpts.HonoursGaugeOptions()
+		}
+	}
+
+	options := make(map[string]interface{}, len(codes))
+	var groups [][]string
+	groupIndex := map[string]int{}
+	for _, code := range codes {
+		opts, err := s.Registry.ParseJSONOptions(description.Script, description.Options, description.Gauges[code])
+		if err != nil {
+			return nil, core.WrapErr(err, "failed to parse options").With("jobId", description.ID).With("code", code)
+		}
+		options[code] = opts
+		key := ""
+		if mode == core.Batched && !honoursGaugeOptions {
+			raw, err := json.Marshal(opts)
+			if err != nil {
+				return nil, core.WrapErr(err, "failed to marshal options").With("jobId", description.ID).With("code", code)
+			}
+			key = string(raw)
+		}
+		i, ok := groupIndex[key]
+		if !ok {
+			i = len(groups)
+			groupIndex[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], code)
+	}
+
+	var batches []gaugeBatch
+	for _, group := range groups {
+		for i := 0; i < len(group); i += batchSize {
+			j := i + batchSize
+			if j > len(group) {
+				j = len(group)
+			}
+			batch := gaugeBatch{codes: core.StringSet{}, options: options[group[i]]}
+			if mode == core.Batched {
+				batch.gaugeOptions = core.GaugeOptions{}
+			}
+			for _, code := range group[i:j] {
+				batch.codes[code] = struct{}{}
+				if batch.gaugeOptions != nil {
+					batch.gaugeOptions[code] = options[code]
+				}
+			}
+			batches = append(batches, batch)
+		}
+	}
+	return batches, nil
+}
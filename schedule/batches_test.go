@@ -0,0 +1,74 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/scripts/testscripts"
+)
+
+type honouringOptions struct {
+	testscripts.BatchedOptions
+}
+
+func (o honouringOptions) HonoursGaugeOptions() bool {
+	return true
+}
+
+var honouring = &core.ScriptDescriptor{
+	Name:        "honouring",
+	Description: "Batched script that honours options of every gauge in batch",
+	Mode:        core.Batched,
+	DefaultOptions: func() interface{} {
+		return &honouringOptions{testscripts.BatchedOptions{BatchSize: 2}}
+	},
+	Factory: testscripts.Batched.Factory,
+}
+
+func TestBatchGauges(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Registry.Register(honouring)
+
+	description := core.JobDescription{
+		ID:     "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script: "batched",
+		Gauges: map[string]json.RawMessage{
+			"g001": nil,
+			"g002": json.RawMessage(`{"min": 100}`),
+			"g003": nil,
+			"g004": json.RawMessage(`{"min": 100}`),
+		},
+		Options: json.RawMessage(`{"batchSize": 2}`),
+	}
+	codes := []string{"g001", "g002", "g003", "g004"}
+
+	batches, err := scheduler.batchGauges(description, core.Batched, codes)
+	require.NoError(t, err)
+	if assert.Len(t, batches, 2) {
+		assert.Equal(t, core.StringSet{"g001": {}, "g003": {}}, batches[0].codes)
+		assert.Equal(t, core.StringSet{"g002": {}, "g004": {}}, batches[1].codes)
+		assert.Equal(t, 100.0, batches[1].options.(*testscripts.BatchedOptions).Min)
+	}
+
+	description.Script = "honouring"
+	batches, err = scheduler.batchGauges(description, core.Batched, codes)
+	require.NoError(t, err)
+	if assert.Len(t, batches, 2) {
+		assert.Equal(t, core.StringSet{"g001": {}, "g002": {}}, batches[0].codes)
+		assert.Equal(t, core.StringSet{"g003": {}, "g004": {}}, batches[1].codes)
+		assert.Equal(t, 100.0, batches[0].gaugeOptions["g002"].(*honouringOptions).Min)
+		assert.Equal(t, 0.0, batches[0].gaugeOptions["g001"].(*honouringOptions).Min)
+	}
+
+	description.Script = "one_by_one"
+	description.Options = json.RawMessage(`{}`)
+	description.Gauges = map[string]json.RawMessage{"g001": nil, "g002": nil, "g003": nil, "g004": nil}
+	batches, err = scheduler.batchGauges(description, core.OneByOne, codes)
+	require.NoError(t, err)
+	if assert.Len(t, batches, 4) {
+		assert.Nil(t, batches[0].gaugeOptions)
+	}
+}
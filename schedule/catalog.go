@@ -0,0 +1,160 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+const (
+	// catalogCron is schedule on which catalog job checks if catalogs of scripts must be refreshed
+	catalogCron = "@every 1h"
+	// catalogTTL is how often catalog of each script is refreshed
+	catalogTTL = 24 * time.Hour
+	// catalogTimeout limits time of listing gauges of single script
+	catalogTimeout = 5 * time.Minute
+	// catalogLeaseTTL is how long instance that refreshes catalog of script keeps this script for itself in coordinated mode
+	// It must be longer than time of refresh, but shorter than catalogCron interval
+	catalogLeaseTTL = 30 * time.Minute
+)
+
+// catalogJob lists gauges from upstream and saves them to gauge catalog
+// It refreshes catalogs of scripts that have harvest jobs, using options of every job of each script
+// Time of last refresh is taken from db, so catalogs are not refreshed more often than catalogTTL after restart or by other instances
+type catalogJob struct {
+	database storage.DatabaseManager
+	registry *core.ScriptRegistry
+	logger   *logrus.Entry
+	// leases are set when multiple gorge instances share same jobs, nil otherwise
+	leases   storage.LeaseManager
+	instance string
+	// ttl is how often catalog of each script is refreshed
+	ttl time.Duration
+
+	mu sync.Mutex
+}
+
+func newCatalogJob(database storage.DatabaseManager, registry *core.ScriptRegistry, logger *logrus.Entry, leases storage.LeaseManager, instance string) *catalogJob {
+	return &catalogJob{
+		database: database,
+		registry: registry,
+		logger:   logger.WithField("job", "catalog"),
+		leases:   leases,
+		instance: instance,
+		ttl:      catalogTTL,
+	}
+}
+
+func (job *catalogJob) Run() {
+	defer func() {
+		if r := recover(); r != nil {
+			job.logger.Errorf("panic in catalog job: %v", r)
+		}
+	}()
+	job.mu.Lock()
+	defer job.mu.Unlock()
+
+	jobs, err := job.database.ListJobs()
+	if err != nil {
+		logError(job.logger, core.WrapErr(err, "failed to list jobs"))
+		return
+	}
+	byScript := map[string][]core.JobDescription{}
+	var scripts []string
+	for _, j := range jobs {
+		if _, ok := byScript[j.Script]; !ok {
+			scripts = append(scripts, j.Script)
+		}
+		byScript[j.Script] = append(byScript[j.Script], j)
+	}
+	for _, script := range scripts {
+		logger := job.logger.WithField("script", script)
+		refreshed, err := job.database.GetCatalogRefreshTime(script)
+		if err != nil {
+			logError(logger, err)
+			continue
+		}
+		if time.Since(refreshed) < job.ttl {
+			continue
+		}
+		if job.leases != nil {
+			acquired, err := job.leases.AcquireLease("catalog:"+script, job.instance, catalogLeaseTTL)
+			if err != nil {
+				logError(logger, core.WrapErr(err, "failed to acquire catalog lease"))
+				continue
+			} else if !acquired {
+				continue
+			}
+		}
+		if err := job.refresh(script, byScript[script]); err != nil {
+			logError(logger, err)
+		}
+	}
+}
+
+// refresh lists gauges of script with options of every given job and saves them to catalog
+// Catalog is not updated if any listing fails, so that gauges are not removed from catalog because of upstream errors
+func (job *catalogJob) refresh(name string, jobs []core.JobDescription) error {
+	logger := job.logger.WithField("script", name)
+	ctx, cancel := context.WithTimeout(context.Background(), catalogTimeout)
+	defer cancel()
+
+	listed := core.StringSet{}
+	var gauges []core.Gauge
+	for _, description := range jobs {
+		options, err := job.registry.ParseJSONOptions(name, description.Options)
+		if err != nil {
+			return core.WrapErr(err, "failed to parse options").With("jobId", description.ID)
+		}
+		// jobs with same options list same gauges
+		raw, err := json.Marshal(options)
+		if err != nil {
+			return core.WrapErr(err, "failed to marshal options").With("jobId", description.ID)
+		}
+		if listed.Contains(string(raw)) {
+			continue
+		}
+		listed[string(raw)] = struct{}{}
+		script, _, err := job.registry.Create(name, options)
+		if err != nil {
+			return err
+		}
+		script.SetLogger(logger)
+		upstream, err := script.ListGauges(ctx)
+		if err != nil {
+			return core.WrapErr(err, "failed to list gauges").With("jobId", description.ID)
+		}
+		gauges = append(gauges, upstream...)
+	}
+	gauges = uniqueGauges(gauges)
+	// most likely upstream is broken, do not wipe catalog
+	if len(gauges) == 0 {
+		logger.Warn("upstream returned no gauges, catalog is not updated")
+		return nil
+	}
+	changes, err := job.database.SaveGauges(name, gauges)
+	if err != nil {
+		return core.WrapErr(err, "failed to save gauges")
+	}
+	logger.Infof("refreshed catalog of %d gauges, %d changes", len(gauges), len(changes))
+	return nil
+}
+
+// uniqueGauges removes gauges with repeated codes, first gauge is kept
+func uniqueGauges(gauges []core.Gauge) []core.Gauge {
+	seen := core.StringSet{}
+	result := gauges[:0]
+	for _, g := range gauges {
+		if seen.Contains(g.Code) {
+			continue
+		}
+		seen[g.Code] = struct{}{}
+		result = append(result, g)
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+func TestCatalogJob(t *testing.T) {
+	scheduler, _ := setupScheduler(t)
+	defer scheduler.Stop()
+	require.NoError(t, scheduler.Database.Start())
+	require.NoError(t, scheduler.Database.AddJob(core.JobDescription{
+		ID:      "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g000": nil},
+		Cron:    "* * * * *",
+		Options: json.RawMessage(`{"gauges": 3}`),
+	}, func(job core.JobDescription) error { return nil }))
+
+	require.NoError(t, scheduler.Database.AddJob(core.JobDescription{
+		ID:      "8cf6bad5-e517-47ee-a6a7-2deae454f232",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g000": nil},
+		Cron:    "* * * * *",
+		Options: json.RawMessage(`{"gauges": 5}`),
+	}, func(job core.JobDescription) error { return nil }))
+
+	defer scheduler.Database.DeleteJob("7bf5a9c4-d406-46dd-b596-1cdfd343e121", func(id string) error { return nil }) // nolint:errcheck
+	defer scheduler.Database.DeleteJob("8cf6bad5-e517-47ee-a6a7-2deae454f232", func(id string) error { return nil }) // nolint:errcheck
+
+	job := newCatalogJob(scheduler.Database, scheduler.Registry, scheduler.Logger, scheduler.Database.(storage.LeaseManager), "this")
+	job.Run()
+	gauges, err := scheduler.Database.ListGauges("all_at_once")
+	if assert.NoError(t, err) {
+		assert.Len(t, gauges, 5, "gauges listed with options of every job are saved")
+	}
+	history, err := scheduler.Database.ListGaugeChanges("all_at_once", "g000")
+	if assert.NoError(t, err) && assert.Len(t, history, 1) {
+		assert.Equal(t, core.GaugeAdded, history[0].Kind)
+	}
+
+	// refreshed recently, upstream is not listed again, even by new instance
+	refreshed, err := scheduler.Database.GetCatalogRefreshTime("all_at_once")
+	require.NoError(t, err)
+	newCatalogJob(scheduler.Database, scheduler.Registry, scheduler.Logger, nil, "").Run()
+	actual, err := scheduler.Database.GetCatalogRefreshTime("all_at_once")
+	if assert.NoError(t, err) {
+		assert.Equal(t, refreshed, actual)
+	}
+
+	// outdated catalog is refreshed by lease holder only
+	time.Sleep(10 * time.Millisecond)
+	other := newCatalogJob(scheduler.Database, scheduler.Registry, scheduler.Logger, scheduler.Database.(storage.LeaseManager), "other")
+	other.ttl, job.ttl = 0, 0
+	other.Run()
+	actual, err = scheduler.Database.GetCatalogRefreshTime("all_at_once")
+	if assert.NoError(t, err) {
+		assert.Equal(t, refreshed, actual, "catalog is not refreshed by other instance")
+	}
+	job.Run()
+	actual, err = scheduler.Database.GetCatalogRefreshTime("all_at_once")
+	if assert.NoError(t, err) {
+		assert.True(t, actual.After(refreshed))
+	}
+}
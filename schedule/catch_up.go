@@ -0,0 +1,71 @@
+package schedule
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// catchUp runs harvests that were missed while gorge was down
+// Cron entry is overdue if it was scheduled to run between its last run and now. Every overdue entry runs once,
+// after random delay within CatchUp period, so that upstreams are not stampeded. Entries that never ran are not overdue.
+// Returns number of scheduled catch-up runs
+func (s *simpleScheduler) catchUp(now time.Time) int {
+	if s.CatchUp <= 0 {
+		return 0
+	}
+	statuses, err := s.Cache.LoadJobStatuses()
+	if err != nil {
+		logError(s.Logger, core.WrapErr(err, "failed to load job statuses for catch-up"))
+		return 0
+	}
+	// gauge statuses of one-by-one jobs, loaded on demand
+	gaugeStatuses := map[string]map[string]core.Status{}
+	count := 0
+	for _, entry := range s.Cron.Entries() {
+		job, ok := entry.Job.(*harvestJob)
+		if !ok {
+			continue
+		}
+		status, ok := statuses[job.jobID]
+		if !ok {
+			continue
+		}
+		lastRun := status.LastRun.Time
+		if code, _ := job.codes.Only(); code != "" {
+			gStatuses, loaded := gaugeStatuses[job.jobID]
+			if !loaded {
+				gStatuses, err = s.Cache.LoadGaugeStatuses(job.jobID)
+				if err != nil {
+					logError(s.Logger, core.WrapErr(err, "failed to load gauge statuses for catch-up").With("jobId", job.jobID))
+				}
+				gaugeStatuses[job.jobID] = gStatuses
+			}
+			if gStatus, ok := gStatuses[code]; ok {
+				lastRun = gStatus.LastRun.Time
+			}
+		}
+		sched, err := cron.ParseStandard(job.cron)
+		if err != nil {
+			continue
+		}
+		missed := sched.Next(lastRun)
+		if !missed.Before(now) {
+			continue
+		}
+		delay := time.Duration(rand.Int63n(int64(s.CatchUp)))
+		logger := job.withLogger().WithField("catchUp", true)
+		if !now.Add(delay).Before(sched.Next(now)) {
+			logger.Debug("catch-up is not made, because next harvest is scheduled earlier")
+			continue
+		}
+		overdue := *job
+		if s.retries.schedule(job.jobID, delay, func() { overdue.run(logger, missed) }) {
+			logger.Infof("harvest missed at %s, catching up in %s", missed.Format(time.RFC3339), delay.Round(time.Second))
+			count++
+		}
+	}
+	return count
+}
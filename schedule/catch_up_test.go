@@ -0,0 +1,93 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+// statusesCache returns fixed statuses
+type statusesCache struct {
+	storage.CacheManager
+	jobs   map[string]core.Status
+	gauges map[string]map[string]core.Status
+}
+
+func (c *statusesCache) LoadJobStatuses() (map[string]core.Status, error) {
+	return c.jobs, nil
+}
+
+func (c *statusesCache) LoadGaugeStatuses(jobID string) (map[string]core.Status, error) {
+	return c.gauges[jobID], nil
+}
+
+func ranAt(year int, month time.Month, day, hour, min int) core.Status {
+	return core.Status{LastRun: core.HTime{Time: time.Date(year, month, day, hour, min, 0, 0, time.UTC)}}
+}
+
+func TestCatchUp(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	scheduler.CatchUp = 30 * time.Minute
+	defer scheduler.retries.stop()
+
+	const (
+		overdue   = "0b1c2d3e-4f50-4617-8283-949596979899"
+		recent    = "1c2d3e4f-5061-4728-9394-a5a6a7a8a9aa"
+		never     = "2d3e4f50-6172-4839-a4a5-b6b7b8b9babb"
+		oneByOne  = "3e4f5061-7283-494a-b5b6-c7c8c9cacbcc"
+		pausedJob = "4f506172-8394-4a5b-86c7-d8d9dadbdcdd"
+	)
+	for _, id := range []string{overdue, recent, never, pausedJob} {
+		require.NoError(t, scheduler.AddJob(core.JobDescription{
+			ID:      id,
+			Script:  "all_at_once",
+			Gauges:  map[string]json.RawMessage{"g000": nil},
+			Cron:    "0 3 * * *",
+			Options: json.RawMessage(`{"gauges": 1}`),
+			Paused:  id == pausedJob,
+		}))
+	}
+	// gauges are harvested at minutes 0 and 30
+	require.NoError(t, scheduler.AddJob(core.JobDescription{
+		ID:      oneByOne,
+		Script:  "one_by_one",
+		Gauges:  map[string]json.RawMessage{"g000": nil, "g001": nil},
+		Options: json.RawMessage(`{}`),
+	}))
+	scheduler.Cache = &statusesCache{
+		jobs: map[string]core.Status{
+			overdue:   ranAt(2020, time.May, 31, 3, 0),
+			recent:    ranAt(2020, time.June, 1, 3, 0),
+			oneByOne:  ranAt(2020, time.June, 1, 12, 5),
+			pausedJob: ranAt(2020, time.May, 31, 3, 0),
+		},
+		gauges: map[string]map[string]core.Status{
+			oneByOne: {
+				"g000": ranAt(2020, time.June, 1, 11, 0),
+				"g001": ranAt(2020, time.June, 1, 11, 30),
+			},
+		},
+	}
+
+	assert.Equal(t, 2, scheduler.catchUp(time.Date(2020, time.June, 1, 12, 10, 0, 0, time.UTC)))
+	assert.Equal(t, 1, scheduler.retries.pending(overdue))
+	assert.Equal(t, 1, scheduler.retries.pending(oneByOne), "only gauge harvested at minute 0 is overdue")
+	assert.Equal(t, 0, scheduler.retries.pending(recent))
+	assert.Equal(t, 0, scheduler.retries.pending(never))
+	assert.Equal(t, 0, scheduler.retries.pending(pausedJob))
+
+	require.NoError(t, scheduler.DeleteJob(overdue))
+	assert.Equal(t, 0, scheduler.retries.pending(overdue), "catch-up is cancelled with job")
+
+	t.Run("disabled", func(t *testing.T) {
+		scheduler.CatchUp = 0
+		assert.Equal(t, 0, scheduler.catchUp(time.Date(2020, time.June, 1, 12, 10, 0, 0, time.UTC)))
+	})
+}
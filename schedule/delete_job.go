@@ -6,8 +6,14 @@ import (
 
 // DeleteJob implements core.JobScheduler interface
 func (s *simpleScheduler) DeleteJob(jobID string) error {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
 	entries := s.Cron.Entries()
-	removed := false
+	removed := s.forgetPaused(jobID)
+	s.retries.cancel(jobID)
+	s.versionsMu.Lock()
+	delete(s.versions, jobID)
+	s.versionsMu.Unlock()
 	for _, entry := range entries {
 		job, ok := entry.Job.(*harvestJob)
 		if ok && job.jobID == jobID {
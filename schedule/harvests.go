@@ -0,0 +1,74 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultHarvestTimeout is used when neither job nor server config set harvest timeout
+const defaultHarvestTimeout = time.Minute
+
+// harvestCancelWait is how long cancelled harvests have to stop during shutdown
+const harvestCancelWait = 5 * time.Second
+
+var errStopping = errors.New("scheduler is stopping")
+
+// runningHarvests provides root context for harvests, so that on shutdown they can finish or be cancelled
+type runningHarvests struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	stopping bool
+	wg       sync.WaitGroup
+}
+
+func newRunningHarvests() *runningHarvests {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &runningHarvests{ctx: ctx, cancel: cancel}
+}
+
+// begin returns context for new harvest and function that must be called when harvest is done
+// Harvests cannot begin when scheduler is stopping
+func (h *runningHarvests) begin() (context.Context, func(), error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopping {
+		return nil, nil, errStopping
+	}
+	h.wg.Add(1)
+	return h.ctx, h.wg.Done, nil
+}
+
+// stop waits for running harvests to finish within grace period and cancels them after that
+// It returns false if cancelled harvests did not stop
+func (h *runningHarvests) stop(grace time.Duration, logger *logrus.Entry) bool {
+	h.mu.Lock()
+	h.stopping = true
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		h.cancel()
+		return true
+	case <-time.After(grace):
+	}
+	logger.Warnf("harvests did not finish in %s, cancelling them", grace)
+	h.cancel()
+	select {
+	case <-done:
+		return true
+	case <-time.After(harvestCancelWait):
+		logger.Error("cancelled harvests did not stop")
+		return false
+	}
+}
@@ -0,0 +1,59 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+func TestRunningHarvestsFinish(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	h := newRunningHarvests()
+	ctx, done, err := h.begin()
+	require.NoError(t, err)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		assert.NoError(t, ctx.Err(), "must not be cancelled within grace period")
+		done()
+	}()
+	assert.True(t, h.stop(time.Second, scheduler.Logger))
+	_, _, err = h.begin()
+	assert.Equal(t, errStopping, err)
+}
+
+func TestRunningHarvestsCancel(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	h := newRunningHarvests()
+	ctx, done, err := h.begin()
+	require.NoError(t, err)
+	go func() {
+		<-ctx.Done()
+		done()
+	}()
+	assert.True(t, h.stop(10*time.Millisecond, scheduler.Logger))
+	assert.Error(t, ctx.Err())
+}
+
+func TestJobTimeout(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	scheduler.Timeout = 3 * time.Minute
+	job := core.JobDescription{
+		ID:      "3b8e9f1c-6a2d-4a7e-9a57-1d5e3c9b2f40",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g000": nil},
+		Cron:    "5 * * * *",
+		Options: json.RawMessage(`{"gauges": 1}`),
+	}
+	require.NoError(t, scheduler.AddJob(job))
+	assert.Equal(t, 3*time.Minute, scheduler.Cron.Entries()[0].Job.(*harvestJob).timeout)
+
+	job.Timeout = &core.Duration{Duration: 10 * time.Minute}
+	require.NoError(t, scheduler.UpdateJob(job))
+	assert.Equal(t, 10*time.Minute, scheduler.Cron.Entries()[0].Job.(*harvestJob).timeout)
+}
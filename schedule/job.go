@@ -2,6 +2,9 @@ package schedule
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -9,6 +12,9 @@ import (
 	"github.com/whitewater-guide/gorge/storage"
 )
 
+// reviseLookback is how old measurements can be revised by jobs in revise mode, unless job configures filters itself
+const reviseLookback = 7 * 24 * time.Hour
+
 type harvestJob struct {
 	database storage.DatabaseManager
 	cache    storage.CacheManager
@@ -19,17 +25,54 @@ type harvestJob struct {
 	codes    core.StringSet
 	script   string
 	options  interface{}
+	saveMode core.SaveMode
+	outliers core.OutlierConfig
+	filters  core.FiltersConfig
+	leases   storage.LeaseManager
+	instance string
+	timeout  time.Duration
+	harvests *runningHarvests
+	pool     *harvestPool
+	retry    *core.RetryPolicy
+	retries  *retrier
+	// gaugeOptions are options of every gauge in batch, they're passed to batched scripts in harvest spec
+	gaugeOptions core.GaugeOptions
+	// attempt is number of retry, 0 for scheduled harvest
+	attempt int
+	// history is true when runs must be recorded in job runs history
+	history bool
+	// adaptive is set when cron of the entry is derived from publication interval of its gauges
+	adaptive *core.AdaptiveSchedule
+	// minute is offset of adaptive entry, that spreads gauges of job over time
+	minute int
 }
 
-func getSince(job *harvestJob, cache map[core.GaugeID]core.Measurement) int64 {
-	code, err := job.codes.Only()
-	if err == nil {
-		key := core.GaugeID{Script: job.script, Code: code}
-		if cached, ok := cache[key]; ok {
-			return cached.Timestamp.UTC().Unix()
+// getSinceMap returns timestamps of latest cached measurements of every job gauge
+func getSinceMap(job *harvestJob, cache map[core.GaugeID]core.Measurement) core.SinceMap {
+	since := make(core.SinceMap, len(job.codes))
+	for code := range job.codes {
+		if cached, ok := cache[core.GaugeID{Script: job.script, Code: code}]; ok {
+			since[code] = cached.Timestamp.UTC().Unix()
 		}
 	}
-	return 0
+	return since
+}
+
+// reviseFilters returns filters config for jobs in revise mode
+// Upstream can correct values older than latest cached, so they must reach db. Unless "latest" filter is configured by job,
+// it is replaced by "window" filter with bounded lookback, so that whole upstream history is not revised on every run
+func reviseFilters(config core.FiltersConfig) core.FiltersConfig {
+	if _, ok := config["latest"]; ok {
+		return config
+	}
+	result := core.FiltersConfig{
+		"latest": json.RawMessage("false"),
+		"window": json.RawMessage(fmt.Sprintf(`{"maxAge": %q}`, reviseLookback)),
+	}
+	for k, v := range config {
+		result[k] = v
+	}
+	return result
 }
 
 func logError(logger *logrus.Entry, err error) {
@@ -40,18 +83,115 @@ func logError(logger *logrus.Entry, err error) {
 	}
 }
 
-func (job harvestJob) Run() {
+func (job harvestJob) withLogger() *logrus.Entry {
 	logger := job.logger.WithField("script", job.script).WithField("id", job.jobID)
-	code, _ := job.codes.Only()
-	if code != "" {
+	if code, _ := job.codes.Only(); code != "" {
 		logger = logger.WithField("code", code)
 	}
+	return logger
+}
+
+func (job harvestJob) Run() {
+	job.run(job.withLogger(), time.Now())
+}
+
+// run harvests and schedules retry if harvest fails
+// tick is time when harvest was scheduled, instances that share jobs compete for lease of this tick
+func (job harvestJob) run(logger *logrus.Entry, tick time.Time) {
 	defer func() {
 		if r := recover(); r != nil {
 			logger.Error(r)
 		}
 	}()
 
+	if job.leases != nil {
+		key := job.leaseKey(tick)
+		acquired, err := job.leases.AcquireLease(key, job.instance, harvestLeaseTTL)
+		if err != nil {
+			// running without lease can harvest twice, which is better than not harvesting at all
+			logError(logger, core.WrapErr(err, "failed to acquire harvest lease, running anyway"))
+		} else if !acquired {
+			logger.Debug("harvest is run by another instance")
+			return
+		}
+	}
+
+	if _, err := job.harvest(logger); err != nil {
+		job.scheduleRetry(logger, time.Now())
+	}
+}
+
+// harvest runs script through filter/save/cache pipeline and saves statuses
+// It returns number of saved measurements and error that was saved in job status
+func (job harvestJob) harvest(logger *logrus.Entry) (saved int, statusErr error) {
+	code, _ := job.codes.Only()
+	mode, gErr := job.registry.GetMode(job.script)
+	run := core.JobRun{
+		JobID:     job.jobID,
+		Script:    job.script,
+		StartedAt: core.HTime{Time: time.Now()},
+		Attempt:   job.attempt,
+	}
+	if gErr == nil && mode == core.OneByOne {
+		run.Code = code
+	} else if gErr == nil && mode == core.Batched {
+		run.Codes = job.codes.Slice()
+		sort.Strings(run.Codes)
+	}
+	if job.history {
+		// registered before recover, so that it's called after recover sets status error
+		defer func() {
+			run.Duration = core.Duration{Duration: time.Since(run.StartedAt.Time)}
+			run.Saved = saved
+			if statusErr != nil {
+				run.Error = statusErr.Error()
+			}
+			if err := job.database.SaveJobRun(run); err != nil {
+				logError(logger, core.WrapErr(err, "save job run error"))
+			}
+		}()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(r)
+			statusErr = fmt.Errorf("panic in harvest: %v", r)
+		}
+	}()
+
+	root := context.Background()
+	if job.harvests != nil {
+		hCtx, done, err := job.harvests.begin()
+		if err != nil {
+			return 0, err
+		}
+		defer done()
+		root = hCtx
+	}
+	if job.pool != nil {
+		release, wait, err := job.pool.acquire(root, job.script, job.registry.GetHost(job.script))
+		if err != nil {
+			return 0, err
+		}
+		defer release()
+		if wait > time.Second {
+			logger.Debugf("waited %s for free harvest slot", wait.Round(time.Second))
+		}
+	}
+
+	// job codes can be renamed in upstream, harvest them under new codes
+	// job is passed by value, so this doesn't affect scheduled job
+	aliases, err := job.database.ListAliases(job.script)
+	if err != nil {
+		job.logger.Warnf("failed to load gauge aliases: %v", err)
+	}
+	codeAliases := core.NewCodeAliases(job.script, aliases)
+	job.codes = codeAliases.ResolveSet(job.codes)
+
+	curves, err := job.database.ListRatingCurves(job.script, "")
+	if err != nil {
+		job.logger.Warnf("failed to load rating curves: %v", err)
+	}
+
 	// get last values from redis cache
 	cache, err := job.cache.LoadLatestMeasurements(map[string]core.StringSet{job.script: job.codes})
 	if err != nil {
@@ -65,13 +205,40 @@ func (job harvestJob) Run() {
 		if ssErr != nil {
 			logError(logger, ssErr)
 		}
-		return
+		return 0, err
 	}
 	script.SetLogger(logger)
 
+	filtersConfig := job.filters
+	save := job.database.SaveMeasurements
+	if job.saveMode == core.SaveRevise {
+		// upstream can correct values older than latest cached, so they must reach db
+		save = job.database.ReviseMeasurements
+		filtersConfig = reviseFilters(job.filters)
+	}
+	filters, err := core.Filters.Build(core.FilterContext{
+		Logger:   logger,
+		Now:      time.Now(),
+		Codes:    job.codes,
+		Latest:   cache,
+		Outliers: job.outliers,
+	}, filtersConfig)
+	if err != nil {
+		logError(logger, err)
+		ssErr := job.cache.SaveStatus(job.jobID, code, err, 0)
+		if ssErr != nil {
+			logError(logger, ssErr)
+		}
+		return 0, err
+	}
+
 	in := make(chan *core.Measurement)
 	errCh := make(chan error, 1)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	timeout := job.timeout
+	if timeout <= 0 {
+		timeout = defaultHarvestTimeout
+	}
+	ctx, cancel := context.WithTimeout(root, timeout)
 	defer cancel()
 
 	go func() {
@@ -80,22 +247,25 @@ func (job harvestJob) Run() {
 				logger.Errorf("panic in harvest: %v", r)
 			}
 		}()
-		script.Harvest(ctx, in, errCh, job.codes, getSince(&job, cache))
+		script.Harvest(ctx, in, errCh, core.HarvestSpec{Codes: job.codes, Since: getSinceMap(&job, cache), GaugeOptions: job.gaugeOptions})
 	}()
-	filteredCh := core.FilterMeasurements(
+	harvestedCh, harvestedCntCh := core.Count(ctx, in)
+	filteredCh, filterStatsCh := core.FilterMeasurementsWithStats(
 		ctx,
-		in,
+		core.RenameCodes(ctx, harvestedCh, codeAliases),
 		logger,
-		core.PartitionRangeFilter{Logger: logger, Now: time.Now(), FutureTolerance: 24 * time.Hour},
-		core.CodesFilter{Codes: job.codes},
-		core.LatestFilter{Latest: cache, After: time.Now().Add(time.Duration(-30*24) * time.Hour)},
+		filters...,
 	)
-	cacheIn, dbIn := core.Split(ctx, filteredCh)
-	savedCh, savedErrCh := job.database.SaveMeasurements(ctx, dbIn)
+	cacheIn, dbIn := core.Split(ctx, core.DeriveFlows(ctx, filteredCh, core.NewRatingCurves(curves)))
+	cacheIn, cachedCntCh := core.Count(ctx, cacheIn)
+	savedCh, savedErrCh := save(ctx, dbIn)
 	cachedErrCh := job.cache.SaveLatestMeasurements(ctx, cacheIn)
 	harvestErr, saved, savedErr, cachedErr := <-errCh, <-savedCh, <-savedErrCh, <-cachedErrCh
+	// pipeline can be stuck if one of its consumers failed, cancel it before collecting counts
+	cancel()
+	run.Harvested, run.Filters, run.Cached = <-harvestedCntCh, <-filterStatsCh, <-cachedCntCh
 
-	statusErr := <-errCh
+	statusErr = harvestErr
 	if statusErr == nil {
 		statusErr = savedErr
 	}
@@ -109,7 +279,6 @@ func (job harvestJob) Run() {
 		logError(logger, core.WrapErr(ssErr, "save job status error"))
 	}
 	// For one-by-one jobs also save gauge status
-	mode, gErr := job.registry.GetMode(job.script)
 	if gErr == nil && mode == core.OneByOne {
 		gErr := job.cache.SaveStatus(job.jobID, code, statusErr, saved)
 		if gErr != nil {
@@ -134,4 +303,5 @@ func (job harvestJob) Run() {
 			logger.Debugf("saved %d measurements", saved)
 		}
 	}
+	return saved, statusErr
 }
@@ -1,38 +1,47 @@
 package schedule
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
-	"github.com/mattn/go-nulltype"
 	"github.com/stretchr/testify/assert"
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func TestGetSince(t *testing.T) {
-	assert := assert.New(t)
+func TestGetSinceMap(t *testing.T) {
 	job := &harvestJob{
-		cron:   "10 * * * *",
-		script: "one-by-one",
+		script: "batched",
 		jobID:  "f45829f1-357c-4b48-aa77-ee1edfa02e38",
-		codes:  core.StringSet{"g001": {}},
+		codes:  core.StringSet{"g001": {}, "g002": {}},
 	}
+	ts := time.Date(2000, time.January, 1, 1, 1, 1, 1, time.UTC)
 	cache := map[core.GaugeID]core.Measurement{
-		{
-			Script: "one-by-one",
-			Code:   "g001",
-		}: {
-			GaugeID: core.GaugeID{
-				Script: "one-by-one",
-				Code:   "g001",
-			},
-			Timestamp: core.HTime{Time: time.Date(2000, time.January, 1, 1, 1, 1, 1, time.UTC)},
-			Level:     nulltype.NullFloat64Of(100),
-			Flow:      nulltype.NullFloat64Of(100),
+		{Script: "batched", Code: "g001"}: {
+			GaugeID:   core.GaugeID{Script: "batched", Code: "g001"},
+			Timestamp: core.HTime{Time: ts},
 		},
+		{Script: "batched", Code: "g003"}: {
+			GaugeID:   core.GaugeID{Script: "batched", Code: "g003"},
+			Timestamp: core.HTime{Time: ts},
+		},
+	}
+	assert.Equal(t, core.SinceMap{"g001": ts.Unix()}, getSinceMap(job, cache))
+}
+
+func TestReviseFilters(t *testing.T) {
+	now := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
+	filters, err := core.Filters.Build(core.FilterContext{Now: now}, reviseFilters(nil))
+	if assert.NoError(t, err) {
+		assert.Contains(t, filters, core.WindowFilter{Now: now, MaxAge: reviseLookback}, "lookback is bounded by window filter")
+		for _, f := range filters {
+			assert.NotEqual(t, "latest", f.Name())
+		}
 	}
-	assert.Equal(
-		time.Date(2000, time.January, 1, 1, 1, 1, 1, time.UTC).Unix(),
-		getSince(job, cache),
-	)
+
+	config := core.FiltersConfig{"latest": json.RawMessage(`{"window": "48h"}`)}
+	assert.Equal(t, config, reviseFilters(config), "configured latest filter is kept")
+
+	config = core.FiltersConfig{"window": json.RawMessage(`{"maxAge": "720h"}`)}
+	assert.Equal(t, core.FiltersConfig{"latest": json.RawMessage("false"), "window": json.RawMessage(`{"maxAge": "720h"}`)}, reviseFilters(config), "configured window filter is kept")
 }
@@ -2,10 +2,13 @@ package schedule
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
+	"github.com/whitewater-guide/gorge/config"
 	"github.com/whitewater-guide/gorge/core"
 	"github.com/whitewater-guide/gorge/storage"
 	"go.uber.org/fx"
@@ -19,19 +22,38 @@ type SchedulerParams struct {
 	Cache    storage.CacheManager
 	Registry *core.ScriptRegistry
 	Logger   *logrus.Logger
+	Config   *config.Config
+	Leases   storage.LeaseManager `optional:"true"`
 }
 
 func newCron() Cron {
 	return cron.New(cron.WithLocation(time.UTC))
 }
 
-func newSimpleScheduler(lc fx.Lifecycle, p SchedulerParams) core.JobScheduler {
+func newSimpleScheduler(lc fx.Lifecycle, p SchedulerParams) (core.JobScheduler, error) {
+	pool, err := newHarvestPool(p.Config.Scheduler)
+	if err != nil {
+		return nil, err
+	}
 	scheduler := &simpleScheduler{
-		Database: p.Database,
-		Cache:    p.Cache,
-		Registry: p.Registry,
-		Cron:     p.Cron,
-		Logger:   p.Logger.WithField("logger", "scheduler"),
+		Database:      p.Database,
+		Cache:         p.Cache,
+		Registry:      p.Registry,
+		Cron:          p.Cron,
+		Logger:        p.Logger.WithField("logger", "scheduler"),
+		Leases:        p.Leases,
+		Instance:      p.Config.Scheduler.Instance,
+		Timeout:       time.Duration(p.Config.Scheduler.Timeout) * time.Second,
+		Grace:         time.Duration(p.Config.Scheduler.Grace) * time.Second,
+		RunsRetention: time.Duration(p.Config.Scheduler.RunsRetention) * 24 * time.Hour,
+		CatchUp:       time.Duration(p.Config.Scheduler.CatchUp) * time.Second,
+		harvests:      newRunningHarvests(),
+		pool:          pool,
+	}
+	if scheduler.Leases != nil && scheduler.Instance == "" {
+		// pid distinguishes instances that run on same host
+		hostname, _ := os.Hostname()
+		scheduler.Instance = fmt.Sprintf("%s-%d", hostname, os.Getpid())
 	}
 	lc.Append(fx.Hook{
 		OnStart: func(c context.Context) error {
@@ -49,7 +71,44 @@ func newSimpleScheduler(lc fx.Lifecycle, p SchedulerParams) core.JobScheduler {
 					scheduler.Logger.Errorf("failed to schedule initial jobs: %v", err)
 					return err
 				}
-				scheduler.Logger.WithFields(logrus.Fields{"script": job.Script, "jobID": job.ID}).Info("started job")
+				if job.Paused {
+					scheduler.Logger.WithFields(logrus.Fields{"script": job.Script, "jobID": job.ID}).Info("loaded paused job")
+				} else {
+					scheduler.Logger.WithFields(logrus.Fields{"script": job.Script, "jobID": job.ID}).Info("started job")
+				}
+			}
+			if n := scheduler.catchUp(time.Now()); n > 0 {
+				scheduler.Logger.Infof("catching up %d missed harvests within %s", n, scheduler.CatchUp)
+			}
+			if scheduler.Leases != nil {
+				if _, err := scheduler.Cron.AddJob(syncCron, cron.FuncJob(scheduler.syncJobs)); err != nil {
+					scheduler.Logger.Errorf("failed to schedule jobs sync: %v", err)
+					return err
+				}
+				scheduler.Logger.WithField("instance", scheduler.Instance).Info("running in coordinated mode")
+			}
+			catalog := newCatalogJob(scheduler.Database, scheduler.Registry, scheduler.Logger, scheduler.Leases, scheduler.Instance)
+			if _, err := scheduler.Cron.AddJob(catalogCron, catalog); err != nil {
+				scheduler.Logger.Errorf("failed to schedule catalog job: %v", err)
+				return err
+			}
+			// catalogs that are older than catalogTTL are refreshed right away instead of within an hour
+			go catalog.Run()
+			if _, err := scheduler.Cron.AddJob(selectCron, cron.FuncJob(scheduler.refreshSelections)); err != nil {
+				scheduler.Logger.Errorf("failed to schedule gauges selection: %v", err)
+				return err
+			}
+			if _, err := scheduler.Cron.AddJob(adaptCron, cron.FuncJob(scheduler.adaptJobs)); err != nil {
+				scheduler.Logger.Errorf("failed to schedule adaptive jobs: %v", err)
+				return err
+			}
+			// jobs are added with default intervals, learn actual ones in background
+			go scheduler.adaptJobs()
+			if scheduler.RunsRetention > 0 {
+				if _, err := scheduler.Cron.AddJob(runsCleanupCron, cron.FuncJob(scheduler.cleanupRuns)); err != nil {
+					scheduler.Logger.Errorf("failed to schedule job runs cleanup: %v", err)
+					return err
+				}
 			}
 
 			scheduler.Logger.Info("started")
@@ -62,7 +121,7 @@ func newSimpleScheduler(lc fx.Lifecycle, p SchedulerParams) core.JobScheduler {
 			return nil
 		},
 	})
-	return scheduler
+	return scheduler, nil
 }
 
 var Module = fx.Provide(
@@ -0,0 +1,87 @@
+package schedule
+
+import (
+	"github.com/robfig/cron/v3"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// pausedEntry is cron entry detached from cron, so that it can be reattached with same schedule
+type pausedEntry struct {
+	spec string
+	job  cron.Job
+}
+
+// PauseJob implements core.JobScheduler interface
+func (s *simpleScheduler) PauseJob(jobID string) error {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	s.pausedMu.Lock()
+	defer s.pausedMu.Unlock()
+	var detached []pausedEntry
+	for _, entry := range s.Cron.Entries() {
+		job, ok := entry.Job.(*harvestJob)
+		if ok && job.jobID == jobID {
+			s.Cron.Remove(entry.ID)
+			detached = append(detached, pausedEntry{spec: job.cron, job: job})
+		}
+	}
+	if len(detached) == 0 {
+		return (&core.Error{Msg: "specified job is not scheduled"}).With("job_id", jobID)
+	}
+	if s.paused == nil {
+		s.paused = map[string][]pausedEntry{}
+	}
+	s.paused[jobID] = append(s.paused[jobID], detached...)
+	s.retries.cancel(jobID)
+	s.Logger.Debugf("paused %d entries of job %s", len(detached), jobID)
+	return nil
+}
+
+// ResumeJob implements core.JobScheduler interface
+func (s *simpleScheduler) ResumeJob(description core.JobDescription) error {
+	description.Paused = false
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	s.pausedMu.Lock()
+	entries, ok := s.paused[description.ID]
+	delete(s.paused, description.ID)
+	s.pausedMu.Unlock()
+	if !ok {
+		return s.AddJob(description)
+	}
+
+	var entryIDs []cron.EntryID
+	for _, e := range entries {
+		eid, err := s.Cron.AddJob(e.spec, e.job)
+		if err != nil {
+			// rollback already resumed entries
+			for _, eid := range entryIDs {
+				s.Cron.Remove(eid)
+			}
+			s.pausedMu.Lock()
+			s.paused[description.ID] = entries
+			s.pausedMu.Unlock()
+			return core.WrapErr(err, "failed to resume harvest job").With("jobId", description.ID)
+		}
+		entryIDs = append(entryIDs, eid)
+	}
+	s.Logger.Debugf("resumed %d entries of job %s", len(entries), description.ID)
+	return nil
+}
+
+// isPaused returns true if job entries are detached from cron
+func (s *simpleScheduler) isPaused(jobID string) bool {
+	s.pausedMu.Lock()
+	defer s.pausedMu.Unlock()
+	_, ok := s.paused[jobID]
+	return ok
+}
+
+// forgetPaused drops detached entries of deleted job, returns false if job was not paused
+func (s *simpleScheduler) forgetPaused(jobID string) bool {
+	s.pausedMu.Lock()
+	defer s.pausedMu.Unlock()
+	_, ok := s.paused[jobID]
+	delete(s.paused, jobID)
+	return ok
+}
@@ -0,0 +1,66 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+func entrySpecs(c Cron) []string {
+	var specs []string
+	for _, e := range c.Entries() {
+		specs = append(specs, e.Job.(*harvestJob).cron)
+	}
+	return specs
+}
+
+func TestPauseResumeJob(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	job := core.JobDescription{
+		ID:      "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script:  "one_by_one",
+		Gauges:  map[string]json.RawMessage{"g000": nil, "g001": nil, "g002": nil},
+		Options: json.RawMessage(`{}`),
+	}
+	require.NoError(t, scheduler.AddJob(job))
+	specs := entrySpecs(scheduler.Cron)
+	require.Len(t, specs, 3)
+
+	require.NoError(t, scheduler.PauseJob(job.ID))
+	assert.Empty(t, scheduler.Cron.Entries())
+	assert.Empty(t, scheduler.ListNext(""))
+	assert.Error(t, scheduler.PauseJob(job.ID), "already paused")
+
+	require.NoError(t, scheduler.ResumeJob(job))
+	assert.ElementsMatch(t, specs, entrySpecs(scheduler.Cron))
+	assert.False(t, scheduler.isPaused(job.ID))
+
+	require.NoError(t, scheduler.PauseJob(job.ID))
+	require.NoError(t, scheduler.DeleteJob(job.ID))
+	assert.False(t, scheduler.isPaused(job.ID))
+}
+
+func TestAddPausedJob(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	job := core.JobDescription{
+		ID:      "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g000": nil},
+		Cron:    "5 * * * *",
+		Options: json.RawMessage(`{"gauges": 1}`),
+		Paused:  true,
+	}
+	require.NoError(t, scheduler.AddJob(job))
+	assert.Empty(t, scheduler.Cron.Entries())
+	assert.True(t, scheduler.isPaused(job.ID))
+
+	require.NoError(t, scheduler.ResumeJob(job))
+	assert.Equal(t, []string{"5 * * * *"}, entrySpecs(scheduler.Cron))
+}
@@ -0,0 +1,195 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/whitewater-guide/gorge/config"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// limiter is semaphore that counts harvests waiting for it
+type limiter struct {
+	limit int
+	slots chan struct{} // nil if there's no limit
+
+	mu        sync.Mutex
+	running   int
+	queued    int
+	started   int64
+	totalWait time.Duration
+	maxWait   time.Duration
+}
+
+func newLimiter(limit int) *limiter {
+	l := &limiter{limit: limit}
+	if limit > 0 {
+		l.slots = make(chan struct{}, limit)
+	}
+	return l
+}
+
+func (l *limiter) acquire(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	l.queued++
+	l.mu.Unlock()
+
+	start := time.Now()
+	var err error
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	wait := time.Since(start)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.queued--
+	if err != nil {
+		return wait, err
+	}
+	l.running++
+	l.started++
+	l.totalWait += wait
+	if wait > l.maxWait {
+		l.maxWait = wait
+	}
+	return wait, nil
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	l.running--
+	l.mu.Unlock()
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+func (l *limiter) stats() core.PoolStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := core.PoolStats{
+		Limit:   l.limit,
+		Running: l.running,
+		Queued:  l.queued,
+		Started: l.started,
+		MaxWait: core.Duration{Duration: l.maxWait},
+	}
+	if l.started > 0 {
+		s.AvgWait = core.Duration{Duration: l.totalWait / time.Duration(l.started)}
+	}
+	return s
+}
+
+// harvestPool limits number of harvests that run at the same time: globally, per script and per upstream host
+type harvestPool struct {
+	global       *limiter
+	scriptLimits map[string]int
+	hostLimits   map[string]int
+
+	mu      sync.Mutex
+	scripts map[string]*limiter
+	hosts   map[string]*limiter
+}
+
+func newHarvestPool(cfg config.SchedulerConfig) (*harvestPool, error) {
+	scriptLimits, err := parseLimits(cfg.ScriptWorkers)
+	if err != nil {
+		return nil, core.WrapErr(err, "invalid script workers limits")
+	}
+	hostLimits, err := parseLimits(cfg.HostWorkers)
+	if err != nil {
+		return nil, core.WrapErr(err, "invalid host workers limits")
+	}
+	return &harvestPool{
+		global:       newLimiter(cfg.Workers),
+		scriptLimits: scriptLimits,
+		hostLimits:   hostLimits,
+		scripts:      map[string]*limiter{},
+		hosts:        map[string]*limiter{},
+	}, nil
+}
+
+// parseLimits parses limits in 'key=N' format
+func parseLimits(values []string) (map[string]int, error) {
+	result := make(map[string]int, len(values))
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("expected 'name=N', got '%s'", v)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("expected non-negative number of workers, got '%s'", v)
+		}
+		result[strings.TrimSpace(parts[0])] = limit
+	}
+	return result, nil
+}
+
+func (p *harvestPool) limiters(script, host string) []*limiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// acquiring in same order everywhere prevents deadlocks
+	// global slot is acquired last, so that harvests waiting for their script or host do not occupy it
+	var result []*limiter
+	if host != "" {
+		l, ok := p.hosts[host]
+		if !ok {
+			l = newLimiter(p.hostLimits[host])
+			p.hosts[host] = l
+		}
+		result = append(result, l)
+	}
+	l, ok := p.scripts[script]
+	if !ok {
+		l = newLimiter(p.scriptLimits[script])
+		p.scripts[script] = l
+	}
+	return append(result, l, p.global)
+}
+
+// acquire waits until harvest of the script can run. It returns function that must be called when harvest is done,
+// and time spent waiting
+func (p *harvestPool) acquire(ctx context.Context, script, host string) (func(), time.Duration, error) {
+	var acquired []*limiter
+	release := func() {
+		for i := len(acquired) - 1; i >= 0; i-- {
+			acquired[i].release()
+		}
+	}
+	start := time.Now()
+	for _, l := range p.limiters(script, host) {
+		if _, err := l.acquire(ctx); err != nil {
+			release()
+			return nil, time.Since(start), err
+		}
+		acquired = append(acquired, l)
+	}
+	return release, time.Since(start), nil
+}
+
+func (p *harvestPool) stats() core.HarvestStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result := core.HarvestStats{
+		Global:  p.global.stats(),
+		Scripts: make(map[string]core.PoolStats, len(p.scripts)),
+		Hosts:   make(map[string]core.PoolStats, len(p.hosts)),
+	}
+	for name, l := range p.scripts {
+		result.Scripts[name] = l.stats()
+	}
+	for name, l := range p.hosts {
+		result.Hosts[name] = l.stats()
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/config"
+)
+
+func TestParseLimits(t *testing.T) {
+	limits, err := parseLimits([]string{"usgs=2", " norway = 1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"usgs": 2, "norway": 1}, limits)
+
+	for _, bad := range []string{"usgs", "=2", "usgs=a", "usgs=-1"} {
+		_, err := parseLimits([]string{bad})
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestHarvestPool(t *testing.T) {
+	pool, err := newHarvestPool(config.SchedulerConfig{
+		Workers:       3,
+		ScriptWorkers: []string{"one_by_one=1"},
+		HostWorkers:   []string{"example.com=2"},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	release, _, err := pool.acquire(ctx, "one_by_one", "")
+	require.NoError(t, err)
+
+	acquired := make(chan time.Duration)
+	go func() {
+		rel, wait, err := pool.acquire(ctx, "one_by_one", "")
+		assert.NoError(t, err)
+		acquired <- wait
+		rel()
+	}()
+	assert.Eventually(t, func() bool {
+		return pool.stats().Scripts["one_by_one"].Queued == 1
+	}, time.Second, time.Millisecond, "second harvest of script must wait")
+
+	// other scripts are not blocked
+	releaseOther, _, err := pool.acquire(ctx, "all_at_once", "example.com")
+	require.NoError(t, err)
+	stats := pool.stats()
+	assert.Equal(t, 2, stats.Global.Running)
+	assert.Equal(t, 1, stats.Hosts["example.com"].Running)
+	assert.Equal(t, 2, stats.Hosts["example.com"].Limit)
+	releaseOther()
+
+	time.Sleep(20 * time.Millisecond)
+	release()
+	assert.GreaterOrEqual(t, <-acquired, 20*time.Millisecond)
+
+	stats = pool.stats()
+	assert.Equal(t, int64(2), stats.Scripts["one_by_one"].Started)
+	assert.Equal(t, 0, stats.Scripts["one_by_one"].Running)
+	assert.GreaterOrEqual(t, stats.Scripts["one_by_one"].MaxWait.Duration, 20*time.Millisecond)
+	assert.Equal(t, 0, stats.Global.Running)
+}
+
+func TestHarvestPoolCancel(t *testing.T) {
+	pool, err := newHarvestPool(config.SchedulerConfig{Workers: 1})
+	require.NoError(t, err)
+	release, _, err := pool.acquire(context.Background(), "one_by_one", "")
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = pool.acquire(ctx, "all_at_once", "")
+	assert.Error(t, err)
+	stats := pool.stats()
+	assert.Equal(t, 1, stats.Global.Running)
+	assert.Equal(t, 0, stats.Global.Queued)
+	assert.Equal(t, 0, stats.Scripts["all_at_once"].Running, "script slot must be released")
+}
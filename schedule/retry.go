@@ -0,0 +1,120 @@
+package schedule
+
+import (
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// retrier keeps timers of pending harvest retries, so they can be cancelled when job is changed or scheduler is stopped
+type retrier struct {
+	mu      sync.Mutex
+	stopped bool
+	timers  map[string]map[*time.Timer]struct{}
+}
+
+// schedule runs f after delay, unless job's retries are cancelled before that
+func (r *retrier) schedule(jobID string, delay time.Duration, f func()) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return false
+	}
+	if r.timers == nil {
+		r.timers = map[string]map[*time.Timer]struct{}{}
+	}
+	if r.timers[jobID] == nil {
+		r.timers[jobID] = map[*time.Timer]struct{}{}
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		r.mu.Lock()
+		// timer could fire while being cancelled
+		_, ok := r.timers[jobID][timer]
+		delete(r.timers[jobID], timer)
+		r.mu.Unlock()
+		if ok {
+			f()
+		}
+	})
+	r.timers[jobID][timer] = struct{}{}
+	return true
+}
+
+// cancel drops pending retries of the job
+func (r *retrier) cancel(jobID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for timer := range r.timers[jobID] {
+		timer.Stop()
+	}
+	delete(r.timers, jobID)
+}
+
+// stop drops all pending retries, no retries can be scheduled after that
+func (r *retrier) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+	for _, timers := range r.timers {
+		for timer := range timers {
+			timer.Stop()
+		}
+	}
+	r.timers = nil
+}
+
+// pending returns number of retries waiting to run
+func (r *retrier) pending(jobID string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.timers[jobID])
+}
+
+// scheduleRetry schedules next retry of failed harvest, if job's retry policy allows it
+// Retries that would run after next scheduled harvest are not made
+func (job harvestJob) scheduleRetry(logger *logrus.Entry, now time.Time) {
+	if job.retry == nil || job.retries == nil || job.attempt >= job.retry.Attempts {
+		return
+	}
+	attempt := job.attempt + 1
+	delay := job.retry.Delay(attempt)
+	if sched, err := cron.ParseStandard(job.cron); err == nil && !now.Add(delay).Before(sched.Next(now)) {
+		logger.Debugf("retry %d is not made, because next harvest is scheduled earlier", attempt)
+		return
+	}
+	retry := job
+	retry.attempt = attempt
+	if job.retries.schedule(job.jobID, delay, retry.runRetry) {
+		logger.Infof("harvest failed, retry %d of %d in %s", attempt, job.retry.Attempts, delay)
+	}
+}
+
+// runRetry harvests again and records retry number in statuses
+func (job harvestJob) runRetry() {
+	logger := job.withLogger().WithField("retry", job.attempt)
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(r)
+		}
+	}()
+	_, err := job.harvest(logger)
+
+	if srErr := job.cache.SaveRetry(job.jobID, "", job.attempt); srErr != nil {
+		logError(logger, core.WrapErr(srErr, "save job retry error"))
+	}
+	if code, _ := job.codes.Only(); code != "" {
+		if mode, mErr := job.registry.GetMode(job.script); mErr == nil && mode == core.OneByOne {
+			if srErr := job.cache.SaveRetry(job.jobID, code, job.attempt); srErr != nil {
+				logError(logger, core.WrapErr(srErr, "save gauge retry error"))
+			}
+		}
+	}
+
+	if err != nil {
+		job.scheduleRetry(logger, time.Now())
+	}
+}
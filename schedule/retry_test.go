@@ -0,0 +1,65 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+func TestRetryFailedHarvest(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	require.NoError(t, scheduler.Database.Start())
+	cache := &storage.EmbeddedCacheManager{}
+	require.NoError(t, cache.Start())
+	defer cache.Close()
+
+	newJob := func(jobID, spec string) harvestJob {
+		options, err := scheduler.Registry.ParseJSONOptions("broken", nil)
+		require.NoError(t, err)
+		return harvestJob{
+			database: scheduler.Database,
+			cache:    cache,
+			registry: scheduler.Registry,
+			logger:   scheduler.Logger,
+			jobID:    jobID,
+			cron:     spec,
+			script:   "broken",
+			codes:    core.StringSet{"g000": {}},
+			options:  options,
+			retry:    &core.RetryPolicy{Attempts: 2, Backoff: core.Duration{Duration: 10 * time.Millisecond}},
+			retries:  &scheduler.retries,
+		}
+	}
+
+	t.Run("retries until attempts are exhausted", func(t *testing.T) {
+		job := newJob("0f3f8f36-2b55-4c38-a0f4-6b0c59a4c8a1", "0 0 1 1 *")
+		job.Run()
+		assert.Eventually(t, func() bool {
+			statuses, err := cache.LoadJobStatuses()
+			return err == nil && statuses[job.jobID].Retries == 2 && scheduler.retries.pending(job.jobID) == 0
+		}, time.Second, 10*time.Millisecond)
+		statuses, err := cache.LoadJobStatuses()
+		require.NoError(t, err)
+		assert.NotEmpty(t, statuses[job.jobID].Error)
+	})
+
+	t.Run("does not retry after next scheduled harvest", func(t *testing.T) {
+		job := newJob("5a9d7ee0-9d7e-4b7a-8f5f-0b8e2cf6d0a2", "* * * * *")
+		job.retry.Backoff = core.Duration{Duration: 2 * time.Minute}
+		job.scheduleRetry(scheduler.Logger, time.Now())
+		assert.Equal(t, 0, scheduler.retries.pending(job.jobID))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		job := newJob("9c1a3a0e-8a51-4d5c-b1e4-5b8e5c1d3f03", "0 0 1 1 *")
+		job.retry.Backoff = core.Duration{Duration: time.Hour}
+		job.scheduleRetry(scheduler.Logger, time.Now())
+		assert.Equal(t, 1, scheduler.retries.pending(job.jobID))
+		scheduler.retries.cancel(job.jobID)
+		assert.Equal(t, 0, scheduler.retries.pending(job.jobID))
+	})
+}
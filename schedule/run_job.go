@@ -0,0 +1,59 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// RunJob implements core.JobScheduler interface
+// Entries are run one after another in current goroutine, bypassing harvest leases
+// One-by-one jobs can only be run for single gauge, because harvesting all their gauges can take very long
+func (s *simpleScheduler) RunJob(jobID, code string) (*core.Status, error) {
+	var jobs []harvestJob
+	for _, entry := range s.Cron.Entries() {
+		if job, ok := entry.Job.(*harvestJob); ok && job.jobID == jobID {
+			jobs = append(jobs, *job)
+		}
+	}
+	// paused jobs can be run too
+	s.pausedMu.Lock()
+	for _, e := range s.paused[jobID] {
+		if job, ok := e.job.(*harvestJob); ok {
+			jobs = append(jobs, *job)
+		}
+	}
+	s.pausedMu.Unlock()
+	if len(jobs) == 0 {
+		return nil, (&core.Error{Msg: "specified job is not scheduled"}).With("job_id", jobID)
+	}
+
+	if code == "" {
+		if mode, err := s.Registry.GetMode(jobs[0].script); err == nil && mode == core.OneByOne {
+			return nil, (&core.Error{Msg: "gauge code is required to run one-by-one job"}).With("job_id", jobID)
+		}
+	} else {
+		var found []harvestJob
+		for _, job := range jobs {
+			if _, ok := job.codes[code]; ok {
+				job.codes = core.StringSet{code: {}}
+				found = append(found, job)
+				break
+			}
+		}
+		if len(found) == 0 {
+			return nil, (&core.Error{Msg: "job has no such gauge"}).With("job_id", jobID).With("code", code)
+		}
+		jobs = found
+	}
+
+	status := &core.Status{LastRun: core.HTime{Time: time.Now().UTC()}}
+	for _, job := range jobs {
+		saved, err := job.harvest(job.withLogger().WithField("manual", true))
+		status.Count += saved
+		if err != nil && status.Error == "" {
+			status.Error = err.Error()
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+func TestRunJob(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	require.NoError(t, scheduler.Database.Start())
+	cache := &storage.EmbeddedCacheManager{}
+	require.NoError(t, cache.Start())
+	defer cache.Close()
+	scheduler.Cache = cache
+
+	job := core.JobDescription{
+		ID:      "4d3c5e0e-8a0b-4b8e-9a59-4b1f3c2a7d10",
+		Script:  "one_by_one",
+		Gauges:  map[string]json.RawMessage{"g000": nil, "g001": nil, "g002": nil},
+		Options: json.RawMessage(`{}`),
+	}
+	require.NoError(t, scheduler.AddJob(job))
+
+	_, err := scheduler.RunJob(job.ID, "")
+	assert.Error(t, err, "one-by-one job cannot be run entirely")
+
+	status, err := scheduler.RunJob(job.ID, "g002")
+	require.NoError(t, err)
+	assert.Equal(t, 1, status.Count)
+	assert.Empty(t, status.Error)
+	assert.False(t, status.LastRun.IsZero())
+
+	statuses, err := cache.LoadGaugeStatuses(job.ID)
+	require.NoError(t, err)
+	assert.Len(t, statuses, 1)
+
+	t.Run("single gauge of paused job", func(t *testing.T) {
+		require.NoError(t, scheduler.PauseJob(job.ID))
+		status, err := scheduler.RunJob(job.ID, "g001")
+		require.NoError(t, err)
+		assert.Empty(t, status.Error)
+	})
+
+	t.Run("unknown gauge", func(t *testing.T) {
+		_, err := scheduler.RunJob(job.ID, "g100")
+		assert.Error(t, err)
+	})
+
+	t.Run("harvest error", func(t *testing.T) {
+		broken := core.JobDescription{
+			ID:     "6b1f0e2d-3c4a-4f5b-8e7d-9a0b1c2d3e4f",
+			Script: "broken",
+			Gauges: map[string]json.RawMessage{"g000": nil},
+			Cron:   "0 * * * *",
+		}
+		require.NoError(t, scheduler.AddJob(broken))
+		status, err := scheduler.RunJob(broken.ID, "")
+		require.NoError(t, err)
+		assert.NotEmpty(t, status.Error)
+		statuses, err := cache.LoadJobStatuses()
+		require.NoError(t, err)
+		assert.NotEmpty(t, statuses[broken.ID].Error, "harvest error is saved in job status")
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		_, err := scheduler.RunJob("9a8c4a6e-0f63-4c8c-8d5f-2f0f9c9f1b7e", "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,36 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/whitewater-guide/gorge/core"
+)
+
+const (
+	// runsCleanupCron is schedule on which runs older than retention period are deleted from job runs history
+	runsCleanupCron = "@every 1h"
+	// runsCleanupLeaseTTL is how long instance that cleaned up job runs keeps cleanup for itself in coordinated mode
+	// It must be shorter than runsCleanupCron interval, so that other instance can take over
+	runsCleanupLeaseTTL = 50 * time.Minute
+)
+
+// cleanupRuns deletes runs older than retention period. In coordinated mode only one instance cleans up runs
+func (s *simpleScheduler) cleanupRuns() {
+	if s.Leases != nil {
+		acquired, err := s.Leases.AcquireLease("runs-cleanup", s.Instance, runsCleanupLeaseTTL)
+		if err != nil {
+			logError(s.Logger, core.WrapErr(err, "failed to acquire job runs cleanup lease"))
+			return
+		} else if !acquired {
+			return
+		}
+	}
+	deleted, err := s.Database.DeleteJobRuns(time.Now().Add(-s.RunsRetention))
+	if err != nil {
+		logError(s.Logger, core.WrapErr(err, "failed to clean up job runs"))
+		return
+	}
+	if deleted > 0 {
+		s.Logger.Debugf("deleted %d old job runs", deleted)
+	}
+}
@@ -0,0 +1,87 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+func TestJobRunsHistory(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	scheduler.RunsRetention = time.Hour
+	require.NoError(t, scheduler.Database.Start())
+	cache := &storage.EmbeddedCacheManager{}
+	require.NoError(t, cache.Start())
+	defer cache.Close()
+	scheduler.Cache = cache
+
+	job := core.JobDescription{
+		ID:      "5f0e7b1a-3c2d-4e8f-9a6b-7c1d2e3f4a5b",
+		Script:  "one_by_one",
+		Gauges:  map[string]json.RawMessage{"g000": nil, "g001": nil},
+		Options: json.RawMessage(`{}`),
+	}
+	require.NoError(t, scheduler.AddJob(job))
+	_, err := scheduler.RunJob(job.ID, "g001")
+	require.NoError(t, err)
+
+	runs, err := scheduler.Database.ListJobRuns(job.ID, "", 10)
+	require.NoError(t, err)
+	if assert.Len(t, runs, 1) {
+		run := runs[0]
+		assert.Equal(t, "g001", run.Code)
+		assert.Equal(t, "one_by_one", run.Script)
+		assert.Equal(t, 1, run.Harvested)
+		assert.Equal(t, 1, run.Saved)
+		assert.Equal(t, 1, run.Cached)
+		assert.Empty(t, run.Error)
+		assert.NotEmpty(t, run.Filters)
+		for _, f := range run.Filters {
+			assert.LessOrEqual(t, f.Out, f.In, f.Name)
+		}
+	}
+
+	batched := core.JobDescription{
+		ID:      "6a1f8c2b-4d3e-4f9a-8b7c-8d2e3f4a5b6c",
+		Script:  "batched",
+		Gauges:  map[string]json.RawMessage{"g000": nil, "g001": nil, "g002": nil},
+		Options: json.RawMessage(`{"batchSize": 2}`),
+	}
+	require.NoError(t, scheduler.AddJob(batched))
+	_, err = scheduler.RunJob(batched.ID, "")
+	require.NoError(t, err)
+	runs, err = scheduler.Database.ListJobRuns(batched.ID, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, runs, 2, "every batch is recorded")
+	runs, err = scheduler.Database.ListJobRuns(batched.ID, "g002", 10)
+	require.NoError(t, err)
+	if assert.Len(t, runs, 1, "only batch of gauge is listed by code") {
+		assert.Empty(t, runs[0].Code)
+		assert.Contains(t, runs[0].Codes, "g002")
+	}
+
+	scheduler.RunsRetention = -time.Minute // everything is older than retention
+	scheduler.Leases, scheduler.Instance = cache, "this"
+	_, err = cache.AcquireLease("runs-cleanup", "other", time.Minute)
+	require.NoError(t, err)
+	scheduler.cleanupRuns()
+	runs, err = scheduler.Database.ListJobRuns(job.ID, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, runs, 1, "runs are cleaned up by lease holder only")
+
+	scheduler.Leases = nil
+	scheduler.cleanupRuns()
+	runs, err = scheduler.Database.ListJobRuns(job.ID, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+	runs, err = scheduler.Database.ListJobRuns(batched.ID, "g002", 10)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+}
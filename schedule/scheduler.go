@@ -2,6 +2,8 @@ package schedule
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
@@ -28,6 +30,45 @@ type simpleScheduler struct {
 	Registry *core.ScriptRegistry
 	Cron     Cron
 	Logger   *logrus.Entry
+	// Leases are set when multiple gorge instances share same jobs, nil otherwise
+	Leases storage.LeaseManager
+	// Instance is name of this gorge instance, it's used as lease holder
+	Instance string
+	// Timeout is default harvest timeout for jobs that do not set their own
+	Timeout time.Duration
+	// Grace is how long running harvests have to finish on shutdown before they're cancelled
+	Grace time.Duration
+	// RunsRetention is how long history of harvest runs is kept, runs are not recorded when it's 0
+	RunsRetention time.Duration
+	// CatchUp is period over which harvests missed while gorge was down are spread on start, 0 disables catch-up
+	CatchUp time.Duration
+
+	// harvests is nil when harvests are not tracked (in tests), they're never cancelled then
+	harvests *runningHarvests
+	// pool is nil when harvests are not limited (in tests)
+	pool *harvestPool
+
+	// jobsMu serializes changes of cron entries of scheduled jobs: updates, pauses, resumes, deletions and adaptive reschedules
+	jobsMu sync.Mutex
+
+	backfillsMu sync.Mutex
+	backfills   map[string]*backfill
+	// backfillsWg is waited by Stop, so that canceled backfills don't write to closed db
+	backfillsWg sync.WaitGroup
+
+	// pending retries of failed harvests and catch-up runs of missed harvests
+	retries retrier
+
+	pausedMu sync.Mutex
+	paused   map[string][]pausedEntry
+
+	// versions of scheduled job descriptions, so that jobs updated by other instances can be detected
+	versionsMu sync.Mutex
+	versions   map[string]string
+
+	// known publication intervals of gauges of adaptive jobs, they're learned and loaded by adaptJobs
+	intervalsMu sync.Mutex
+	intervals   map[core.GaugeID]time.Duration
 }
 
 // Start implements core.JobScheduler interface
@@ -36,9 +77,24 @@ func (s *simpleScheduler) Start() {
 	s.Cron.Start()
 }
 
+// HarvestStats implements core.JobScheduler interface
+func (s *simpleScheduler) HarvestStats() core.HarvestStats {
+	if s.pool == nil {
+		return core.HarvestStats{}
+	}
+	return s.pool.stats()
+}
+
 // Stop implements core.JobScheduler interface
 func (s *simpleScheduler) Stop() {
 	s.Logger.Info("stopping")
+	s.cancelBackfills()
+	s.backfillsWg.Wait()
+	s.retries.stop()
 	schedCtx := s.Cron.Stop()
+	if s.harvests != nil && !s.harvests.stop(s.Grace, s.Logger) {
+		// cron would wait for stuck harvests forever
+		return
+	}
 	<-schedCtx.Done()
 }
@@ -0,0 +1,127 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+const (
+	// selectCron is schedule on which gauges of jobs with gauge selectors are refreshed
+	selectCron = "@every 1h"
+	// selectLeaseTTL is how long instance that refreshed gauges of job keeps this job for itself in coordinated mode
+	// It must be shorter than selectCron interval, so that other instance can take over
+	selectLeaseTTL = 30 * time.Minute
+)
+
+// SelectGauges implements core.JobScheduler interface
+// Descriptions without selector are returned as is
+func (s *simpleScheduler) SelectGauges(ctx context.Context, description core.JobDescription) (core.JobDescription, error) {
+	if description.AllGauges == nil {
+		return description, nil
+	}
+	options, err := s.Registry.ParseJSONOptions(description.Script, description.Options)
+	if err != nil {
+		return description, core.WrapErr(err, "failed to parse options").With("jobId", description.ID)
+	}
+	ctx, cancel := context.WithTimeout(ctx, catalogTimeout)
+	defer cancel()
+	gauges, err := s.listUpstreamGauges(ctx, description.Script, options)
+	if err != nil {
+		return description, core.WrapErr(err, "failed to select gauges").With("jobId", description.ID)
+	}
+	selected, err := description.AllGauges.Select(gauges, description.Gauges)
+	if err != nil {
+		return description, core.WrapErr(err, "failed to select gauges").With("jobId", description.ID)
+	}
+	description.Gauges = selected
+	return description, nil
+}
+
+// listUpstreamGauges creates script with given options and lists its gauges
+// Gauge catalog is not used, because it contains gauges listed with options of every job of the script
+func (s *simpleScheduler) listUpstreamGauges(ctx context.Context, name string, options interface{}) (core.Gauges, error) {
+	script, _, err := s.Registry.Create(name, options)
+	if err != nil {
+		return nil, err
+	}
+	script.SetLogger(s.Logger.WithField("script", name))
+	gauges, err := script.ListGauges(ctx)
+	if err != nil {
+		return nil, core.WrapErr(err, "failed to list upstream gauges").With("script", name)
+	}
+	return gauges, nil
+}
+
+// refreshSelections updates gauges of jobs with gauge selectors and reschedules jobs which gauges have changed
+func (s *simpleScheduler) refreshSelections() {
+	jobs, err := s.Database.ListJobs()
+	if err != nil {
+		logError(s.Logger, core.WrapErr(err, "failed to list jobs for gauges selection"))
+		return
+	}
+	for _, job := range jobs {
+		if job.AllGauges == nil {
+			continue
+		}
+		if s.Leases != nil {
+			acquired, err := s.Leases.AcquireLease("select:"+job.ID, s.Instance, selectLeaseTTL)
+			if err != nil {
+				logError(s.Logger, core.WrapErr(err, "failed to acquire gauges selection lease").With("jobId", job.ID))
+				continue
+			} else if !acquired {
+				continue
+			}
+		}
+		if err := s.refreshSelection(job); err != nil {
+			logError(s.Logger, err)
+		}
+	}
+}
+
+// refreshSelection updates gauges of one job in database and in schedule, if upstream gauges have changed
+// Statuses of removed gauges are deleted
+func (s *simpleScheduler) refreshSelection(job core.JobDescription) error {
+	logger := s.Logger.WithFields(logrus.Fields{"script": job.Script, "jobID": job.ID})
+	job.Status = nil
+	selected, err := s.SelectGauges(context.Background(), job)
+	if err != nil {
+		return err
+	}
+	// most likely upstream is broken, do not remove all gauges
+	if len(selected.Gauges) == 0 {
+		logger.Warn("no upstream gauges are selected, job gauges are not updated")
+		return nil
+	}
+	var added, removed []string
+	for code := range selected.Gauges {
+		if _, ok := job.Gauges[code]; !ok {
+			added = append(added, code)
+		}
+	}
+	for code := range job.Gauges {
+		if _, ok := selected.Gauges[code]; !ok {
+			removed = append(removed, code)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	err = s.Database.UpdateJob(selected, func(prev, next core.JobDescription) error {
+		// job could be updated by user while upstream was listed
+		if jobVersion(prev) != jobVersion(job) {
+			return (&core.Error{Msg: "job was changed while its gauges were selected"}).With("jobId", job.ID)
+		}
+		return s.UpdateJob(next)
+	})
+	if err != nil {
+		return core.WrapErr(err, "failed to update selected gauges").With("jobId", job.ID)
+	}
+	if err := s.Cache.DeleteGaugeStatuses(job.ID, removed); err != nil {
+		logger.Warnf("failed to delete statuses of removed gauges: %v", err)
+	}
+	logger.WithFields(logrus.Fields{"added": added, "removed": removed}).Infof("updated selected gauges, %d added, %d removed", len(added), len(removed))
+	return nil
+}
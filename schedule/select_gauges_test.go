@@ -0,0 +1,113 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+func TestSelectGauges(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	require.NoError(t, scheduler.Database.Start())
+	description := core.JobDescription{
+		ID:        "5e7a9c1b-3d5f-4a7b-8c9d-0e1f2a3b4c5d",
+		Script:    "one_by_one",
+		Gauges:    map[string]json.RawMessage{"g001": json.RawMessage(`{"value":1}`), "g009": nil},
+		Options:   json.RawMessage(`{"gauges": 4}`),
+		AllGauges: &core.GaugeSelector{Name: "#[1-2]$"},
+	}
+	selected, err := scheduler.SelectGauges(context.Background(), description)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]json.RawMessage{"g001": json.RawMessage(`{"value":1}`), "g002": nil}, selected.Gauges)
+
+	description.AllGauges = nil
+	selected, err = scheduler.SelectGauges(context.Background(), description)
+	require.NoError(t, err)
+	assert.Equal(t, description, selected, "job without selector is returned as is")
+
+	description.Script, description.AllGauges = "broken", &core.GaugeSelector{}
+	_, err = scheduler.SelectGauges(context.Background(), description)
+	assert.Error(t, err)
+}
+
+func TestSelectGaugesWithJobOptions(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	require.NoError(t, scheduler.Database.Start())
+	// catalog contains gauges listed with options of both jobs
+	var catalog []core.Gauge
+	for _, code := range []string{"g000", "g001", "g002", "g003", "g004"} {
+		catalog = append(catalog, core.Gauge{GaugeID: core.GaugeID{Script: "batched", Code: code}})
+	}
+	_, err := scheduler.Database.SaveGauges("batched", catalog)
+	require.NoError(t, err)
+	defer scheduler.Database.SaveGauges("batched", nil) // nolint:errcheck
+
+	small := core.JobDescription{
+		ID:        "0b6f4d1e-8a2c-4f3e-9d5b-7c1a2e3f4a5b",
+		Script:    "batched",
+		Options:   json.RawMessage(`{"gauges": 2}`),
+		AllGauges: &core.GaugeSelector{},
+	}
+	large := core.JobDescription{
+		ID:        "1c7e5f2a-9b3d-4a4f-8e6c-8d2b3f4a5b6c",
+		Script:    "batched",
+		Options:   json.RawMessage(`{"gauges": 7}`),
+		AllGauges: &core.GaugeSelector{},
+	}
+	selected, err := scheduler.SelectGauges(context.Background(), small)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"g000", "g001"}, core.GaugesCodes(selected.Gauges).Slice(), "gauges of other job are not selected")
+	selected, err = scheduler.SelectGauges(context.Background(), large)
+	require.NoError(t, err)
+	assert.Len(t, selected.Gauges, 7, "gauges missing in catalog are selected")
+}
+
+func TestRefreshSelections(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	require.NoError(t, scheduler.Database.Start())
+	require.NoError(t, scheduler.Cache.Start())
+
+	const jobID = "6f8b0d2c-4e6a-4b8c-9dae-1f2a3b4c5d6e"
+	require.NoError(t, scheduler.Database.AddJob(core.JobDescription{
+		ID:        jobID,
+		Script:    "one_by_one",
+		Gauges:    map[string]json.RawMessage{"g000": json.RawMessage(`{"value":1}`), "g009": nil},
+		Options:   json.RawMessage(`{"gauges": 3}`),
+		AllGauges: &core.GaugeSelector{},
+	}, scheduler.AddJob))
+	defer scheduler.Database.DeleteJob(jobID, scheduler.DeleteJob) // nolint:errcheck
+	require.Len(t, scheduler.Cron.Entries(), 2)
+
+	scheduler.refreshSelections()
+	job, err := scheduler.Database.GetJob(jobID)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.ElementsMatch(t, []string{"g000", "g001", "g002"}, core.GaugesCodes(job.Gauges).Slice(), "g009 is removed, g001 and g002 are added")
+	assert.JSONEq(t, `{"value":1}`, string(job.Gauges["g000"]), "options of kept gauge are not changed")
+	assert.Len(t, scheduler.Cron.Entries(), 3)
+	assert.Equal(t, jobVersion(*job), scheduler.getVersion(jobID))
+
+	t.Run("nothing selected", func(t *testing.T) {
+		require.NoError(t, scheduler.Database.UpdateJob(core.JobDescription{
+			ID:        jobID,
+			Script:    "one_by_one",
+			Gauges:    job.Gauges,
+			Options:   json.RawMessage(`{"gauges": 3}`),
+			AllGauges: &core.GaugeSelector{Name: "^foo$"},
+		}, func(prev, job core.JobDescription) error {
+			return scheduler.UpdateJob(job)
+		}))
+		scheduler.refreshSelections()
+		updated, err := scheduler.Database.GetJob(jobID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"g000", "g001", "g002"}, core.GaugesCodes(updated.Gauges).Slice(), "gauges are kept")
+		assert.Len(t, scheduler.Cron.Entries(), 3)
+	})
+}
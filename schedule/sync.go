@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+const (
+	// syncCron is schedule on which coordinated instances pick up jobs that were added or deleted by other instances
+	syncCron = "@every 1m"
+	// harvestLeaseTTL is how long harvest lease is kept. It must be longer than clock difference between instances
+	// Leases are never released, so harvest cannot be repeated by instance that fired a bit later
+	harvestLeaseTTL = 10 * time.Minute
+)
+
+// syncJobs applies changes that were made by other instances: schedules added jobs, removes deleted jobs, pauses and resumes jobs
+func (s *simpleScheduler) syncJobs() {
+	// collect scheduled jobs first, so that jobs that are being added right now are not removed
+	scheduled := core.StringSet{}
+	for _, entry := range s.Cron.Entries() {
+		if job, ok := entry.Job.(*harvestJob); ok {
+			scheduled[job.jobID] = struct{}{}
+		}
+	}
+	s.pausedMu.Lock()
+	for id := range s.paused {
+		scheduled[id] = struct{}{}
+	}
+	s.pausedMu.Unlock()
+	jobs, err := s.Database.ListJobs()
+	if err != nil {
+		logError(s.Logger, core.WrapErr(err, "failed to list jobs for sync"))
+		return
+	}
+	stored := core.StringSet{}
+	for _, job := range jobs {
+		stored[job.ID] = struct{}{}
+		paused := s.isPaused(job.ID)
+		var action string
+		switch {
+		case !scheduled.Contains(job.ID):
+			// paused jobs are added detached
+			action, err = "started", s.AddJob(job)
+		case job.Paused && !paused:
+			action, err = "paused", s.PauseJob(job.ID)
+		case s.getVersion(job.ID) != jobVersion(job):
+			// paused flag is not part of version, so updated job keeps its paused state
+			action, err = "updated", s.UpdateJob(job)
+		case !job.Paused && paused:
+			action, err = "resumed", s.ResumeJob(job)
+		default:
+			continue
+		}
+		if err != nil {
+			logError(s.Logger, core.WrapErr(err, "failed to sync job").With("jobId", job.ID))
+			continue
+		}
+		s.Logger.WithFields(logrus.Fields{"script": job.Script, "jobID": job.ID}).Infof("%s synced job", action)
+	}
+	for id := range scheduled {
+		if stored.Contains(id) {
+			continue
+		}
+		if err := s.DeleteJob(id); err != nil {
+			logError(s.Logger, core.WrapErr(err, "failed to remove synced job").With("jobId", id))
+			continue
+		}
+		s.Logger.WithField("jobID", id).Info("removed synced job")
+	}
+}
+
+// jobVersion is hash of job description without paused flag and status
+// Description is normalized, because db can reformat raw json options
+func jobVersion(description core.JobDescription) string {
+	description.Paused, description.Status = false, nil
+	var normalized interface{}
+	raw, _ := json.Marshal(description)  // nolint:errcheck
+	_ = json.Unmarshal(raw, &normalized) // nolint:errcheck
+	raw, _ = json.Marshal(normalized)    // nolint:errcheck
+	h := fnv.New64a()
+	h.Write(raw) // nolint:errcheck
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+func (s *simpleScheduler) setVersion(description core.JobDescription) {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	if s.versions == nil {
+		s.versions = map[string]string{}
+	}
+	s.versions[description.ID] = jobVersion(description)
+}
+
+func (s *simpleScheduler) getVersion(jobID string) string {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	return s.versions[jobID]
+}
+
+// leaseKey identifies single run of harvest job entry
+// Cron fires at the start of minute, so rounded time is same on all instances
+func (job *harvestJob) leaseKey(now time.Time) string {
+	codes := job.codes.Slice()
+	sort.Strings(codes)
+	h := fnv.New64a()
+	for _, code := range codes {
+		h.Write([]byte(code)) // nolint:errcheck
+		h.Write([]byte{0})    // nolint:errcheck
+	}
+	return fmt.Sprintf("harvest:%s:%x:%d", job.jobID, h.Sum64(), now.Round(time.Minute).Unix())
+}
@@ -0,0 +1,98 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+func TestSyncJobs(t *testing.T) {
+	scheduler, c := setupScheduler(t)
+	defer scheduler.Stop()
+	require.NoError(t, scheduler.Database.Start())
+	require.NoError(t, scheduler.Database.AddJob(core.JobDescription{
+		ID:      "0d3bb0a2-6d0e-4b7a-9c39-2f8e7f1d5a11",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g000": nil},
+		Cron:    "* * * * *",
+		Options: json.RawMessage(`{"gauges": 1}`),
+	}, func(job core.JobDescription) error { return nil }))
+
+	c.On("Entries").Return([]cron.Entry{{ID: 7, Job: &harvestJob{jobID: "b6f3b3a4-2c2f-4d5e-8f0a-53a0f3d0c0de"}}})
+	c.On("Remove", cron.EntryID(7)).Return()
+	scheduler.syncJobs()
+
+	c.AssertCalled(t, "Remove", cron.EntryID(7))
+	c.AssertCalled(t, "AddJob", "* * * * *", mock.MatchedBy(func(job *harvestJob) bool {
+		return job.jobID == "0d3bb0a2-6d0e-4b7a-9c39-2f8e7f1d5a11"
+	}))
+}
+
+func TestHarvestLease(t *testing.T) {
+	scheduler, _ := setupScheduler(t)
+	defer scheduler.Stop()
+	require.NoError(t, scheduler.Database.Start())
+	options, err := scheduler.Registry.ParseJSONOptions("all_at_once", json.RawMessage(`{"gauges": 1}`))
+	require.NoError(t, err)
+
+	// instances share database leases, but have separate caches to tell which one has run
+	run := func(instance string) map[string]core.Status {
+		cache := &storage.EmbeddedCacheManager{}
+		require.NoError(t, cache.Start())
+		defer cache.Close()
+		job := harvestJob{
+			database: scheduler.Database,
+			cache:    cache,
+			registry: scheduler.Registry,
+			logger:   scheduler.Logger,
+			jobID:    "6a1f0f43-4c55-4a52-9d0c-8f1e34f0b3b7",
+			cron:     "* * * * *",
+			script:   "all_at_once",
+			codes:    core.StringSet{"g000": {}},
+			options:  options,
+			leases:   scheduler.Database.(storage.LeaseManager),
+			instance: instance,
+		}
+		job.Run()
+		statuses, err := cache.LoadJobStatuses()
+		require.NoError(t, err)
+		return statuses
+	}
+
+	assert.Contains(t, run("first"), "6a1f0f43-4c55-4a52-9d0c-8f1e34f0b3b7")
+	assert.Empty(t, run("second"))
+}
+
+func TestLeaseKey(t *testing.T) {
+	job := &harvestJob{jobID: "6a1f0f43-4c55-4a52-9d0c-8f1e34f0b3b7", codes: core.StringSet{"a": {}, "b": {}}}
+	other := &harvestJob{jobID: "6a1f0f43-4c55-4a52-9d0c-8f1e34f0b3b7", codes: core.StringSet{"c": {}}}
+	fired := time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, job.leaseKey(fired.Add(-2*time.Second)), job.leaseKey(fired.Add(3*time.Second)))
+	assert.NotEqual(t, job.leaseKey(fired), job.leaseKey(fired.Add(time.Minute)))
+	assert.NotEqual(t, job.leaseKey(fired), other.leaseKey(fired))
+}
+
+func TestJobVersion(t *testing.T) {
+	job := core.JobDescription{
+		ID:      "6a1f0f43-4c55-4a52-9d0c-8f1e34f0b3b7",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g000": json.RawMessage(`{"b": 1, "a": 2}`)},
+		Cron:    "* * * * *",
+		Options: json.RawMessage(`{"gauges": 1}`),
+	}
+	reformatted := job
+	reformatted.Gauges = map[string]json.RawMessage{"g000": json.RawMessage(`{"a":2,"b":1}`)}
+	reformatted.Paused = true
+	assert.Equal(t, jobVersion(job), jobVersion(reformatted))
+
+	changed := job
+	changed.Cron = "5 * * * *"
+	assert.NotEqual(t, jobVersion(job), jobVersion(changed))
+}
@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"github.com/robfig/cron/v3"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// UpdateJob implements core.JobScheduler interface
+// New entries are added before old ones are removed, so harvests are not missed, and failed update keeps old job running
+func (s *simpleScheduler) UpdateJob(description core.JobDescription) error {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	var old []cron.EntryID
+	for _, entry := range s.Cron.Entries() {
+		job, ok := entry.Job.(*harvestJob)
+		if ok && job.jobID == description.ID {
+			old = append(old, entry.ID)
+		}
+	}
+	s.pausedMu.Lock()
+	detached, wasPaused := s.paused[description.ID]
+	delete(s.paused, description.ID)
+	s.pausedMu.Unlock()
+
+	if err := s.AddJob(description); err != nil {
+		if wasPaused {
+			s.pausedMu.Lock()
+			s.paused[description.ID] = detached
+			s.pausedMu.Unlock()
+		}
+		return err
+	}
+	for _, eid := range old {
+		s.Cron.Remove(eid)
+	}
+	// pending retries belong to old entries
+	s.retries.cancel(description.ID)
+	s.Logger.Debugf("updated job %s, replaced %d entries", description.ID, len(old)+len(detached))
+	return nil
+}
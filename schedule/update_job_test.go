@@ -0,0 +1,41 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+func TestUpdateJob(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	job := core.JobDescription{
+		ID:      "7bf5a9c4-d406-46dd-b596-1cdfd343e121",
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"g000": nil},
+		Cron:    "5 * * * *",
+		Options: json.RawMessage(`{"gauges": 1}`),
+	}
+	require.NoError(t, scheduler.AddJob(job))
+
+	job.Cron = "10 * * * *"
+	require.NoError(t, scheduler.UpdateJob(job))
+	assert.Equal(t, []string{"10 * * * *"}, entrySpecs(scheduler.Cron))
+
+	bad := job
+	bad.Cron = "a * * * *"
+	assert.Error(t, scheduler.UpdateJob(bad))
+	assert.Equal(t, []string{"10 * * * *"}, entrySpecs(scheduler.Cron), "old job keeps running")
+
+	require.NoError(t, scheduler.PauseJob(job.ID))
+	job.Cron, job.Paused = "15 * * * *", true
+	require.NoError(t, scheduler.UpdateJob(job))
+	assert.Empty(t, scheduler.Cron.Entries())
+	require.NoError(t, scheduler.ResumeJob(job))
+	assert.Equal(t, []string{"15 * * * *"}, entrySpecs(scheduler.Cron))
+}
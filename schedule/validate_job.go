@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/robfig/cron/v3"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// ValidateJob implements core.JobScheduler interface
+// Problems are collected instead of failing on first one. Gauges are looked up in upstream only when script options are valid
+// Gauges of jobs with gauge selectors are selected first, and are not looked up again
+func (s *simpleScheduler) ValidateJob(ctx context.Context, description core.JobDescription) []core.JobProblem {
+	problems := description.Problems()
+	mode, err := s.Registry.GetMode(description.Script)
+	if err != nil {
+		return append(problems, core.JobProblem{Field: "script", Message: fmt.Sprintf("unknown script '%s'", description.Script)})
+	}
+	selected := description.AllGauges != nil
+	if selected && description.AllGauges.Validate() == nil {
+		// bad options are reported below
+		if _, err := s.Registry.ParseJSONOptions(description.Script, description.Options); err == nil {
+			if description, err = s.SelectGauges(ctx, description); err != nil {
+				problems = append(problems, core.JobProblem{Field: "allGauges", Message: err.Error()})
+			} else if len(description.Gauges) == 0 {
+				problems = append(problems, core.JobProblem{Field: "allGauges", Message: "no upstream gauges match selector"})
+			}
+		}
+	}
+	if description.Adaptive != nil && mode == core.AllAtOnce {
+		problems = append(problems, core.JobProblem{Field: "adaptive", Message: "adaptive schedule is supported by one-by-one and batched scripts only"})
+	}
+	if len(description.Gauges) == 0 && !selected {
+		problems = append(problems, core.JobProblem{Field: "gauges", Message: "job gauge codes must be specified"})
+	}
+	if _, err := core.ParseOutlierConfig(description.Options, description.Gauges); err != nil {
+		problems = append(problems, core.JobProblem{Field: "options", Message: fmt.Sprintf("failed to parse outlier options: %v", err)})
+	}
+	if mode == core.AllAtOnce {
+		if _, err := cron.ParseStandard(description.Cron); err != nil {
+			problems = append(problems, core.JobProblem{Field: "cron", Message: fmt.Sprintf("bad job cron: %v", err)})
+		}
+	}
+	options, err := s.Registry.ParseJSONOptions(description.Script, description.Options)
+	if err != nil {
+		return append(problems, core.JobProblem{Field: "options", Message: fmt.Sprintf("failed to parse options: %v", err)})
+	}
+	if _, ok := options.(core.BatchableOptions); mode == core.Batched && !ok {
+		problems = append(problems, core.JobProblem{Field: "options", Message: "options are not batchable"})
+	}
+	codes := make([]string, 0, len(description.Gauges))
+	for code := range description.Gauges {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	if mode != core.AllAtOnce {
+		for _, code := range codes {
+			if _, err := s.Registry.ParseJSONOptions(description.Script, description.Options, description.Gauges[code]); err != nil {
+				problems = append(problems, core.JobProblem{Field: "gauges", Code: code, Message: fmt.Sprintf("failed to parse options: %v", err)})
+			}
+		}
+	}
+	if selected {
+		return problems
+	}
+	return append(problems, s.findUpstreamGauges(ctx, description, options, codes)...)
+}
+
+// findUpstreamGauges returns problems for job gauges which are not listed by upstream with options of the job
+// Codes are resolved using aliases first, so gauges renamed in upstream are found by their old codes
+func (s *simpleScheduler) findUpstreamGauges(ctx context.Context, description core.JobDescription, options interface{}, codes []string) []core.JobProblem {
+	if len(codes) == 0 {
+		return nil
+	}
+	timeout := s.Timeout
+	if description.Timeout != nil {
+		timeout = description.Timeout.Duration
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	gauges, err := s.listUpstreamGauges(ctx, description.Script, options)
+	if err != nil {
+		return []core.JobProblem{{Field: "gauges", Message: err.Error()}}
+	}
+	upstream := core.StringSet{}
+	for _, g := range gauges {
+		upstream[g.Code] = struct{}{}
+	}
+	aliases, err := s.Database.ListAliases(description.Script)
+	if err != nil {
+		logError(s.Logger, core.WrapErr(err, "failed to list aliases").With("script", description.Script))
+	}
+	codeAliases := core.NewCodeAliases(description.Script, aliases)
+	var problems []core.JobProblem
+	for _, code := range codes {
+		resolved, err := codeAliases.Resolve(code)
+		if err != nil {
+			resolved = code
+		}
+		if !upstream.Contains(resolved) {
+			problems = append(problems, core.JobProblem{Field: "gauges", Code: code, Message: "gauge is not found in upstream"})
+		}
+	}
+	return problems
+}
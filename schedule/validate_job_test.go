@@ -0,0 +1,114 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+func TestValidateJob(t *testing.T) {
+	scheduler := newMockScheduler(t)
+	scheduler.Cron = cron.New(cron.WithLocation(time.UTC))
+	require.NoError(t, scheduler.Database.Start())
+	_, err := scheduler.Database.AddAlias(core.GaugeAlias{Script: "one_by_one", OldCode: "old", NewCode: "g003"})
+	require.NoError(t, err)
+	// catalog contains gauge listed with options of other job, it must not be used
+	_, err = scheduler.Database.SaveGauges("one_by_one", []core.Gauge{{GaugeID: core.GaugeID{Script: "one_by_one", Code: "g005"}}})
+	require.NoError(t, err)
+	defer scheduler.Database.SaveGauges("one_by_one", nil) // nolint:errcheck
+
+	fields := func(problems []core.JobProblem) []string {
+		var result []string
+		for _, p := range problems {
+			result = append(result, p.Field+":"+p.Code)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		description core.JobDescription
+		problems    []string
+	}{
+		{
+			name: "valid",
+			description: core.JobDescription{
+				ID:     "2a6e4a93-43e1-4c3a-96b0-7b1c0e5e0e6f",
+				Script: "one_by_one",
+				Gauges: map[string]json.RawMessage{"g000": nil, "old": nil},
+			},
+		},
+		{
+			name: "not found in upstream",
+			description: core.JobDescription{
+				ID:      "2a6e4a93-43e1-4c3a-96b0-7b1c0e5e0e6f",
+				Script:  "one_by_one",
+				Gauges:  map[string]json.RawMessage{"g000": nil, "g005": nil},
+				Options: json.RawMessage(`{"gauges": 3}`),
+			},
+			problems: []string{"gauges:g005"},
+		},
+		{
+			name: "not in catalog yet",
+			description: core.JobDescription{
+				ID:      "2a6e4a93-43e1-4c3a-96b0-7b1c0e5e0e6f",
+				Script:  "one_by_one",
+				Gauges:  map[string]json.RawMessage{"g006": nil},
+				Options: json.RawMessage(`{"gauges": 7}`),
+			},
+		},
+		{
+			name: "no gauges",
+			description: core.JobDescription{
+				ID:     "2a6e4a93-43e1-4c3a-96b0-7b1c0e5e0e6f",
+				Script: "batched",
+			},
+			problems: []string{"gauges:"},
+		},
+		{
+			name: "bad options skip upstream",
+			description: core.JobDescription{
+				ID:      "2a6e4a93-43e1-4c3a-96b0-7b1c0e5e0e6f",
+				Script:  "all_at_once",
+				Cron:    "* * * * *",
+				Gauges:  map[string]json.RawMessage{"g042": nil},
+				Options: json.RawMessage(`{"foo": 1}`),
+			},
+			problems: []string{"options:"},
+		},
+		{
+			name: "adaptive all at once",
+			description: core.JobDescription{
+				ID:       "2a6e4a93-43e1-4c3a-96b0-7b1c0e5e0e6f",
+				Script:   "all_at_once",
+				Cron:     "* * * * *",
+				Gauges:   map[string]json.RawMessage{"g000": nil},
+				Adaptive: &core.AdaptiveSchedule{MinInterval: core.Duration{Duration: 5 * time.Minute}, MaxInterval: core.Duration{Duration: time.Hour}},
+			},
+			problems: []string{"adaptive:"},
+		},
+		{
+			name: "broken upstream",
+			description: core.JobDescription{
+				ID:     "2a6e4a93-43e1-4c3a-96b0-7b1c0e5e0e6f",
+				Script: "broken",
+				Cron:   "* * * * *",
+				Gauges: map[string]json.RawMessage{"g000": nil},
+			},
+			problems: []string{"gauges:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := scheduler.ValidateJob(context.Background(), tt.description)
+			assert.Equal(t, tt.problems, fields(problems))
+		})
+	}
+	assert.Empty(t, scheduler.Cron.Entries(), "nothing is scheduled")
+}
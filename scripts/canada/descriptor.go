@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "canada",
 	Description: "Environment and Climate Change Canada",
 	Mode:        core.AllAtOnce,
+	Host:        "dd.weather.gc.ca",
 	DefaultOptions: func() interface{} {
 		return &optionsCanada{}
 	},
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/whitewater-guide/gorge/core"
 	"golang.org/x/text/encoding/charmap"
@@ -24,16 +25,14 @@ type scriptCanada struct {
 	core.LoggingScript
 }
 
-func (s *scriptCanada) ListGauges() (result core.Gauges, err error) {
-	client := core.Client
+func (s *scriptCanada) ListGauges(ctx context.Context) (result core.Gauges, err error) {
 	if s.timeoutSec != 0 {
-		client = core.NewClient(core.ClientOptions{
-			UserAgent: "whitewater.guide robot",
-			Timeout:   s.timeoutSec,
-		}, s.GetLogger())
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(s.timeoutSec)*time.Second)
+		defer cancel()
 	}
 
-	err = client.StreamCSV(
+	err = core.Client.WithContext(ctx).StreamCSV(
 		s.baseURL+"/doc/hydrometric_StationList.csv",
 		func(row []string) error {
 			g, err := s.gaugeFromRow(row)
@@ -55,17 +54,17 @@ func (s *scriptCanada) ListGauges() (result core.Gauges, err error) {
 	return
 }
 
-func (s *scriptCanada) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptCanada) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 	// Sometimes gauge list would contain main gauge, but measurements list would contain auxiliary gauge for it or vise versa.
 	// Auxiliary gauges are marked with X character, main gauges have 0. Some gauges have 1, I dont't know what it means
 	// I could not find any documentation on this, so I know this by trial and error
 	remapCodes := make(map[string]string)
-	if len(codes) > 0 {
-		for code := range codes {
+	if len(spec.Codes) > 0 {
+		for code := range spec.Codes {
 			code2 := getPairedGauge(code)
-			if _, ok := codes[code2]; !ok && code2 != code {
+			if _, ok := spec.Codes[code2]; !ok && code2 != code {
 				remapCodes[code2] = code
 			}
 		}
@@ -104,7 +103,7 @@ func (s *scriptCanada) provinceWorker(ctx context.Context, provinces <-chan stri
 		logger := s.GetLogger().WithField("province", province)
 
 		var m *core.Measurement
-		err := core.Client.StreamCSV(
+		err := core.Client.WithContext(ctx).StreamCSV(
 			fmt.Sprintf("%s/csv/%s/hourly/%s_hourly_hydrometric.csv", s.baseURL, province, province),
 			func(row []string) error {
 				select {
@@ -1,6 +1,7 @@
 package canada
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -23,7 +24,7 @@ func TestCanada_ListGauges(t *testing.T) {
 		baseURL:   ts.URL,
 		provinces: getProvinces(""),
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -135,7 +136,7 @@ func TestCanada_HarvestRemap(t *testing.T) {
 		},
 	}
 
-	actual, err := core.HarvestSlice(&s, core.StringSet{"08GA043": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"08GA043": {}})
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected, actual)
 	}
@@ -150,7 +151,7 @@ func TestCanada_HarvestProvinces(t *testing.T) {
 		numWokers: 2,
 		provinces: getProvinces(""),
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	if assert.NoError(t, err) {
 		assert.Len(t, actual, 11)
 	}
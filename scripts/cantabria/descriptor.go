@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "cantabria",
 	Description: "Confederación Hidrográfica del Cantábrico",
 	Mode:        core.AllAtOnce,
+	Host:        "www.chcantabrico.es",
 	DefaultOptions: func() interface{} {
 		return &optionsCantabria{}
 	},
@@ -2,6 +2,7 @@ package cantabria
 
 import (
 	"bufio"
+	"context"
 	"strconv"
 	"strings"
 
@@ -12,8 +13,8 @@ const preLen = len("var myCenter=new google.maps.LatLng(")
 const postLen = len(");\n")
 
 // http://saih.chminosil.es/index.php?url=/datos/ficha/estacion:N015
-func (s *scriptCantabria) parseGaugeLocation(code string) (result core.Location) {
-	resp, err := core.Client.Get(s.gaugeURLBase+code, nil)
+func (s *scriptCantabria) parseGaugeLocation(ctx context.Context, code string) (result core.Location) {
+	resp, err := core.Client.WithContext(ctx).Get(s.gaugeURLBase+code, nil)
 	if err != nil {
 		return
 	}
@@ -2,6 +2,7 @@ package cantabria
 
 import (
 	"bufio"
+	"context"
 	"strconv"
 	"strings"
 	"time"
@@ -21,12 +22,12 @@ type tableEntry struct {
 	measurement *core.Measurement
 }
 
-func (s *scriptCantabria) parseTable() (<-chan *tableEntry, <-chan error, error) {
+func (s *scriptCantabria) parseTable(ctx context.Context) (<-chan *tableEntry, <-chan error, error) {
 	location, err := time.LoadLocation("CET")
 	if err != nil {
 		return nil, nil, err
 	}
-	resp, err := core.Client.Get(s.listURL, nil)
+	resp, err := core.Client.WithContext(ctx).Get(s.listURL, nil)
 	if err != nil {
 		return nil, nil, err
 	}
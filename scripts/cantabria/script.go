@@ -15,8 +15,8 @@ type scriptCantabria struct {
 	core.LoggingScript
 }
 
-func (s *scriptCantabria) ListGauges() (core.Gauges, error) {
-	resCh, errCh, err := s.parseTable()
+func (s *scriptCantabria) ListGauges(ctx context.Context) (core.Gauges, error) {
+	resCh, errCh, err := s.parseTable(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +41,7 @@ outer:
 	gauges := make([]core.Gauge, len(result))
 
 	for w := 1; w <= 10; w++ {
-		go s.gaugePageWorker(jobsCh, resultsCh)
+		go s.gaugePageWorker(ctx, jobsCh, resultsCh)
 	}
 	for i := range result {
 		jobsCh <- result[i].gauge
@@ -57,10 +57,10 @@ outer:
 	return gauges, nil
 }
 
-func (s *scriptCantabria) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptCantabria) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	resCh, errCh, err := s.parseTable()
+	resCh, errCh, err := s.parseTable(ctx)
 	if err != nil {
 		errs <- err
 		return
@@ -81,9 +81,9 @@ func (s *scriptCantabria) Harvest(ctx context.Context, recv chan<- *core.Measure
 
 }
 
-func (s *scriptCantabria) gaugePageWorker(gauges <-chan *core.Gauge, results chan<- *core.Gauge) {
+func (s *scriptCantabria) gaugePageWorker(ctx context.Context, gauges <-chan *core.Gauge, results chan<- *core.Gauge) {
 	for gauge := range gauges {
-		loc := s.parseGaugeLocation((*gauge).Code)
+		loc := s.parseGaugeLocation(ctx, (*gauge).Code)
 		(*gauge).Location = &loc
 		(*gauge).Timezone = "Europe/Madrid"
 		results <- gauge
@@ -1,6 +1,7 @@
 package cantabria
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -27,7 +28,7 @@ func TestCantabria_ListGauges(t *testing.T) {
 		listURL:      ts.URL + "/list.html",
 		gaugeURLBase: ts.URL + "/",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauge{
 		GaugeID: core.GaugeID{
 			Script: "cantabria",
@@ -56,7 +57,7 @@ func TestCantabria_Harvest(t *testing.T) {
 		listURL:      ts.URL + "/list.html",
 		gaugeURLBase: ts.URL + "/",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := &core.Measurement{
 		GaugeID: core.GaugeID{
 			Script: "cantabria",
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "catalunya",
 	Description: "Catalan Water Agency",
 	Mode:        core.AllAtOnce,
+	Host:        "aplicacions.aca.gencat.cat",
 	DefaultOptions: func() interface{} {
 		return &optionsCatalunya{}
 	},
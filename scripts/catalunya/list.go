@@ -1,6 +1,7 @@
 package catalunya
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -12,9 +13,9 @@ import (
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptCatalunya) fetchList() ([]sensor, error) {
+func (s *scriptCatalunya) fetchList(ctx context.Context) ([]sensor, error) {
 	list := &catalunyaList{}
-	err := core.Client.GetAsJSON(s.gaugesURL, list, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.gaugesURL, list, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -60,8 +61,8 @@ func (s *scriptCatalunya) convert(sensor *sensor) (*core.Gauge, error) {
 	}, nil
 }
 
-func (s *scriptCatalunya) parseList() (core.Gauges, error) {
-	sensors, err := s.fetchList()
+func (s *scriptCatalunya) parseList(ctx context.Context) (core.Gauges, error) {
+	sensors, err := s.fetchList(ctx)
 
 	if err != nil {
 		return nil, err
@@ -84,9 +85,9 @@ func (s *scriptCatalunya) parseList() (core.Gauges, error) {
 
 var flowSensors map[string]bool
 
-func (s *scriptCatalunya) isFlowSensor(sensor *dataSensor) (bool, error) {
+func (s *scriptCatalunya) isFlowSensor(ctx context.Context, sensor *dataSensor) (bool, error) {
 	if flowSensors == nil {
-		sensors, err := s.fetchList()
+		sensors, err := s.fetchList(ctx)
 		if err != nil {
 			return false, err
 		}
@@ -1,13 +1,14 @@
 package catalunya
 
 import (
+	"context"
 	"github.com/mattn/go-nulltype"
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptCatalunya) fetchObservations() ([]dataSensor, error) {
+func (s *scriptCatalunya) fetchObservations(ctx context.Context) ([]dataSensor, error) {
 	res := &catalunyaData{}
-	err := core.Client.GetAsJSON(s.measurementsURL, res, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.measurementsURL, res, nil)
 
 	if err != nil {
 		return nil, err
@@ -15,8 +16,8 @@ func (s *scriptCatalunya) fetchObservations() ([]dataSensor, error) {
 	return res.Sensors, err
 }
 
-func (s *scriptCatalunya) parseObservations(recv chan<- *core.Measurement, errs chan<- error) {
-	dataSensors, err := s.fetchObservations()
+func (s *scriptCatalunya) parseObservations(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error) {
+	dataSensors, err := s.fetchObservations(ctx)
 	if err != nil {
 		errs <- err
 		return
@@ -26,7 +27,7 @@ func (s *scriptCatalunya) parseObservations(recv chan<- *core.Measurement, errs
 			var flow, level nulltype.NullFloat64
 			// observation data doesn't contain any mention of it's type
 			// so this worker has to be stateful and use isFlowSensor
-			ifs, err := s.isFlowSensor(&sensor)
+			ifs, err := s.isFlowSensor(ctx, &sensor)
 			if err != nil {
 				s.GetLogger().Error(err)
 				continue
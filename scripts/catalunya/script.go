@@ -15,12 +15,12 @@ type scriptCatalunya struct {
 
 type optionsCatalunya struct{}
 
-func (s *scriptCatalunya) ListGauges() (core.Gauges, error) {
-	return s.parseList()
+func (s *scriptCatalunya) ListGauges(ctx context.Context) (core.Gauges, error) {
+	return s.parseList(ctx)
 }
 
-func (s *scriptCatalunya) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptCatalunya) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	s.parseObservations(recv, errs)
+	s.parseObservations(ctx, recv, errs)
 }
@@ -1,6 +1,7 @@
 package catalunya
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -56,7 +57,7 @@ func TestCatalunya_ListGauges(t *testing.T) {
 			Timezone: "Europe/Madrid",
 		},
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected, actual)
 	}
@@ -92,7 +93,7 @@ func TestCatalunya_Harvest(t *testing.T) {
 			Flow: nulltype.NullFloat64Of(4.296),
 		},
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected, actual)
 	}
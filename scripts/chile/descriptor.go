@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "chile",
 	Description: "Chile: The Ministry of Public Works, The Water Division (DGA)",
 	Mode:        core.OneByOne,
+	Host:        "dgasatel.mop.cl",
 	DefaultOptions: func() interface{} {
 		return &optionsChile{}
 	},
@@ -1,6 +1,7 @@
 package chile
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -159,9 +160,9 @@ type chileKml struct {
 	} `xml:"Document"`
 }
 
-func (s *scriptChile) getKMLGauges() (map[string]core.Gauge, error) {
+func (s *scriptChile) getKMLGauges(ctx context.Context) (map[string]core.Gauge, error) {
 	var data chileKml
-	err := core.Client.GetAsXML("http://documentos.dga.cl/KML/01_Red_Hidrometrica/Fluviometricas_001.kml", &data, nil)
+	err := core.Client.WithContext(ctx).GetAsXML("http://documentos.dga.cl/KML/01_Red_Hidrometrica/Fluviometricas_001.kml", &data, nil)
 	if err != nil {
 		return nil, err
 	}
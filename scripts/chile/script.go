@@ -2,6 +2,7 @@ package chile
 
 import (
 	"context"
+	"time"
 
 	"github.com/whitewater-guide/gorge/core"
 )
@@ -18,12 +19,12 @@ type scriptChile struct {
 	core.LoggingScript
 }
 
-func (s *scriptChile) ListGauges() (core.Gauges, error) {
-	fromKml, err := s.getKMLGauges()
+func (s *scriptChile) ListGauges(ctx context.Context) (core.Gauges, error) {
+	fromKml, err := s.getKMLGauges(ctx)
 	if err != nil {
 		return nil, err
 	}
-	fromWeb, err := s.getWebGauges()
+	fromWeb, err := s.getWebGauges(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -42,13 +43,35 @@ func (s *scriptChile) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptChile) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptChile) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	code, err := codes.Only()
+	code, err := spec.Codes.Only()
 	if err != nil {
 		errs <- err
 		return
 	}
-	s.parseXLS(recv, errs, code, since)
+	period := "1d"
+	if spec.Since.Oldest(spec.Codes) == 0 {
+		period = "3m"
+	}
+	now := time.Now()
+	s.parseXLS(ctx, recv, errs, code, period, now, now)
+}
+
+// BackfillPage implements core.Backfiller interface
+func (s *scriptChile) BackfillPage() time.Duration {
+	return 31 * 24 * time.Hour
+}
+
+// Backfill implements core.Backfiller interface
+func (s *scriptChile) Backfill(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec, from, to time.Time) {
+	defer close(recv)
+	defer close(errs)
+	code, err := spec.Codes.Only()
+	if err != nil {
+		errs <- err
+		return
+	}
+	s.parseXLS(ctx, recv, errs, code, "", from, to)
 }
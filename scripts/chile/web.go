@@ -1,6 +1,7 @@
 package chile
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/whitewater-guide/gorge/core"
@@ -10,13 +11,13 @@ import (
 
 // const wgs84 = "+proj=merc +a=6378137 +b=6378137 +lat_ts=0.0 +lon_0=0.0 +x_0=0.0 +y_0=0 +k=1.0 +units=m +nadgrids=@null +wktext  +no_defs"
 
-func (s *scriptChile) getWebGauges() (map[string]core.Gauge, error) {
-	webmap, err := s.parseWebmap()
+func (s *scriptChile) getWebGauges(ctx context.Context) (map[string]core.Gauge, error) {
+	webmap, err := s.parseWebmap(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	listedGauges, err := s.getListedGauges()
+	listedGauges, err := s.getListedGauges(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +29,7 @@ func (s *scriptChile) getWebGauges() (map[string]core.Gauge, error) {
 	numGauges := len(gaugeIds)
 	usefulness := make(map[string]bool)
 	for i := 0; i < numGauges; i += 3 {
-		err := s.areGaugesUseful(gaugeIds[i:i+3], usefulness)
+		err := s.areGaugesUseful(ctx, gaugeIds[i:i+3], usefulness)
 		if err != nil {
 			return nil, err
 		}
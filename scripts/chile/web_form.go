@@ -2,6 +2,7 @@ package chile
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -24,7 +25,7 @@ const (
 // User can submit up to 3 gauges at a time
 // When gauges are selected it's possible to select which parameters do we query next
 // If this list of parameters contains level/flow then the gauge is useful
-func (s *scriptChile) areGaugesUseful(ids []string, data map[string]bool) error {
+func (s *scriptChile) areGaugesUseful(ctx context.Context, ids []string, data map[string]bool) error {
 	if len(ids) > 3 {
 		return fmt.Errorf("no more than 3 ids at a time allowed, but received %d", len(ids))
 	}
@@ -45,7 +46,7 @@ func (s *scriptChile) areGaugesUseful(ids []string, data map[string]bool) error
 		return err
 	}
 	t := time.Now().In(tz)
-	html, _, err := core.Client.PostFormAsString(s.selectFormURL, url.Values{
+	html, _, err := core.Client.WithContext(ctx).PostFormAsString(s.selectFormURL, url.Values{
 		"accion":     {"refresca"},
 		"EsDL1":      {"0"},
 		"EsDL2":      {"0"},
@@ -67,7 +68,7 @@ func (s *scriptChile) areGaugesUseful(ids []string, data map[string]bool) error
 	// sometimes a retry is needed
 	if !strings.Contains(html, "DATOS EN TABLAS") {
 		time.Sleep(10 * time.Second)
-		return s.areGaugesUseful(ids, data)
+		return s.areGaugesUseful(ctx, ids, data)
 	}
 
 	// append "_1" to gauge id => value of "Nivel de agua" checkbox (level)
@@ -113,8 +114,8 @@ func parseOption(opt string) (id string, name string) {
  * Parse dropdown select options and get gauge ids
  * Returns map where gauge id is the key and gauge name is the value
  */
-func (s *scriptChile) getListedGauges() (map[string]string, error) {
-	html, err := core.Client.GetAsString(s.selectFormURL, nil)
+func (s *scriptChile) getListedGauges(ctx context.Context) (map[string]string, error) {
+	html, err := core.Client.WithContext(ctx).GetAsString(s.selectFormURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package chile
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/whitewater-guide/gorge/core"
@@ -57,7 +58,7 @@ type webmapPage struct {
 	OperationalLayers []operationalLayer `json:"operationalLayers"`
 }
 
-func (s *scriptChile) getWebmapID() (string, error) {
+func (s *scriptChile) getWebmapID(ctx context.Context) (string, error) {
 	type webmapIDPageValues struct {
 		Webmap string `json:"webmap"`
 	}
@@ -65,7 +66,7 @@ func (s *scriptChile) getWebmapID() (string, error) {
 		Values webmapIDPageValues `json:"values"`
 	}
 	response := &webmapIDPage{}
-	err := core.Client.GetAsJSON(s.webmapIDPageURL, response, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.webmapIDPageURL, response, nil)
 
 	if err != nil {
 		return "", err
@@ -73,8 +74,8 @@ func (s *scriptChile) getWebmapID() (string, error) {
 	return response.Values.Webmap, nil
 }
 
-func (s *scriptChile) getWepmapURL() (string, error) {
-	webmapID, err := s.getWebmapID()
+func (s *scriptChile) getWepmapURL(ctx context.Context) (string, error) {
+	webmapID, err := s.getWebmapID(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -82,13 +83,13 @@ func (s *scriptChile) getWepmapURL() (string, error) {
 	return result, nil
 }
 
-func (s *scriptChile) parseWebmap() (map[string]feature, error) {
-	url, err := s.getWepmapURL()
+func (s *scriptChile) parseWebmap(ctx context.Context) (map[string]feature, error) {
+	url, err := s.getWepmapURL(ctx)
 	if err != nil {
 		return nil, err
 	}
 	response := &webmapPage{}
-	err = core.Client.GetAsJSON(url, response, nil)
+	err = core.Client.WithContext(ctx).GetAsJSON(url, response, nil)
 	if err != nil {
 		return nil, err
 	}
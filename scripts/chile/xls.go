@@ -1,6 +1,7 @@
 package chile
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -11,19 +12,18 @@ import (
 	"golang.org/x/net/html"
 )
 
-func (s *scriptChile) loadXLS(code string, since int64, retry bool) (string, error) {
+// loadXLS requests xls report for one station
+// Report period is either one of form's presets (period) or explicit dates range, when period is empty
+func (s *scriptChile) loadXLS(ctx context.Context, code string, period string, from, to time.Time, retry bool) (string, error) {
 	tz, err := time.LoadLocation("America/Santiago")
 	if err != nil {
 		return "", nil
 	}
-	period := "1d"
-	if since == 0 {
-		period = "3m"
-	}
-	t := time.Now().In(tz)
+	from, to = from.In(tz), to.In(tz)
+	client := core.Client.WithContext(ctx)
 	var cookieErr error
 	if !s.skipCookies {
-		cookieErr = core.Client.EnsureCookie("http://dgasatel.mop.cl", !retry)
+		cookieErr = client.EnsureCookie("http://dgasatel.mop.cl", !retry)
 		if cookieErr != nil {
 			s.GetLogger().Warn("cookie error", cookieErr)
 		}
@@ -39,20 +39,20 @@ func (s *scriptChile) loadXLS(code string, since int64, retry bool) (string, err
 		"estacion1":      {code},
 		"estacion2":      {"-1"},
 		"estacion3":      {"-1"},
-		"fecha_fin":      {t.Format("02/01/2006")},
-		"fecha_finP":     {t.Format("02/01/2006")},
-		"fecha_ini":      {t.Format("02/01/2006")},
+		"fecha_fin":      {to.Format("02/01/2006")},
+		"fecha_finP":     {to.Format("02/01/2006")},
+		"fecha_ini":      {from.Format("02/01/2006")},
 		"period":         {period},
 		"tiporep":        {"I"},
 	}
-	html, _, err := core.Client.PostFormAsString(s.xlsURL, values, nil)
+	html, _, err := client.PostFormAsString(s.xlsURL, values, nil)
 
 	if !strings.Contains(html, "tabla para resultados numerados") {
 		if retry {
-			return s.loadXLS(code, since, false)
+			return s.loadXLS(ctx, code, period, from, to, false)
 		}
 		s.GetLogger().WithFields(logrus.Fields{
-			"since":     since,
+			"period":    period,
 			"cookieErr": cookieErr != nil,
 			"values":    values.Encode(),
 		}).Warn("missing data table in XLS response")
@@ -174,8 +174,8 @@ func parseDataRow(tr *html.Node, ind columnIndices, tz *time.Location) (result c
 	return
 }
 
-func (s *scriptChile) parseXLS(recv chan<- *core.Measurement, errs chan<- error, code string, since int64) {
-	rawDoc, err := s.loadXLS(code, since, true)
+func (s *scriptChile) parseXLS(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, code string, period string, from, to time.Time) {
+	rawDoc, err := s.loadXLS(ctx, code, period, from, to, true)
 	if err != nil {
 		errs <- err
 		return
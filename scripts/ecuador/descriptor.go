@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "ecuador",
 	Description: "Ecuador: Instituto Nacional de Meteorología e Hidrología",
 	Mode:        core.OneByOne,
+	Host:        "186.42.174.243",
 	DefaultOptions: func() interface{} {
 		return &optionsEcuador{}
 	},
@@ -1,6 +1,7 @@
 package ecuador
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -64,10 +65,10 @@ func (s *scriptEcuador) parseMeasurement(raw []interface{}, code string, dateInd
 	}, nil
 }
 
-func (s *scriptEcuador) parseGauge(recv chan<- *core.Measurement, errs chan<- error, code string) {
+func (s *scriptEcuador) parseGauge(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, code string) {
 	ts := time.Now().In(time.UTC).UnixNano() / int64(time.Millisecond)
 	resp := &ecuadorRoot{}
-	err := core.Client.GetAsJSON(fmt.Sprintf(s.gaugeURLFormat, code, ts), resp, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(fmt.Sprintf(s.gaugeURLFormat, code, ts), resp, nil)
 
 	if err != nil {
 		errs <- err
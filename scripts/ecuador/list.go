@@ -1,6 +1,7 @@
 package ecuador
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/whitewater-guide/gorge/core"
@@ -8,8 +9,8 @@ import (
 	"golang.org/x/text/language"
 )
 
-func (s *scriptEcuador) parseList() (map[string]core.Gauge, error) {
-	raw, err := core.Client.GetAsString(s.listURL1, nil)
+func (s *scriptEcuador) parseList(ctx context.Context) (map[string]core.Gauge, error) {
+	raw, err := core.Client.WithContext(ctx).GetAsString(s.listURL1, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package ecuador
 
 import (
+	"context"
 	"encoding/json"
 	"math"
 
@@ -9,8 +10,8 @@ import (
 	"golang.org/x/text/language"
 )
 
-func (s *scriptEcuador) parseList2() (map[string]core.Gauge, error) {
-	raw, err := core.Client.GetAsString(s.listURL2, nil)
+func (s *scriptEcuador) parseList2(ctx context.Context) (map[string]core.Gauge, error) {
+	raw, err := core.Client.WithContext(ctx).GetAsString(s.listURL2, nil)
 	if err != nil {
 		return nil, err
 	}
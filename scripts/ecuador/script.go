@@ -15,14 +15,14 @@ type scriptEcuador struct {
 	core.LoggingScript
 }
 
-func (s *scriptEcuador) ListGauges() (core.Gauges, error) {
+func (s *scriptEcuador) ListGauges(ctx context.Context) (core.Gauges, error) {
 	// this list has no coordinates
-	list1, err := s.parseList()
+	list1, err := s.parseList(ctx)
 	if err != nil {
 		return nil, err
 	}
 	// this list has coordinates
-	list2, err := s.parseList2()
+	list2, err := s.parseList2(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -41,13 +41,13 @@ func (s *scriptEcuador) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptEcuador) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptEcuador) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	code, err := codes.Only()
+	code, err := spec.Codes.Only()
 	if err != nil {
 		errs <- err
 		return
 	}
-	s.parseGauge(recv, errs, code)
+	s.parseGauge(ctx, recv, errs, code)
 }
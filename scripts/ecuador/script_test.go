@@ -1,6 +1,7 @@
 package ecuador
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -28,7 +29,7 @@ func TestEcuador_ListGauges(t *testing.T) {
 		listURL2:       ts.URL + "/list2",
 		gaugeURLFormat: ts.URL + "/%s/%d",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -82,7 +83,7 @@ func TestEcuador_Harvest(t *testing.T) {
 		listURL2:       ts.URL + "/list2",
 		gaugeURLFormat: ts.URL + "/%s/%d",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"H0064": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"H0064": {}})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "finland",
 	Description: "The Finnish Environment Institute (SYKE)",
 	Mode:        core.OneByOne,
+	Host:        "rajapinnat.ymparisto.fi",
 	DefaultOptions: func() interface{} {
 		return &optionsFinland{}
 	},
@@ -1,15 +1,16 @@
 package finland
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptFinland) fetchList(url string, gauges *core.Gauges) error {
+func (s *scriptFinland) fetchList(ctx context.Context, url string, gauges *core.Gauges) error {
 	var data stationsList
-	err := core.Client.GetAsJSON(url, &data, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(url, &data, nil)
 	if err != nil {
 		return err
 	}
@@ -53,7 +54,7 @@ func (s *scriptFinland) fetchList(url string, gauges *core.Gauges) error {
 		if !strings.HasPrefix(next, "http") {
 			next = s.url + next
 		}
-		return s.fetchList(next, gauges)
+		return s.fetchList(ctx, next, gauges)
 	}
 	return nil
 }
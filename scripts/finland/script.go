@@ -14,22 +14,22 @@ type scriptFinland struct {
 	core.LoggingScript
 }
 
-func (s *scriptFinland) ListGauges() (core.Gauges, error) {
+func (s *scriptFinland) ListGauges(ctx context.Context) (core.Gauges, error) {
 	result := core.Gauges{}
-	err := s.fetchList(fmt.Sprintf("%s/Paikka?$skip=0&$filter=Suure_Id%%20eq%%202%%20or%%20Suure_Id%%20eq%%201&$select=KoordErTmIta,KoordErTmPohj,KuntaNimi,Nro,Paikka_Id,Nimi,Suure_Id", s.url), &result)
+	err := s.fetchList(ctx, fmt.Sprintf("%s/Paikka?$skip=0&$filter=Suure_Id%%20eq%%202%%20or%%20Suure_Id%%20eq%%201&$select=KoordErTmIta,KoordErTmPohj,KuntaNimi,Nro,Paikka_Id,Nimi,Suure_Id", s.url), &result)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-func (s *scriptFinland) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptFinland) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	code, err := codes.Only()
+	code, err := spec.Codes.Only()
 	if err != nil {
 		errs <- err
 		return
 	}
-	s.fetchMeasurement(code, recv, errs)
+	s.fetchMeasurement(ctx, code, recv, errs)
 }
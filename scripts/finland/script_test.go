@@ -1,6 +1,7 @@
 package finland
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -25,7 +26,7 @@ func TestFinland_ListGauges(t *testing.T) {
 		name: "finland",
 		url:  ts.URL,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -68,7 +69,7 @@ func TestFinland_Harvest(t *testing.T) {
 		name: "finland",
 		url:  ts.URL,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"894": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"894": {}})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
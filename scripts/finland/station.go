@@ -1,6 +1,7 @@
 package finland
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -9,11 +10,11 @@ import (
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptFinland) fetchMeasurement(code string, measurements chan<- *core.Measurement, errs chan<- error) {
+func (s *scriptFinland) fetchMeasurement(ctx context.Context, code string, measurements chan<- *core.Measurement, errs chan<- error) {
 	weekAgo := time.Now().In(finTz).Add(-24 * 7 * time.Hour).Format("2006-01-02T15:04:05")
 	path := fmt.Sprintf("%s/Virtaama?$top=1&$filter=Paikka_Id%%20eq%%20%s%%20and%%20Aika%%20ge%%20datetime%%27%s%%27&$orderby=Aika%%20desc&$select=Aika,Arvo", s.url, code, weekAgo)
 	var data virtaamaList
-	err := core.Client.GetAsJSON(path, &data, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(path, &data, nil)
 	if err != nil {
 		errs <- err
 		return
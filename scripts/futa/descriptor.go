@@ -8,6 +8,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "futa",
 	Description: "Central Hidroeléctrica Futaleufú",
 	Mode:        core.AllAtOnce,
+	Host:        "www.chfutaleufu.com.ar",
 	DefaultOptions: func() interface{} {
 		return &optionsFuta{}
 	},
@@ -18,7 +18,7 @@ type scriptFuta struct {
 	core.LoggingScript
 }
 
-func (s *scriptFuta) ListGauges() (result core.Gauges, err error) {
+func (s *scriptFuta) ListGauges(ctx context.Context) (result core.Gauges, err error) {
 	result = append(result, core.Gauge{
 		GaugeID:  core.GaugeID{Script: "futa", Code: "futa00"},
 		Name:     "Futaleufu Hidroelectrica",
@@ -29,7 +29,7 @@ func (s *scriptFuta) ListGauges() (result core.Gauges, err error) {
 	return
 }
 
-func (s *scriptFuta) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptFuta) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
@@ -38,7 +38,7 @@ func (s *scriptFuta) Harvest(ctx context.Context, recv chan<- *core.Measurement,
 		return
 	}
 
-	resp, err := core.Client.Get(s.dataURL, nil)
+	resp, err := core.Client.WithContext(ctx).Get(s.dataURL, nil)
 	if err != nil {
 		return
 	}
@@ -1,6 +1,7 @@
 package futa
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -22,7 +23,7 @@ func TestFuta_ListGauges(t *testing.T) {
 		name:    "futa",
 		dataURL: ts.URL + "/hoyweb.txt",
 	}
-	gauges, err := s.ListGauges()
+	gauges, err := s.ListGauges(context.Background())
 	if assert.NoError(t, err) {
 		assert.ElementsMatch(
 			t,
@@ -47,7 +48,7 @@ func TestFuta_Harvest(t *testing.T) {
 		name:    "futa",
 		dataURL: ts.URL + "/hoyweb.txt",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "galicia",
 	Description: "Spain: MeteoGalicia",
 	Mode:        core.AllAtOnce,
+	Host:        "servizos.meteogalicia.gal",
 	DefaultOptions: func() interface{} {
 		return &optionsGalicia{}
 	},
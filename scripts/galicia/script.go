@@ -21,8 +21,8 @@ type scriptGalicia struct {
 	core.LoggingScript
 }
 
-func (s *scriptGalicia) ListGauges() (core.Gauges, error) {
-	l, err := s.fetch()
+func (s *scriptGalicia) ListGauges(ctx context.Context) (core.Gauges, error) {
+	l, err := s.fetch(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -49,10 +49,10 @@ func (s *scriptGalicia) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptGalicia) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptGalicia) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	list, err := s.fetch()
+	list, err := s.fetch(ctx)
 	if err != nil {
 		errs <- err
 		return
@@ -80,9 +80,9 @@ func (s *scriptGalicia) Harvest(ctx context.Context, recv chan<- *core.Measureme
 	}
 }
 
-func (s *scriptGalicia) fetch() ([]entry, error) {
+func (s *scriptGalicia) fetch(ctx context.Context) ([]entry, error) {
 	var data entries
-	err := core.Client.GetAsJSON(s.url, &data, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.url, &data, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package galicia
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -22,7 +23,7 @@ func TestGalicia_ListGauges(t *testing.T) {
 		name: "galicia",
 		url:  ts.URL + "/galicia.json",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -67,7 +68,7 @@ func TestGalicia_Harvest(t *testing.T) {
 		name: "galicia",
 		url:  ts.URL + "/galicia.json",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
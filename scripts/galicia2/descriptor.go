@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "galicia2",
 	Description: "Spain: Confederación Hidrográfica del Miño-Sil",
 	Mode:        core.AllAtOnce,
+	Host:        "saih.chminosil.es",
 	DefaultOptions: func() interface{} {
 		return &optionsGalicia2{}
 	},
@@ -2,6 +2,7 @@ package galicia2
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"html"
 	"net/http"
@@ -72,7 +73,7 @@ func prettyName(name string) string {
 	return strings.Join(words, " ")
 }
 
-func (s *scriptGalicia2) parseTable() ([]item, error) {
+func (s *scriptGalicia2) parseTable(ctx context.Context) ([]item, error) {
 	var result []item
 	if !s.skipCookies {
 		if u, err := url.Parse(s.listURL); err == nil {
@@ -88,7 +89,7 @@ func (s *scriptGalicia2) parseTable() ([]item, error) {
 		}
 	}
 
-	resp, err := client.Get(s.listURL, nil)
+	resp, err := client.WithContext(ctx).Get(s.listURL, nil)
 	if err != nil {
 		return result, err
 	}
@@ -148,8 +149,8 @@ func (s *scriptGalicia2) parseTable() ([]item, error) {
 }
 
 // http://saih.chminosil.es/index.php?url=/datos/ficha/estacion:N015
-func (s *scriptGalicia2) parseGaugePage(code string) (lat float64, lon float64, altitude float64) {
-	html, err := client.GetAsString(fmt.Sprintf(s.gaugeURLFormat, code), nil)
+func (s *scriptGalicia2) parseGaugePage(ctx context.Context, code string) (lat float64, lon float64, altitude float64) {
+	html, err := client.WithContext(ctx).GetAsString(fmt.Sprintf(s.gaugeURLFormat, code), nil)
 	if err != nil {
 		return
 	}
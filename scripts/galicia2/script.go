@@ -16,8 +16,8 @@ type scriptGalicia2 struct {
 	core.LoggingScript
 }
 
-func (s *scriptGalicia2) ListGauges() (core.Gauges, error) {
-	items, err := s.parseTable()
+func (s *scriptGalicia2) ListGauges(ctx context.Context) (core.Gauges, error) {
+	items, err := s.parseTable(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +26,7 @@ func (s *scriptGalicia2) ListGauges() (core.Gauges, error) {
 	resultsCh := make(chan *core.Gauge, len(items))
 
 	for w := 1; w <= 10; w++ {
-		go s.gaugePageWorker(jobsCh, resultsCh)
+		go s.gaugePageWorker(ctx, jobsCh, resultsCh)
 	}
 	for i := range items {
 		jobsCh <- &items[i].gauge
@@ -41,10 +41,10 @@ func (s *scriptGalicia2) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptGalicia2) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptGalicia2) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	gauges, err := s.parseTable()
+	gauges, err := s.parseTable(ctx)
 	if err != nil {
 		errs <- err
 		return
@@ -55,9 +55,9 @@ func (s *scriptGalicia2) Harvest(ctx context.Context, recv chan<- *core.Measurem
 	}
 }
 
-func (s *scriptGalicia2) gaugePageWorker(gauges <-chan *core.Gauge, results chan<- *core.Gauge) {
+func (s *scriptGalicia2) gaugePageWorker(ctx context.Context, gauges <-chan *core.Gauge, results chan<- *core.Gauge) {
 	for g := range gauges {
-		latitude, longitude, altitude := s.parseGaugePage(g.Code)
+		latitude, longitude, altitude := s.parseGaugePage(ctx, g.Code)
 		g.Location = &core.Location{
 			Latitude:  latitude,
 			Longitude: longitude,
@@ -1,6 +1,7 @@
 package galicia2
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -27,7 +28,7 @@ func TestGalicia2_ListGauges(t *testing.T) {
 		gaugeURLFormat: ts.URL + "/%s.html",
 		skipCookies:    true,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauge{
 		GaugeID: core.GaugeID{
 			Script: "galicia2",
@@ -58,7 +59,7 @@ func TestGalicia2_Harvest(t *testing.T) {
 		gaugeURLFormat: ts.URL + "/%s.html",
 		skipCookies:    true,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := &core.Measurement{
 		GaugeID: core.GaugeID{
 			Script: "galicia2",
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "georgia",
 	Description: "Georgia: The National Environmental Agency",
 	Mode:        core.AllAtOnce,
+	Host:        "meteo.gov.ge",
 	DefaultOptions: func() interface{} {
 		return &optionsGeorgia{}
 	},
@@ -1,6 +1,7 @@
 package georgia
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -22,7 +23,7 @@ func TestGeorgia_ListGauges(t *testing.T) {
 		name: "georgia",
 		url:  ts.URL + "/page.html",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauge{
 		GaugeID: core.GaugeID{
 			Script: "georgia",
@@ -47,7 +48,7 @@ func TestGeorgia_Harvest(t *testing.T) {
 		url:  ts.URL + "/page.html",
 	}
 	now := time.Now().UTC().Truncate(time.Hour)
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := &core.Measurement{
 		GaugeID: core.GaugeID{
 			Script: "georgia",
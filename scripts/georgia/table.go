@@ -1,6 +1,7 @@
 package georgia
 
 import (
+	"context"
 	"crypto/md5"
 	"fmt"
 	"regexp"
@@ -15,8 +16,8 @@ import (
 
 var nameRegex = regexp.MustCompile(`\W`)
 
-func (s *scriptGeorgia) parseTable(gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
-	doc, err := core.Client.GetAsDoc(s.url, nil)
+func (s *scriptGeorgia) parseTable(ctx context.Context, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
+	doc, err := core.Client.WithContext(ctx).GetAsDoc(s.url, nil)
 	if err != nil {
 		errs <- err
 		return
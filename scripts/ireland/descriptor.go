@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "ireland",
 	Description: "https://waterlevel.ie/",
 	Mode:        core.AllAtOnce,
+	Host:        "waterlevel.ie",
 	DefaultOptions: func() interface{} {
 		return &optionsIreland{}
 	},
@@ -18,9 +18,9 @@ type scriptIreland struct {
 	core.LoggingScript
 }
 
-func (s *scriptIreland) ListGauges() (core.Gauges, error) {
+func (s *scriptIreland) ListGauges(ctx context.Context) (core.Gauges, error) {
 	var resp geojson
-	if err := core.Client.GetAsJSON(fmt.Sprintf("%s?%d", s.url, time.Now().Unix()), &resp, nil); err != nil {
+	if err := core.Client.WithContext(ctx).GetAsJSON(fmt.Sprintf("%s?%d", s.url, time.Now().Unix()), &resp, nil); err != nil {
 		return nil, err
 	}
 	var result []core.Gauge
@@ -50,12 +50,12 @@ func (s *scriptIreland) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptIreland) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptIreland) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
 	var resp geojson
-	if err := core.Client.GetAsJSON(fmt.Sprintf("%s?%d", s.url, time.Now().Unix()), &resp, nil); err != nil {
+	if err := core.Client.WithContext(ctx).GetAsJSON(fmt.Sprintf("%s?%d", s.url, time.Now().Unix()), &resp, nil); err != nil {
 		errs <- err
 		return
 	}
@@ -1,6 +1,7 @@
 package ireland
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -24,7 +25,7 @@ func TestIreland_ListGauges(t *testing.T) {
 		name: "ireland",
 		url:  ts.URL,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -67,7 +68,7 @@ func TestIreland_Harvest(t *testing.T) {
 		name: "ireland",
 		url:  ts.URL,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "ireland2",
 	Description: "https://www.riverspy.net",
 	Mode:        core.AllAtOnce,
+	Host:        "www.riverspy.net",
 	DefaultOptions: func() interface{} {
 		return &optionsIreland2{}
 	},
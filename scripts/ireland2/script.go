@@ -19,8 +19,8 @@ type scriptIreland2 struct {
 	core.LoggingScript
 }
 
-func (s *scriptIreland2) fetch() ([]river, error) {
-	raw, err := core.Client.GetAsString(s.url, nil)
+func (s *scriptIreland2) fetch(ctx context.Context) ([]river, error) {
+	raw, err := core.Client.WithContext(ctx).GetAsString(s.url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +35,8 @@ func (s *scriptIreland2) fetch() ([]river, error) {
 	return resp.Rivers, nil
 }
 
-func (s *scriptIreland2) ListGauges() (core.Gauges, error) {
-	rivers, err := s.fetch()
+func (s *scriptIreland2) ListGauges(ctx context.Context) (core.Gauges, error) {
+	rivers, err := s.fetch(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -66,11 +66,11 @@ func (s *scriptIreland2) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptIreland2) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptIreland2) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
-	rivers, err := s.fetch()
+	rivers, err := s.fetch(ctx)
 	if err != nil {
 		errs <- err
 		return
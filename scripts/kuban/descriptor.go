@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "kuban",
 	Description: "Russia: Kuban drainage",
 	Mode:        core.AllAtOnce,
+	Host:        "193.7.160.230",
 	DefaultOptions: func() interface{} {
 		return &optionsKuban{}
 	},
@@ -13,19 +13,19 @@ type scriptKuban struct {
 	core.LoggingScript
 }
 
-func (s *scriptKuban) ListGauges() (core.Gauges, error) {
+func (s *scriptKuban) ListGauges(ctx context.Context) (core.Gauges, error) {
 	gaugesCh := make(chan *core.Gauge)
 	errCh := make(chan error)
 	go func() {
 		defer close(gaugesCh)
 		defer close(errCh)
-		s.parseTable(gaugesCh, nil, errCh)
+		s.parseTable(ctx, gaugesCh, nil, errCh)
 	}()
 	return core.GaugeSinkToSlice(gaugesCh, errCh)
 }
 
-func (s *scriptKuban) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptKuban) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	s.parseTable(nil, recv, errs)
+	s.parseTable(ctx, nil, recv, errs)
 }
@@ -1,6 +1,7 @@
 package kuban
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -50,7 +51,7 @@ func TestKuban_ListGauges(t *testing.T) {
 			Timezone: "Europe/Moscow",
 		},
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	if assert.NoError(t, err) && assert.Len(t, actual, 28) {
 		assert.Equal(t, expected[0], actual[0])
 		assert.Equal(t, expected[1], actual[27])
@@ -64,7 +65,7 @@ func TestKuban_Harvest(t *testing.T) {
 		name: "kuban",
 		url:  ts.URL + "/data.html",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
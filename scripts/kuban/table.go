@@ -1,6 +1,7 @@
 package kuban
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"time"
@@ -15,8 +16,8 @@ import (
 var tz, _ = time.LoadLocation("Europe/Moscow")
 var decoder = charmap.Windows1251.NewDecoder()
 
-func (s *scriptKuban) parseTable(gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
-	doc, err := core.Client.GetAsDoc(s.url, nil)
+func (s *scriptKuban) parseTable(ctx context.Context, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
+	doc, err := core.Client.WithContext(ctx).GetAsDoc(s.url, nil)
 	if err != nil {
 		errs <- err
 		return
@@ -11,6 +11,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "norway",
 	Description: "Norwegian Water Resources and Energy Directorate",
 	Mode:        core.Batched,
+	Host:        "hydapi.nve.no",
 	DefaultOptions: func() interface{} {
 		return &optionsNorway{
 			ApiKey:    os.Getenv("NVE_API_KEY"),
@@ -3,7 +3,9 @@ package norway
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +16,8 @@ type optionsNorway struct {
 	ApiKey       string `desc:"API Key, default to env variable NVE_API_KEY" json:"version"`
 	BatchSize    int    `desc:"Batch size for requesting multiple stations at once" json:"batchSize"`
 	IgnoreLegacy bool   `desc:"Do not support station ids from previous version of this script" json:"ignoreLegacy"`
+	IgnoreLevel  bool   `desc:"Do not harvest water level, usually set for single gauge" json:"ignoreLevel"`
+	IgnoreFlow   bool   `desc:"Do not harvest discharge, usually set for single gauge" json:"ignoreFlow"`
 }
 
 // GetBatchSize Implements core.BatchableOptions interface
@@ -21,6 +25,19 @@ func (o optionsNorway) GetBatchSize() int {
 	return o.BatchSize
 }
 
+// HonoursGaugeOptions Implements core.GaugeOptionsBatchable interface
+func (o optionsNorway) HonoursGaugeOptions() bool {
+	return true
+}
+
+// ignores returns true if gauge with these options does not harvest given parameter
+func (o *optionsNorway) ignores(parameter int) bool {
+	if o == nil {
+		return false
+	}
+	return (parameter == 1000 && o.IgnoreLevel) || (parameter == 1001 && o.IgnoreFlow)
+}
+
 type scriptNorway struct {
 	name         string
 	urlBase      string
@@ -29,9 +46,9 @@ type scriptNorway struct {
 	core.LoggingScript
 }
 
-func (s *scriptNorway) ListGauges() (core.Gauges, error) {
+func (s *scriptNorway) ListGauges(ctx context.Context) (core.Gauges, error) {
 	var resp statiosResponse
-	err := core.Client.GetAsJSON(
+	err := core.Client.WithContext(ctx).GetAsJSON(
 		s.urlBase+"/Stations?Active=1",
 		&resp,
 		&core.RequestOptions{Headers: map[string]string{
@@ -76,18 +93,18 @@ func (s *scriptNorway) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptNorway) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptNorway) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
 	params := url.Values{}
-	params.Add("StationId", strings.Join(getTheirStationIds(!s.ignoreLegacy, codes.Slice()), ","))
+	params.Add("StationId", strings.Join(getTheirStationIds(!s.ignoreLegacy, spec.Codes.Slice()), ","))
 	params.Add("Parameter", "1000,1001")
 	params.Add("ResolutionTime", "0")
-	params.Add("ReferenceTime", "PT3H/") // 3hours to now - some stations have delay
+	params.Add("ReferenceTime", referenceTime(spec, time.Now()))
 
 	resp := observationsResp{}
-	err := core.Client.GetAsJSON(
+	err := core.Client.WithContext(ctx).GetAsJSON(
 		fmt.Sprintf("%s/Observations?%s", s.urlBase, params.Encode()),
 		&resp,
 		&core.RequestOptions{Headers: map[string]string{
@@ -101,6 +118,10 @@ func (s *scriptNorway) Harvest(ctx context.Context, recv chan<- *core.Measuremen
 	measurements := make(map[string]*core.Measurement)
 
 	for _, obsList := range resp.Data {
+		code := getOurStationId(!s.ignoreLegacy, obsList.StationID)
+		if opts, _ := spec.GaugeOptions[code].(*optionsNorway); opts.ignores(obsList.Parameter) {
+			continue
+		}
 		for _, o := range obsList.Observations {
 			ts, err := time.ParseInLocation("2006-01-02T15:04:05Z", o.Time, time.UTC)
 			if err != nil {
@@ -112,7 +133,7 @@ func (s *scriptNorway) Harvest(ctx context.Context, recv chan<- *core.Measuremen
 				m = &core.Measurement{
 					GaugeID: core.GaugeID{
 						Script: s.name,
-						Code:   getOurStationId(!s.ignoreLegacy, obsList.StationID),
+						Code:   code,
 					},
 					Timestamp: core.HTime{Time: ts},
 				}
@@ -124,6 +145,13 @@ func (s *scriptNorway) Harvest(ctx context.Context, recv chan<- *core.Measuremen
 			} else {
 				continue
 			}
+			if o.Quality > 0 {
+				m.Quality = strconv.Itoa(o.Quality)
+			}
+			// values are final only after secondary control
+			if o.Quality == 1 || o.Quality == 2 {
+				m.Provisional = true
+			}
 			measurements[key] = m
 		}
 	}
@@ -132,3 +160,12 @@ func (s *scriptNorway) Harvest(ctx context.Context, recv chan<- *core.Measuremen
 		recv <- m
 	}
 }
+
+// referenceTime returns period to request from upstream: since oldest cached measurement of harvested stations, but not longer than 3 hours
+func referenceTime(spec core.HarvestSpec, now time.Time) string {
+	window := spec.Since.Window(spec.Codes, now, 3*time.Hour)
+	if window >= 3*time.Hour {
+		return "PT3H/" // 3hours to now - some stations have delay
+	}
+	return fmt.Sprintf("PT%dM/", int(math.Max(1, math.Ceil(window.Minutes()))))
+}
@@ -1,6 +1,7 @@
 package norway
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -26,7 +27,7 @@ func TestNorway_Auth(t *testing.T) {
 		urlBase: ts.URL,
 		apiKey:  "__bad__",
 	}
-	_, err := s.ListGauges()
+	_, err := s.ListGauges(context.Background())
 	assert.Error(t, err)
 }
 
@@ -38,7 +39,7 @@ func TestNorway_ListGauges(t *testing.T) {
 		urlBase: ts.URL,
 		apiKey:  testutils.TestAuthKey,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -87,7 +88,7 @@ func TestNorwayHarvest(t *testing.T) {
 		urlBase: ts.URL,
 		apiKey:  testutils.TestAuthKey,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"2.284": {}, "2.13.0": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"2.284": {}, "2.13.0": {}})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -97,8 +98,10 @@ func TestNorwayHarvest(t *testing.T) {
 			Timestamp: core.HTime{
 				Time: time.Date(2024, time.June, 8, 18, 0, 0, 0, time.UTC),
 			},
-			Flow:  nulltype.NullFloat64Of(19.97738),
-			Level: nulltype.NullFloat64Of(406.4),
+			Flow:        nulltype.NullFloat64Of(19.97738),
+			Level:       nulltype.NullFloat64Of(406.4),
+			Quality:     "1",
+			Provisional: true,
 		},
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -108,11 +111,53 @@ func TestNorwayHarvest(t *testing.T) {
 			Timestamp: core.HTime{
 				Time: time.Date(2024, time.June, 8, 18, 0, 0, 0, time.UTC),
 			},
-			Flow:  nulltype.NullFloat64Of(56.41846),
-			Level: nulltype.NullFloat64Of(940.731),
+			Flow:        nulltype.NullFloat64Of(56.41846),
+			Level:       nulltype.NullFloat64Of(940.731),
+			Quality:     "1",
+			Provisional: true,
 		},
 	}
 	if assert.NoError(t, err) {
 		assert.ElementsMatch(t, expected, actual)
 	}
 }
+
+func TestNorwayHarvestGaugeOptions(t *testing.T) {
+	ts := setupTestServer()
+	defer ts.Close()
+	s := scriptNorway{
+		name:    "norway",
+		urlBase: ts.URL,
+		apiKey:  testutils.TestAuthKey,
+	}
+	in := make(chan *core.Measurement)
+	errCh := make(chan error, 1)
+	out := core.SinkToSlice(context.Background(), in)
+	s.Harvest(context.Background(), in, errCh, core.HarvestSpec{
+		Codes: core.StringSet{"2.284": {}, "2.13.0": {}},
+		GaugeOptions: core.GaugeOptions{
+			"2.284":  &optionsNorway{IgnoreFlow: true},
+			"2.13.0": &optionsNorway{IgnoreLevel: true, IgnoreFlow: true},
+		},
+	})
+	actual := <-out
+	if assert.NoError(t, <-errCh) && assert.Len(t, actual, 1) {
+		assert.Equal(t, "2.284", actual[0].Code)
+		assert.Equal(t, nulltype.NullFloat64Of(406.4), actual[0].Level)
+		assert.False(t, actual[0].Flow.Valid())
+	}
+}
+
+func TestNorwayReferenceTime(t *testing.T) {
+	now := time.Date(2024, time.June, 8, 18, 0, 0, 0, time.UTC)
+	codes := core.StringSet{"2.284": {}, "2.13.0": {}}
+	assert.Equal(t, "PT3H/", referenceTime(core.HarvestSpec{Codes: codes}, now))
+
+	spec := core.HarvestSpec{Codes: codes, Since: core.SinceMap{
+		"2.284":  now.Add(-30 * time.Minute).Unix(),
+		"2.13.0": now.Add(-10 * time.Minute).Unix(),
+	}}
+	assert.Equal(t, "PT30M/", referenceTime(spec, now))
+	spec.Codes = core.StringSet{"2.284": {}, "2.2.0": {}}
+	assert.Equal(t, "PT3H/", referenceTime(spec, now))
+}
@@ -160,5 +160,6 @@ type observation struct {
 	Time  string               `json:"time"`
 	Value nulltype.NullFloat64 `json:"value"`
 	// Correction int `json:"correction"`
-	// Quality int `json:"quality"`
+	// Quality is 0 - unknown, 1 - uncontrolled, 2 - primary controlled, 3 - secondary controlled
+	Quality int `json:"quality"`
 }
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "nzbop",
 	Description: "New Zealand: Bay of Plenty Regional Council",
 	Mode:        core.OneByOne,
+	Host:        "monitoring.boprc.govt.nz",
 	DefaultOptions: func() interface{} {
 		return &optionsBop{}
 	},
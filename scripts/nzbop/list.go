@@ -1,6 +1,7 @@
 package nzbop
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/whitewater-guide/gorge/core"
@@ -8,8 +9,8 @@ import (
 
 var codesRegExp = regexp.MustCompile(`d\.add\(\d+,558,.*site=(\d+)`)
 
-func (s *scriptBop) parseList() ([]string, error) {
-	html, err := core.Client.GetAsString(s.listURL, nil)
+func (s *scriptBop) parseList(ctx context.Context) ([]string, error) {
+	html, err := core.Client.WithContext(ctx).GetAsString(s.listURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package nzbop
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -36,9 +37,9 @@ func parseRow(td *goquery.Selection) (v nulltype.NullFloat64, m string, t time.T
 	return
 }
 
-func (s *scriptBop) parsePage(code string, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement) {
+func (s *scriptBop) parsePage(ctx context.Context, code string, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement) {
 	url := fmt.Sprintf(s.pageURL, code)
-	doc, err := core.Client.GetAsDoc(url, nil)
+	doc, err := core.Client.WithContext(ctx).GetAsDoc(url, nil)
 	if err != nil {
 		s.GetLogger().WithField("code", code).Error("failed to fetch page")
 		return
@@ -116,9 +117,9 @@ func (s *scriptBop) parsePage(code string, gauges chan<- *core.Gauge, measuremen
 	}
 }
 
-func (s *scriptBop) gaugePageWorker(codes <-chan string, results chan<- *core.Gauge, wg *sync.WaitGroup) {
+func (s *scriptBop) gaugePageWorker(ctx context.Context, codes <-chan string, results chan<- *core.Gauge, wg *sync.WaitGroup) {
 	for code := range codes {
-		s.parsePage(code, results, nil)
+		s.parsePage(ctx, code, results, nil)
 	}
 	wg.Done()
 }
@@ -16,8 +16,8 @@ type scriptBop struct {
 	core.LoggingScript
 }
 
-func (s *scriptBop) ListGauges() (core.Gauges, error) {
-	codes, err := s.parseList()
+func (s *scriptBop) ListGauges(ctx context.Context) (core.Gauges, error) {
+	codes, err := s.parseList(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +28,7 @@ func (s *scriptBop) ListGauges() (core.Gauges, error) {
 	var wg sync.WaitGroup
 	for i := 1; i <= s.numWorkers; i++ {
 		wg.Add(1)
-		go s.gaugePageWorker(codesCh, resultsCh, &wg)
+		go s.gaugePageWorker(ctx, codesCh, resultsCh, &wg)
 	}
 	go func() {
 		for _, code := range codes {
@@ -46,13 +46,13 @@ func (s *scriptBop) ListGauges() (core.Gauges, error) {
 	return results, nil
 }
 
-func (s *scriptBop) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptBop) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	code, err := codes.Only()
+	code, err := spec.Codes.Only()
 	if err != nil {
 		errs <- err
 		return
 	}
-	s.parsePage(code, nil, recv)
+	s.parsePage(ctx, code, nil, recv)
 }
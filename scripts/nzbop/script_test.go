@@ -1,6 +1,7 @@
 package nzbop
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -24,7 +25,7 @@ func TestBop_ListGauges(t *testing.T) {
 		pageURL:    ts.URL + "/%s.html",
 		numWorkers: 2,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -83,7 +84,7 @@ func TestBop_Harvest(t *testing.T) {
 		pageURL:    ts.URL + "/%s.html",
 		numWorkers: 2,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"202": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"202": {}})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -111,7 +112,7 @@ func TestBop_ParseList(t *testing.T) {
 		pageURL:    ts.URL + "/%s.html",
 		numWorkers: 2,
 	}
-	actual, err := s.parseList()
+	actual, err := s.parseList(context.Background())
 	expected := []string{
 		"11386",
 		"333",
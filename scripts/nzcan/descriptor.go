@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "nzcan",
 	Description: "New Zealand: Environment Canterbury",
 	Mode:        core.AllAtOnce,
+	Host:        "ecan.govt.nz",
 	DefaultOptions: func() interface{} {
 		return &optionsNzcan{}
 	},
@@ -1,6 +1,7 @@
 package nzcan
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -39,8 +40,8 @@ func getTime(year int, str string) (*core.HTime, error) {
 	}, nil
 }
 
-func (s *scriptNzcan) fetchGeo() (map[string]geoloc, error) {
-	txt, err := core.Client.GetAsString(s.url+"/RiverflowGeo/ALL", &core.RequestOptions{
+func (s *scriptNzcan) fetchGeo(ctx context.Context) (map[string]geoloc, error) {
+	txt, err := core.Client.WithContext(ctx).GetAsString(s.url+"/RiverflowGeo/ALL", &core.RequestOptions{
 		Headers: map[string]string{
 			"X-Requested-With": "XMLHttpRequest",
 		},
@@ -76,8 +77,8 @@ func (s *scriptNzcan) fetchGeo() (map[string]geoloc, error) {
 	return result, nil
 }
 
-func (s *scriptNzcan) fetchList(suffix string, recv chan<- *core.Measurement) error {
-	doc, err := core.Client.GetAsDoc(s.url+"/RiverflowList/"+suffix, &core.RequestOptions{
+func (s *scriptNzcan) fetchList(ctx context.Context, suffix string, recv chan<- *core.Measurement) error {
+	doc, err := core.Client.WithContext(ctx).GetAsDoc(s.url+"/RiverflowList/"+suffix, &core.RequestOptions{
 		Headers: map[string]string{
 			"X-Requested-With": "XMLHttpRequest",
 		},
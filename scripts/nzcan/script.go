@@ -15,19 +15,19 @@ type scriptNzcan struct {
 	core.LoggingScript
 }
 
-func (s *scriptNzcan) ListGauges() (core.Gauges, error) {
-	locations, err := s.fetchGeo()
+func (s *scriptNzcan) ListGauges(ctx context.Context) (core.Gauges, error) {
+	locations, err := s.fetchGeo(ctx)
 	if err != nil {
 		return nil, err
 	}
 	msmnts := make(chan *core.Measurement)
 	go func() {
 		defer close(msmnts)
-		err = s.fetchList("NORTH", msmnts)
+		err = s.fetchList(ctx, "NORTH", msmnts)
 		if err != nil {
 			return
 		}
-		err = s.fetchList("SOUTH", msmnts)
+		err = s.fetchList(ctx, "SOUTH", msmnts)
 	}()
 	var result core.Gauges
 	for m := range msmnts {
@@ -55,15 +55,15 @@ func (s *scriptNzcan) ListGauges() (core.Gauges, error) {
 	return result, err
 }
 
-func (s *scriptNzcan) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptNzcan) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	err := s.fetchList("NORTH", recv)
+	err := s.fetchList(ctx, "NORTH", recv)
 	if err != nil {
 		errs <- err
 		return
 	}
-	err = s.fetchList("SOUTH", recv)
+	err = s.fetchList(ctx, "SOUTH", recv)
 	if err != nil {
 		errs <- err
 		return
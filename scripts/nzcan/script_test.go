@@ -1,6 +1,7 @@
 package nzcan
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -27,7 +28,7 @@ func TestNzcan_ListGauges(t *testing.T) {
 		name: "nzcan",
 		url:  ts.URL,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -87,7 +88,7 @@ func TestNzcan_Harvest(t *testing.T) {
 		name: "nzcan",
 		url:  ts.URL,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
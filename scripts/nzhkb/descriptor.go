@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "nzhkb",
 	Description: "New Zealand: Hawke's Bay Regional Council",
 	Mode:        core.AllAtOnce,
+	Host:        "hbmaps.hbrc.govt.nz",
 	DefaultOptions: func() interface{} {
 		return &optionsNzhkb{}
 	},
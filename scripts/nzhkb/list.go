@@ -1,6 +1,7 @@
 package nzhkb
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -15,22 +16,22 @@ const (
 	paramsMeasurements = "where=1%3D1&outFields=ObjectID,Hydrotel_LastSampleTime,Hydrotel_CurrentValue&returnGeometry=false&outSR=4326&f=json"
 )
 
-func (s *scriptNzhkb) fetchList(endpoint string, params string) ([]hkbFeature, error) {
+func (s *scriptNzhkb) fetchList(ctx context.Context, endpoint string, params string) ([]hkbFeature, error) {
 	var data hkbList
 	url := fmt.Sprintf("%s/%s/query?%s", s.url, endpoint, params)
-	err := core.Client.GetAsJSON(url, &data, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(url, &data, nil)
 	if err != nil {
 		return nil, err
 	}
 	return data.Features, nil
 }
 
-func (s *scriptNzhkb) fetchGauges() (core.Gauges, error) {
-	flows, err := s.fetchList(endpointFlow, paramsGauges)
+func (s *scriptNzhkb) fetchGauges(ctx context.Context) (core.Gauges, error) {
+	flows, err := s.fetchList(ctx, endpointFlow, paramsGauges)
 	if err != nil {
 		return nil, err
 	}
-	levels, err := s.fetchList(endpointLevels, paramsGauges)
+	levels, err := s.fetchList(ctx, endpointLevels, paramsGauges)
 	if err != nil {
 		return nil, err
 	}
@@ -75,13 +76,13 @@ func (s *scriptNzhkb) fetchGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptNzhkb) fetchMeasurements(recv chan<- *core.Measurement, errs chan<- error) {
-	flows, err := s.fetchList(endpointFlow, paramsMeasurements)
+func (s *scriptNzhkb) fetchMeasurements(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error) {
+	flows, err := s.fetchList(ctx, endpointFlow, paramsMeasurements)
 	if err != nil {
 		errs <- err
 		return
 	}
-	levels, err := s.fetchList(endpointLevels, paramsMeasurements)
+	levels, err := s.fetchList(ctx, endpointLevels, paramsMeasurements)
 	if err != nil {
 		errs <- err
 		return
@@ -13,12 +13,12 @@ type scriptNzhkb struct {
 	core.LoggingScript
 }
 
-func (s *scriptNzhkb) ListGauges() (core.Gauges, error) {
-	return s.fetchGauges()
+func (s *scriptNzhkb) ListGauges(ctx context.Context) (core.Gauges, error) {
+	return s.fetchGauges(ctx)
 }
 
-func (s *scriptNzhkb) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptNzhkb) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(errs)
 	defer close(recv)
-	s.fetchMeasurements(recv, errs)
+	s.fetchMeasurements(ctx, recv, errs)
 }
@@ -1,6 +1,7 @@
 package nzhkb
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -25,7 +26,7 @@ func TestNzhkb_ListGauges(t *testing.T) {
 		name: "nzhkb",
 		url:  ts.URL,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -83,7 +84,7 @@ func TestNzhkb_Harvest(t *testing.T) {
 		name: "nzhkb",
 		url:  ts.URL,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
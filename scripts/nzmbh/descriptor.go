@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "nzmbh",
 	Description: "New Zealand: Marlborough District Council",
 	Mode:        core.AllAtOnce,
+	Host:        "hydro.marlborough.govt.nz",
 	DefaultOptions: func() interface{} {
 		return &optionsNzmbh{}
 	},
@@ -1,15 +1,16 @@
 package nzmbh
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/mattn/go-nulltype"
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptNzmbh) fetchReport(recv chan<- *core.Measurement, errs chan<- error) {
+func (s *scriptNzmbh) fetchReport(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error) {
 	var report riverReport
-	err := core.Client.GetAsJSON(s.reportURL, &report, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.reportURL, &report, nil)
 	if err != nil {
 		errs <- err
 		return
@@ -14,8 +14,8 @@ type scriptNzmbh struct {
 	core.LoggingScript
 }
 
-func (s *scriptNzmbh) ListGauges() (core.Gauges, error) {
-	sites, err := s.fetchSiteList()
+func (s *scriptNzmbh) ListGauges(ctx context.Context) (core.Gauges, error) {
+	sites, err := s.fetchSiteList(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +26,7 @@ func (s *scriptNzmbh) ListGauges() (core.Gauges, error) {
 	go func() {
 		defer close(msmntsCh)
 		defer close(errCh)
-		s.fetchReport(msmntsCh, errCh)
+		s.fetchReport(ctx, msmntsCh, errCh)
 	}()
 
 outer:
@@ -51,8 +51,8 @@ outer:
 	return gauges, nil
 }
 
-func (s *scriptNzmbh) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptNzmbh) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	s.fetchReport(recv, errs)
+	s.fetchReport(ctx, recv, errs)
 }
@@ -1,6 +1,7 @@
 package nzmbh
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -23,7 +24,7 @@ func TestNzmbh_ListGauges(t *testing.T) {
 		reportURL:   ts.URL + "/riverreport.json",
 		siteListURL: ts.URL + "/sitelist.xml",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -68,7 +69,7 @@ func TestNzmbh_Harvest(t *testing.T) {
 		reportURL:   ts.URL + "/riverreport.json",
 		siteListURL: ts.URL + "/sitelist.xml",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -1,6 +1,7 @@
 package nzmbh
 
 import (
+	"context"
 	"encoding/xml"
 	"net/http"
 	"strconv"
@@ -14,8 +15,8 @@ type site struct {
 	name string
 }
 
-func (s *scriptNzmbh) fetchSiteList() (map[string]site, error) {
-	req, _ := http.NewRequest("GET", s.siteListURL, nil)
+func (s *scriptNzmbh) fetchSiteList(ctx context.Context) (map[string]site, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", s.siteListURL, nil)
 	resp, err := core.Client.Do(req, nil)
 
 	if err != nil {
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "nzniwa",
 	Description: "New Zealand: National Institute of Water and Atmospheric Research",
 	Mode:        core.AllAtOnce,
+	Host:        "hydrowebportal.niwa.co.nz",
 	DefaultOptions: func() interface{} {
 		return &optionsNzniwa{}
 	},
@@ -1,6 +1,7 @@
 package nzniwa
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,9 +12,9 @@ import (
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptNzniwa) fetchMeasurements(measurements chan<- *core.Measurement, errs chan<- error) {
+func (s *scriptNzniwa) fetchMeasurements(ctx context.Context, measurements chan<- *core.Measurement, errs chan<- error) {
 	var data niwaList
-	err := core.Client.GetAsJSON(s.flowURL, &data, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.flowURL, &data, nil)
 	if err != nil {
 		errs <- err
 		return
@@ -32,9 +33,9 @@ func (s *scriptNzniwa) fetchMeasurements(measurements chan<- *core.Measurement,
 	}
 }
 
-func (s *scriptNzniwa) fetchGauges(gauges chan<- *core.Gauge) error {
+func (s *scriptNzniwa) fetchGauges(ctx context.Context, gauges chan<- *core.Gauge) error {
 	var data niwaList
-	err := core.Client.GetAsJSON(s.flowURL, &data, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.flowURL, &data, nil)
 	if err != nil {
 		return err
 	}
@@ -62,9 +63,9 @@ func (s *scriptNzniwa) fetchGauges(gauges chan<- *core.Gauge) error {
 	return nil
 }
 
-func (s *scriptNzniwa) parseLocation(locationID string) (string, string, error) {
+func (s *scriptNzniwa) parseLocation(ctx context.Context, locationID string) (string, string, error) {
 	url := s.locationURL + locationID
-	req, err := http.NewRequest("POST", url, strings.NewReader("sort=Identifier-asc&page=1&pageSize=100&group=&filter=&timezone=0"))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader("sort=Identifier-asc&page=1&pageSize=100&group=&filter=&timezone=0"))
 	req.Header.Add("Accept", "*/*")
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded; charset=UTF-8")
 	req.Header.Add("Pragma", "no-cache")
@@ -98,9 +99,9 @@ func (s *scriptNzniwa) parseLocation(locationID string) (string, string, error)
 	return "", "", errors.New("not found")
 }
 
-func (s *scriptNzniwa) gaugePageWorker(gauges <-chan *core.Gauge, results chan<- *core.Gauge, wg *sync.WaitGroup) {
+func (s *scriptNzniwa) gaugePageWorker(ctx context.Context, gauges <-chan *core.Gauge, results chan<- *core.Gauge, wg *sync.WaitGroup) {
 	for g := range gauges {
-		unit, id, err := s.parseLocation(g.Code)
+		unit, id, err := s.parseLocation(ctx, g.Code)
 		if err != nil {
 			fmt.Println(err)
 			s.GetLogger().WithField("code", g.GaugeID.Code).Error(err)
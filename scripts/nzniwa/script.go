@@ -17,9 +17,9 @@ type scriptNzniwa struct {
 	// levelURL    string
 }
 
-func (s *scriptNzniwa) ListGauges() (core.Gauges, error) {
+func (s *scriptNzniwa) ListGauges(ctx context.Context) (core.Gauges, error) {
 	gaugesCh := make(chan *core.Gauge)
-	err := s.fetchGauges(gaugesCh)
+	err := s.fetchGauges(ctx, gaugesCh)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +28,7 @@ func (s *scriptNzniwa) ListGauges() (core.Gauges, error) {
 	var wg sync.WaitGroup
 	for w := 1; w <= s.numWorkers; w++ {
 		wg.Add(1)
-		go s.gaugePageWorker(gaugesCh, resultsCh, &wg)
+		go s.gaugePageWorker(ctx, gaugesCh, resultsCh, &wg)
 	}
 	go func() {
 		wg.Wait()
@@ -41,8 +41,8 @@ func (s *scriptNzniwa) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptNzniwa) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptNzniwa) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(errs)
 	defer close(recv)
-	s.fetchMeasurements(recv, errs)
+	s.fetchMeasurements(ctx, recv, errs)
 }
@@ -1,6 +1,7 @@
 package nzniwa
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -27,7 +28,7 @@ func TestNzniwa_ListGauges(t *testing.T) {
 		numWorkers:  2,
 		flowURL:     ts.URL + "/flow.json",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -86,7 +87,7 @@ func TestNzniwa_Harvest(t *testing.T) {
 		numWorkers:  2,
 		flowURL:     ts.URL + "/flow.json",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "nzstl",
 	Description: "New Zealand: Environment Southland",
 	Mode:        core.AllAtOnce,
+	Host:        "envdata.es.govt.nz",
 	DefaultOptions: func() interface{} {
 		return &optionsNzstl{}
 	},
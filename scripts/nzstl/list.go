@@ -1,15 +1,16 @@
 package nzstl
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/mattn/go-nulltype"
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptNzstl) fetchList(gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
+func (s *scriptNzstl) fetchList(ctx context.Context, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
 	var data list
-	err := core.Client.GetAsJSON(s.url, &data, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.url, &data, nil)
 	if err != nil {
 		errs <- err
 		return
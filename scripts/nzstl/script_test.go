@@ -1,6 +1,7 @@
 package nzstl
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -22,7 +23,7 @@ func TestNzstl_ListGauges(t *testing.T) {
 		name: "nzstl",
 		url:  ts.URL + "/list.json",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -66,7 +67,7 @@ func TestNzstl_Harvest(t *testing.T) {
 		name: "nzstl",
 		url:  ts.URL + "/list.json",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
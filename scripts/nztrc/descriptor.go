@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "nztrc",
 	Description: "New Zealand: Taranaki Regional Council",
 	Mode:        core.AllAtOnce,
+	Host:        "www.trc.govt.nz",
 	DefaultOptions: func() interface{} {
 		return &optionsNztrc{}
 	},
@@ -1,6 +1,7 @@
 package nztrc
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -18,9 +19,9 @@ type station struct {
 	flow  *site
 }
 
-func (s *scriptNztrc) fetchList(measureID string, out *map[int]station) error {
+func (s *scriptNztrc) fetchList(ctx context.Context, measureID string, out *map[int]station) error {
 	var list []site
-	err := core.Client.GetAsJSON(s.url+measureID, &list, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.url+measureID, &list, nil)
 	if err != nil {
 		return err
 	}
@@ -41,14 +42,14 @@ func (s *scriptNztrc) fetchList(measureID string, out *map[int]station) error {
 	return nil
 }
 
-func (s *scriptNztrc) parseList(gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
+func (s *scriptNztrc) parseList(ctx context.Context, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
 	stations := map[int]station{}
-	err := s.fetchList("9", &stations)
+	err := s.fetchList(ctx, "9", &stations)
 	if err != nil {
 		errs <- err
 		return
 	}
-	err = s.fetchList("7", &stations)
+	err = s.fetchList(ctx, "7", &stations)
 	if err != nil {
 		errs <- err
 		return
@@ -13,19 +13,19 @@ type scriptNztrc struct {
 	core.LoggingScript
 }
 
-func (s *scriptNztrc) ListGauges() (core.Gauges, error) {
+func (s *scriptNztrc) ListGauges(ctx context.Context) (core.Gauges, error) {
 	gaugesCh := make(chan *core.Gauge)
 	errCh := make(chan error)
 	go func() {
 		defer close(gaugesCh)
 		defer close(errCh)
-		s.parseList(gaugesCh, nil, errCh)
+		s.parseList(ctx, gaugesCh, nil, errCh)
 	}()
 	return core.GaugeSinkToSlice(gaugesCh, errCh)
 }
 
-func (s *scriptNztrc) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptNztrc) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	s.parseList(nil, recv, errs)
+	s.parseList(ctx, nil, recv, errs)
 }
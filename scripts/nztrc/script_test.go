@@ -1,6 +1,7 @@
 package nztrc
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -25,7 +26,7 @@ func TestNztrc_ListGauges(t *testing.T) {
 		name: "nztrc",
 		url:  ts.URL + "/",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -83,7 +84,7 @@ func TestNztrc_Harvest(t *testing.T) {
 		name: "nztrc",
 		url:  ts.URL + "/",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	now := time.Now().In(tz)
 	expected := core.Measurements{
 		&core.Measurement{
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "nzwgn",
 	Description: "New Zealand: Greater Wellington Regional Council",
 	Mode:        core.AllAtOnce,
+	Host:        "hilltop.gw.govt.nz",
 	DefaultOptions: func() interface{} {
 		return &optionsNzwgn{}
 	},
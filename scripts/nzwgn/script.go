@@ -13,12 +13,12 @@ type scriptNzwgn struct {
 	core.LoggingScript
 }
 
-func (s *scriptNzwgn) ListGauges() (core.Gauges, error) {
-	locations, err := s.fetchLocations()
+func (s *scriptNzwgn) ListGauges(ctx context.Context) (core.Gauges, error) {
+	locations, err := s.fetchLocations(ctx)
 	if err != nil {
 		return nil, err
 	}
-	vals, err := s.fetchValues()
+	vals, err := s.fetchValues(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -38,10 +38,10 @@ func (s *scriptNzwgn) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptNzwgn) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptNzwgn) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	data, err := s.fetchValues()
+	data, err := s.fetchValues(ctx)
 	if err != nil {
 		errs <- err
 		return
@@ -1,6 +1,7 @@
 package nzwgn
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -19,10 +20,10 @@ type dataItem struct {
 // Another possible option is "WQ%20/%20Rivers%20and%20Streams" but I think it's useless
 const collection = "River%20and%20Stream%20Levels"
 
-func (s *scriptNzwgn) fetchLocations() (map[string]core.Location, error) {
+func (s *scriptNzwgn) fetchLocations(ctx context.Context) (map[string]core.Location, error) {
 	url := fmt.Sprintf("%s?Service=Hilltop&Request=SiteList&Collection=%s&Location=LatLong", s.url, collection)
 	var list siteList
-	err := core.Client.GetAsXML(url, &list, nil)
+	err := core.Client.WithContext(ctx).GetAsXML(url, &list, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -39,10 +40,10 @@ func (s *scriptNzwgn) fetchLocations() (map[string]core.Location, error) {
 	return result, nil
 }
 
-func (s *scriptNzwgn) fetchValues() (map[string]dataItem, error) {
+func (s *scriptNzwgn) fetchValues(ctx context.Context) (map[string]dataItem, error) {
 	url := fmt.Sprintf("%s?Service=Hilltop&Request=GetData&Collection=%s&Location=LatLong", s.url, collection)
 	var data measurements
-	err := core.Client.GetAsXML(url, &data, nil)
+	err := core.Client.WithContext(ctx).GetAsXML(url, &data, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "nzwko",
 	Description: "New Zealand: Waikato Regional Council",
 	Mode:        core.AllAtOnce,
+	Host:        "riverlevelsmap.waikatoregion.govt.nz",
 	DefaultOptions: func() interface{} {
 		return &optionsWaikato{}
 	},
@@ -1,6 +1,7 @@
 package nzwko
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -14,9 +15,9 @@ import (
 var locRegExp = regexp.MustCompile(`NZTM:\s*(\d+)\s*-\s*(\d+)`)
 var spaces = regexp.MustCompile(`\s+`)
 
-func (s *scriptWaikato) parseGaugePage(code string, hasFlow, hasLevel bool) (*core.Gauge, error) {
+func (s *scriptWaikato) parseGaugePage(ctx context.Context, code string, hasFlow, hasLevel bool) (*core.Gauge, error) {
 	url := fmt.Sprintf(s.pageURL, code)
-	doc, err := core.Client.GetAsDoc(url, nil)
+	doc, err := core.Client.WithContext(ctx).GetAsDoc(url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -64,10 +65,10 @@ func (s *scriptWaikato) parseGaugePage(code string, hasFlow, hasLevel bool) (*co
 	}, nil
 }
 
-func (s *scriptWaikato) gaugePageWorker(ms <-chan *core.Measurement, results chan<- *core.Gauge, wg *sync.WaitGroup) {
+func (s *scriptWaikato) gaugePageWorker(ctx context.Context, ms <-chan *core.Measurement, results chan<- *core.Gauge, wg *sync.WaitGroup) {
 	for m := range ms {
 		code := m.Code
-		gauge, err := s.parseGaugePage(code, m.Flow.Valid(), m.Level.Valid())
+		gauge, err := s.parseGaugePage(ctx, code, m.Flow.Valid(), m.Level.Valid())
 		if err != nil {
 			fmt.Println(err)
 			s.GetLogger().WithFields(logrus.Fields{
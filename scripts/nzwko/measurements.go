@@ -1,6 +1,7 @@
 package nzwko
 
 import (
+	"context"
 	"net/url"
 	"strconv"
 	"time"
@@ -12,8 +13,8 @@ import (
 
 var tz, _ = time.LoadLocation("Pacific/Auckland")
 
-func (s *scriptWaikato) parseMeasurements(measurements chan<- *core.Measurement) error {
-	doc, err := core.Client.GetAsDoc(s.listURL, nil)
+func (s *scriptWaikato) parseMeasurements(ctx context.Context, measurements chan<- *core.Measurement) error {
+	doc, err := core.Client.WithContext(ctx).GetAsDoc(s.listURL, nil)
 	if err != nil {
 		return err
 	}
@@ -16,7 +16,7 @@ type scriptWaikato struct {
 	core.LoggingScript
 }
 
-func (s *scriptWaikato) ListGauges() (core.Gauges, error) {
+func (s *scriptWaikato) ListGauges(ctx context.Context) (core.Gauges, error) {
 	listCh := make(chan *core.Measurement)
 	resultsCh := make(chan *core.Gauge)
 	var err error
@@ -25,11 +25,11 @@ func (s *scriptWaikato) ListGauges() (core.Gauges, error) {
 	var wg sync.WaitGroup
 	for i := 1; i <= s.numWorkers; i++ {
 		wg.Add(1)
-		go s.gaugePageWorker(listCh, resultsCh, &wg)
+		go s.gaugePageWorker(ctx, listCh, resultsCh, &wg)
 	}
 	go func() {
 		defer close(listCh)
-		err = s.parseMeasurements(listCh)
+		err = s.parseMeasurements(ctx, listCh)
 	}()
 	if err != nil {
 		close(resultsCh)
@@ -45,10 +45,10 @@ func (s *scriptWaikato) ListGauges() (core.Gauges, error) {
 	return results, nil
 }
 
-func (s *scriptWaikato) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptWaikato) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	err := s.parseMeasurements(recv)
+	err := s.parseMeasurements(ctx, recv)
 	if err != nil {
 		errs <- err
 	}
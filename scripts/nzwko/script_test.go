@@ -1,6 +1,7 @@
 package nzwko
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -24,7 +25,7 @@ func TestWaikato_ListGauges(t *testing.T) {
 		pageURL:    ts.URL + "/%s.html",
 		numWorkers: 2,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -85,7 +86,7 @@ func TestWaikato_Harvest(t *testing.T) {
 		pageURL:    ts.URL + "/%s.html",
 		numWorkers: 2,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"894": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"894": {}})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -1,14 +1,15 @@
 package quebec
 
 import (
+	"context"
 	"strings"
 
 	"github.com/antchfx/htmlquery"
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptQuebec) getCodes() ([]string, error) {
-	resp, err := core.Client.Get(s.codesURL, &core.RequestOptions{SkipCookies: true})
+func (s *scriptQuebec) getCodes(ctx context.Context) ([]string, error) {
+	resp, err := core.Client.WithContext(ctx).Get(s.codesURL, &core.RequestOptions{SkipCookies: true})
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "quebec",
 	Description: "Québec: Ministère de l'Environnement et de la Lutte contre les changements climatiques",
 	Mode:        core.OneByOne,
+	Host:        "www.cehq.gouv.qc.ca",
 	DefaultOptions: func() interface{} {
 		return &optionsQuebec{}
 	},
@@ -1,6 +1,7 @@
 package quebec
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -18,8 +19,8 @@ type stationInfo struct {
 	isLocal     bool
 }
 
-func (s *scriptQuebec) parsePage(code string) (*stationInfo, error) {
-	resp, err := core.Client.Get(fmt.Sprintf(s.stationURLFormat, code), &core.RequestOptions{SkipCookies: true})
+func (s *scriptQuebec) parsePage(ctx context.Context, code string) (*stationInfo, error) {
+	resp, err := core.Client.WithContext(ctx).Get(fmt.Sprintf(s.stationURLFormat, code), &core.RequestOptions{SkipCookies: true})
 	if err != nil {
 		return nil, err
 	}
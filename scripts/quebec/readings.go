@@ -1,6 +1,7 @@
 package quebec
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
@@ -94,16 +95,16 @@ func (s *scriptQuebec) parseReadings(recv chan<- *core.Measurement, errs chan<-
 	}
 }
 
-func (s *scriptQuebec) getReadings(recv chan<- *core.Measurement, errs chan<- error, code string) {
-	if err := s.getReadingsJson(recv, errs, code); err != nil {
+func (s *scriptQuebec) getReadings(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, code string) {
+	if err := s.getReadingsJson(ctx, recv, errs, code); err != nil {
 		s.GetLogger().WithField("code", code).Debugf("failed to get json: %s", err)
-		s.getReadingsCSV(recv, errs, code)
+		s.getReadingsCSV(ctx, recv, errs, code)
 	}
 }
 
-func (s *scriptQuebec) getReadingsJson(recv chan<- *core.Measurement, errs chan<- error, code string) error {
+func (s *scriptQuebec) getReadingsJson(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, code string) error {
 	var dest qJson
-	if err := core.Client.GetAsJSON(fmt.Sprintf(s.readingsJSONFormat, code), &dest, &core.RequestOptions{SkipCookies: true, RetryErrors: true}); err != nil {
+	if err := core.Client.WithContext(ctx).GetAsJSON(fmt.Sprintf(s.readingsJSONFormat, code), &dest, &core.RequestOptions{SkipCookies: true, RetryErrors: true}); err != nil {
 		return err
 	}
 	all := map[int64]core.Measurement{}
@@ -134,10 +135,10 @@ func (s *scriptQuebec) getReadingsJson(recv chan<- *core.Measurement, errs chan<
 	return nil
 }
 
-func (s *scriptQuebec) getReadingsCSV(recv chan<- *core.Measurement, errs chan<- error, code string) {
+func (s *scriptQuebec) getReadingsCSV(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, code string) {
 	// will set-cookies every time, until max headers length overflows
 	// the workaround is to ignore cookies entirely
-	resp, err := core.Client.Get(fmt.Sprintf(s.readingsCSVFormat, code), &core.RequestOptions{SkipCookies: true, RetryErrors: true})
+	resp, err := core.Client.WithContext(ctx).Get(fmt.Sprintf(s.readingsCSVFormat, code), &core.RequestOptions{SkipCookies: true, RetryErrors: true})
 	if err != nil {
 		errs <- err
 		return
@@ -1,6 +1,7 @@
 package quebec
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
@@ -8,8 +9,8 @@ import (
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptQuebec) getReferenceList() (map[string]core.Gauge, error) {
-	resp, err := core.Client.Get(s.referenceListURL, &core.RequestOptions{SkipCookies: true})
+func (s *scriptQuebec) getReferenceList(ctx context.Context) (map[string]core.Gauge, error) {
+	resp, err := core.Client.WithContext(ctx).Get(s.referenceListURL, &core.RequestOptions{SkipCookies: true})
 	if err != nil {
 		return nil, err
 	}
@@ -17,13 +17,13 @@ type scriptQuebec struct {
 	core.LoggingScript
 }
 
-func (s *scriptQuebec) ListGauges() (result core.Gauges, err error) {
-	federal, err := s.getReferenceList()
+func (s *scriptQuebec) ListGauges(ctx context.Context) (result core.Gauges, err error) {
+	federal, err := s.getReferenceList(ctx)
 	var local []stationInfo
 	if err != nil {
 		return nil, err
 	}
-	codes, err := s.getCodes()
+	codes, err := s.getCodes(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +32,7 @@ func (s *scriptQuebec) ListGauges() (result core.Gauges, err error) {
 	resultsCh := make(chan stationInfo, len(codes))
 	numWorkers := min(5, len(codes))
 	for i := 0; i < numWorkers; i++ {
-		go s.stationWorker(jobsCh, resultsCh)
+		go s.stationWorker(ctx, jobsCh, resultsCh)
 	}
 	for _, code := range codes {
 		jobsCh <- code
@@ -77,20 +77,20 @@ func (s *scriptQuebec) ListGauges() (result core.Gauges, err error) {
 	return
 }
 
-func (s *scriptQuebec) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptQuebec) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	code, err := codes.Only()
+	code, err := spec.Codes.Only()
 	if err != nil {
 		errs <- err
 		return
 	}
-	s.getReadings(recv, errs, code)
+	s.getReadings(ctx, recv, errs, code)
 }
 
-func (s *scriptQuebec) stationWorker(codes <-chan string, results chan<- stationInfo) {
+func (s *scriptQuebec) stationWorker(ctx context.Context, codes <-chan string, results chan<- stationInfo) {
 	for code := range codes {
-		gauge, err := s.parsePage(code)
+		gauge, err := s.parsePage(ctx, code)
 		if err != nil {
 			s.GetLogger().WithFields(logrus.Fields{
 				"script":  s.name,
@@ -1,6 +1,7 @@
 package quebec
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -30,7 +31,7 @@ func TestQuebec_ListGauges(t *testing.T) {
 		readingsCSVFormat:  ts.URL + "/readings/%s.html",
 		readingsJSONFormat: ts.URL + "/readings_json/%s.json",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -89,7 +90,7 @@ func TestQuebec_Harvest_CSV(t *testing.T) {
 		readingsCSVFormat:  ts.URL + "/readings/%s.csv",
 		readingsJSONFormat: ts.URL + "/readings_json/%s.json",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"023402": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"023402": {}})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -119,7 +120,7 @@ func TestQuebec_Harvest_CSV(t *testing.T) {
 	}
 
 	// File encoding windows-1252
-	actual, err = core.HarvestSlice(&s, core.StringSet{"062701": {}}, 0)
+	actual, err = core.HarvestSlice(&s, core.StringSet{"062701": {}})
 	expected = core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -148,7 +149,7 @@ func TestQuebec_Harvest_CSV(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	}
 	// File encoding utf8
-	actual, err = core.HarvestSlice(&s, core.StringSet{"062702": {}}, 0)
+	actual, err = core.HarvestSlice(&s, core.StringSet{"062702": {}})
 	expected = core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -177,7 +178,7 @@ func TestQuebec_Harvest_CSV(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	}
 
-	actual, err = core.HarvestSlice(&s, core.StringSet{"061901": {}}, 0)
+	actual, err = core.HarvestSlice(&s, core.StringSet{"061901": {}})
 	expected = core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -219,7 +220,7 @@ func TestQuebec_Harvest_JSON(t *testing.T) {
 		readingsCSVFormat:  ts.URL + "/readings/%s.csv",
 		readingsJSONFormat: ts.URL + "/readings_json/%s.json",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"051305": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"051305": {}})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
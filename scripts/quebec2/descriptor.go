@@ -11,6 +11,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "quebec2",
 	Description: "Hydro Québec",
 	Mode:        core.AllAtOnce,
+	Host:        "www.hydroquebec.com",
 	DefaultOptions: func() interface{} {
 		return &optionsQuebec2{}
 	},
@@ -40,15 +40,15 @@ func (s *scriptQuebec2) parseLocation(site q2site) (*core.Location, error) {
 	return &core.Location{Latitude: lat, Longitude: lon}, nil
 }
 
-func (s *scriptQuebec2) fetchData() ([]q2site, []q2site, error) {
+func (s *scriptQuebec2) fetchData(ctx context.Context) ([]q2site, []q2site, error) {
 	g := new(errgroup.Group)
 	var sitez q2sites
 	var stationz q2stations
 	g.Go(func() error {
-		return q2client.GetAsJSON(s.urlBase+"Donnees_VUE_CENTRALES_ET_OUVRAGES.json", &sitez, nil)
+		return q2client.WithContext(ctx).GetAsJSON(s.urlBase+"Donnees_VUE_CENTRALES_ET_OUVRAGES.json", &sitez, nil)
 	})
 	g.Go(func() error {
-		return q2client.GetAsJSON(s.urlBase+"Donnees_VUE_STATIONS_ET_TARAGES.json", &stationz, nil)
+		return q2client.WithContext(ctx).GetAsJSON(s.urlBase+"Donnees_VUE_STATIONS_ET_TARAGES.json", &stationz, nil)
 	})
 	if err := g.Wait(); err != nil {
 		return nil, nil, err
@@ -195,8 +195,8 @@ func appendMeasurement(to map[time.Time]*core.Measurement, gaugeId core.GaugeID,
 	to[ts] = m
 }
 
-func (s *scriptQuebec2) ListGauges() (core.Gauges, error) {
-	sites, stations, err := s.fetchData()
+func (s *scriptQuebec2) ListGauges(ctx context.Context) (core.Gauges, error) {
+	sites, stations, err := s.fetchData(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -211,10 +211,10 @@ func (s *scriptQuebec2) ListGauges() (core.Gauges, error) {
 	return core.GaugeSinkToSlice(gaugesCh, errCh)
 }
 
-func (s *scriptQuebec2) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptQuebec2) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	sites, stations, err := s.fetchData()
+	sites, stations, err := s.fetchData(ctx)
 	if err != nil {
 		errs <- err
 		return
@@ -1,6 +1,7 @@
 package quebec2
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -22,7 +23,7 @@ func TestQuebec2_ListGauges(t *testing.T) {
 		name:    "quebec2",
 		urlBase: ts.URL + "/",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -122,7 +123,7 @@ func TestQuebec2_Harvest(t *testing.T) {
 		name:    "quebec2",
 		urlBase: ts.URL + "/",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 
 	expected := core.Measurements{
 		&core.Measurement{
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "riverzone",
 	Description: "riverzone.eu",
 	Mode:        core.AllAtOnce,
+	Host:        "api.riverzone.eu",
 	DefaultOptions: func() interface{} {
 		return &optionsRiverzone{}
 	},
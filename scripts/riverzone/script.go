@@ -30,7 +30,7 @@ func containsStr(s []string, str string) bool {
 	return false
 }
 
-func (s *scriptRiverzone) fetch(path string, dest interface{}) error {
+func (s *scriptRiverzone) fetch(ctx context.Context, path string, dest interface{}) error {
 	key := s.options.Key
 	if key == "" {
 		key = os.Getenv("RIVERZONE_KEY")
@@ -38,7 +38,7 @@ func (s *scriptRiverzone) fetch(path string, dest interface{}) error {
 	if key == "" {
 		return fmt.Errorf("riverzone api key not found")
 	}
-	err := core.Client.GetAsJSON(
+	err := core.Client.WithContext(ctx).GetAsJSON(
 		s.stationsEndpointURL+path,
 		&dest,
 		&core.RequestOptions{
@@ -52,20 +52,20 @@ func (s *scriptRiverzone) fetch(path string, dest interface{}) error {
 	return nil
 }
 
-func (s *scriptRiverzone) fetchStations() (*stationsResp, error) {
+func (s *scriptRiverzone) fetchStations(ctx context.Context) (*stationsResp, error) {
 	var response *stationsResp
-	err := s.fetch("", &response)
+	err := s.fetch(ctx, "", &response)
 	return response, err
 }
 
-func (s *scriptRiverzone) fetchReadings() (*readingsResp, error) {
+func (s *scriptRiverzone) fetchReadings(ctx context.Context) (*readingsResp, error) {
 	var response *readingsResp
-	err := s.fetch("/readings?from=60&to=60", &response)
+	err := s.fetch(ctx, "/readings?from=60&to=60", &response)
 	return response, err
 }
 
-func (s *scriptRiverzone) ListGauges() (core.Gauges, error) {
-	stations, err := s.fetchStations()
+func (s *scriptRiverzone) ListGauges(ctx context.Context) (core.Gauges, error) {
+	stations, err := s.fetchStations(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -104,10 +104,10 @@ func (s *scriptRiverzone) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptRiverzone) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptRiverzone) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	readings, err := s.fetchReadings()
+	readings, err := s.fetchReadings(ctx)
 	if err != nil {
 		errs <- err
 		return
@@ -1,6 +1,7 @@
 package riverzone
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -26,7 +27,7 @@ func TestRiverzone_Auth(t *testing.T) {
 		stationsEndpointURL: ts.URL,
 		options:             optionsRiverzone{Key: "__bad__"},
 	}
-	_, err := s.ListGauges()
+	_, err := s.ListGauges(context.Background())
 	assert.Error(t, err)
 }
 
@@ -38,7 +39,7 @@ func TestRiverzone_ListGauges(t *testing.T) {
 		stationsEndpointURL: ts.URL,
 		options:             optionsRiverzone{Key: testutils.TestAuthKey},
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -98,7 +99,7 @@ func TestRiverzone_Harvest(t *testing.T) {
 		stationsEndpointURL: ts.URL,
 		options:             optionsRiverzone{Key: testutils.TestAuthKey},
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "russia1",
 	Description: "Russia: Emercit",
 	Mode:        core.AllAtOnce,
+	Host:        "www.emercit.com",
 	DefaultOptions: func() interface{} {
 		return &optionsRussia1{}
 	},
@@ -1,12 +1,13 @@
 package russia1
 
 import (
+	"context"
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptRussia1) fetchList(gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
+func (s *scriptRussia1) fetchList(ctx context.Context, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
 	list := &russia1Features{}
-	err := core.Client.GetAsJSON(s.gaugesURL, list, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.gaugesURL, list, nil)
 	if err != nil {
 		errs <- err
 		return
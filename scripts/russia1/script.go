@@ -14,13 +14,13 @@ type scriptRussia1 struct {
 
 type optionsRussia1 struct{}
 
-func (s *scriptRussia1) ListGauges() (core.Gauges, error) {
+func (s *scriptRussia1) ListGauges(ctx context.Context) (core.Gauges, error) {
 	gaugesCh := make(chan *core.Gauge)
 	errCh := make(chan error)
 	go func() {
 		defer close(gaugesCh)
 		defer close(errCh)
-		s.fetchList(gaugesCh, nil, errCh)
+		s.fetchList(ctx, gaugesCh, nil, errCh)
 	}()
 	var gauges core.Gauges
 outer:
@@ -42,8 +42,8 @@ outer:
 	return gauges, nil
 }
 
-func (s *scriptRussia1) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptRussia1) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	s.fetchList(nil, recv, errs)
+	s.fetchList(ctx, nil, recv, errs)
 }
@@ -1,6 +1,7 @@
 package russia1
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -70,7 +71,7 @@ func TestRussia1_ListGauges(t *testing.T) {
 			Timezone: "Europe/Moscow",
 		},
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected, actual)
 	}
@@ -105,7 +106,7 @@ func TestRussia1_Harvest(t *testing.T) {
 			Level: nulltype.NullFloat64Of(-0.19361280441284),
 		},
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected, actual)
 	}
@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "sepa",
 	Description: "Scottish Environment Protection Agency",
 	Mode:        core.AllAtOnce,
+	Host:        "timeseries.sepa.org.uk",
 	DefaultOptions: func() interface{} {
 		return &optionsSepa{}
 	},
@@ -3,6 +3,7 @@ package sepa
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/mattn/go-nulltype"
 	"github.com/whitewater-guide/gorge/core"
@@ -11,6 +12,8 @@ import (
 type DataPoint struct {
 	Timestamp core.HTime           `json:"timestamp"`
 	Value     nulltype.NullFloat64 `json:"value"`
+	// Quality is KiWIS quality code, empty if it's not requested
+	Quality string `json:"quality"`
 }
 
 func (dp *DataPoint) UnmarshalJSON(data []byte) error {
@@ -19,8 +22,8 @@ func (dp *DataPoint) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if len(raw) != 2 {
-		return fmt.Errorf("data point must have exactly 2 elements, got %d", len(raw))
+	if len(raw) != 2 && len(raw) != 3 {
+		return fmt.Errorf("data point must have 2 or 3 elements, got %d", len(raw))
 	}
 
 	// Parse timestamp (first element)
@@ -49,6 +52,19 @@ func (dp *DataPoint) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("value must be a number or null, got %T", raw[1])
 	}
 
+	// Parse quality code (optional third element)
+	if len(raw) == 3 {
+		switch q := raw[2].(type) {
+		case float64:
+			dp.Quality = strconv.FormatFloat(q, 'f', -1, 64)
+		case string:
+			dp.Quality = q
+		case nil:
+		default:
+			return fmt.Errorf("quality code must be a number or string, got %T", raw[2])
+		}
+	}
+
 	return nil
 }
 
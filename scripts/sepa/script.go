@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/whitewater-guide/gorge/core"
 )
 
+// backfillBatchSize is number of stations which timeseries are requested at once during backfill
+const backfillBatchSize = 50
+
 type optionsSepa struct{}
 type scriptSepa struct {
 	name    string
@@ -17,8 +21,8 @@ type scriptSepa struct {
 	core.LoggingScript
 }
 
-func (s *scriptSepa) ListGauges() (result core.Gauges, err error) {
-	err = core.Client.StreamCSV(
+func (s *scriptSepa) ListGauges(ctx context.Context) (result core.Gauges, err error) {
+	err = core.Client.WithContext(ctx).StreamCSV(
 		s.listURL+"/SEPA_River_Levels_Web.csv",
 		func(row []string) error {
 			if row[3] == "---" {
@@ -41,15 +45,50 @@ func (s *scriptSepa) ListGauges() (result core.Gauges, err error) {
 	return
 }
 
-func (s *scriptSepa) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptSepa) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
-	var resp SEPAStationMeasurements
 	// This harvests levels only. To harvest flows, we need to make another reauest, because two wildcard ts_path parameters are not supported
-	if err := core.Client.GetAsJSON(fmt.Sprintf("%s?service=kisters&type=queryServices&datasource=0&request=getTimeseriesValues&returnfields=Timestamp,Value&metadata=true&md_returnfields=station_no,ts_unitsymbol,stationparameter_name&format=dajson&ts_path=1/*/SG/15m.Cmd", s.apiURL), &resp, nil); err != nil {
+	if err := s.getLevels(ctx, recv, "ts_path=1/*/SG/15m.Cmd"); err != nil {
 		errs <- err
-		return
+	}
+}
+
+// BackfillPage implements core.Backfiller interface
+func (s *scriptSepa) BackfillPage() time.Duration {
+	return 7 * 24 * time.Hour
+}
+
+// Backfill implements core.Backfiller interface
+func (s *scriptSepa) Backfill(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec, from, to time.Time) {
+	defer close(recv)
+	defer close(errs)
+
+	period := fmt.Sprintf("from=%s&to=%s", from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+	all := spec.Codes.Slice()
+	// request timeseries of multiple stations at once, but keep number of values in response reasonable
+	for i := 0; i < len(all); i += backfillBatchSize {
+		j := i + backfillBatchSize
+		if j > len(all) {
+			j = len(all)
+		}
+		paths := make([]string, j-i)
+		for k, code := range all[i:j] {
+			paths[k] = "1/" + code + "/SG/15m.Cmd"
+		}
+		if err := s.getLevels(ctx, recv, "ts_path="+strings.Join(paths, ",")+"&"+period); err != nil {
+			errs <- err
+			return
+		}
+	}
+}
+
+// getLevels requests level timeseries values, query selects timeseries and optionally time period
+func (s *scriptSepa) getLevels(ctx context.Context, recv chan<- *core.Measurement, query string) error {
+	var resp SEPAStationMeasurements
+	if err := core.Client.WithContext(ctx).GetAsJSON(fmt.Sprintf("%s?service=kisters&type=queryServices&datasource=0&request=getTimeseriesValues&returnfields=Timestamp,Value,Quality%%20Code&metadata=true&md_returnfields=station_no,ts_unitsymbol,stationparameter_name&format=dajson&%s", s.apiURL, query), &resp, nil); err != nil {
+		return err
 	}
 
 	for _, station := range resp {
@@ -64,7 +103,9 @@ func (s *scriptSepa) Harvest(ctx context.Context, recv chan<- *core.Measurement,
 				},
 				Timestamp: d.Timestamp,
 				Level:     d.Value,
+				Quality:   d.Quality,
 			}
 		}
 	}
+	return nil
 }
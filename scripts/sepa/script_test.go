@@ -1,6 +1,7 @@
 package sepa
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -28,7 +29,7 @@ func TestSepa_ListGauges(t *testing.T) {
 		listURL: ts.URL + "/list",
 		apiURL:  ts.URL + "/api",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -82,6 +83,7 @@ func TestSepa_Harvest(t *testing.T) {
 				Code:   "116011",
 			},
 			Level:     nulltype.NullFloat64Of(1.214),
+			Quality:   "254",
 			Timestamp: core.HTime{Time: time.Date(2025, time.July, 5, 11, 45, 0, 0, time.UTC)},
 		},
 		&core.Measurement{
@@ -90,11 +92,31 @@ func TestSepa_Harvest(t *testing.T) {
 				Code:   "10048",
 			},
 			Level:     nulltype.NullFloat64Of(0.433),
+			Quality:   "200",
 			Timestamp: core.HTime{Time: time.Date(2025, time.July, 5, 17, 45, 0, 0, time.UTC)},
 		},
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"10048": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"10048": {}})
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected, actual)
 	}
 }
+
+func TestSepa_Backfill(t *testing.T) {
+	ts := setupTestServer()
+	defer ts.Close()
+	s := scriptSepa{
+		name:    "sepa",
+		listURL: ts.URL + "/list",
+		apiURL:  ts.URL + "/api",
+	}
+	actual, err := core.BackfillSlice(
+		&s,
+		core.StringSet{"10048": {}},
+		time.Date(2025, time.July, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.July, 6, 0, 0, 0, 0, time.UTC),
+	)
+	if assert.NoError(t, err) {
+		assert.Len(t, actual, 2)
+	}
+}
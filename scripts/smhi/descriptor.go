@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "smhi",
 	Description: "https://www.smhi.se",
 	Mode:        core.AllAtOnce,
+	Host:        "opendata-download-hydroobs.smhi.se",
 	DefaultOptions: func() interface{} {
 		return &optionsSmhi{}
 	},
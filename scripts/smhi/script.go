@@ -15,9 +15,9 @@ type scriptSmhi struct {
 	core.LoggingScript
 }
 
-func (s *scriptSmhi) ListGauges() (core.Gauges, error) {
+func (s *scriptSmhi) ListGauges(ctx context.Context) (core.Gauges, error) {
 	var resp response
-	if err := core.Client.GetAsJSON(fmt.Sprintf("%s/api/version/latest/parameter/2/station-set/all/period/latest-hour/data.json", s.url), &resp, nil); err != nil {
+	if err := core.Client.WithContext(ctx).GetAsJSON(fmt.Sprintf("%s/api/version/latest/parameter/2/station-set/all/period/latest-hour/data.json", s.url), &resp, nil); err != nil {
 		return nil, err
 	}
 	var result []core.Gauge
@@ -43,12 +43,12 @@ func (s *scriptSmhi) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptSmhi) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptSmhi) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
 	var resp response
-	if err := core.Client.GetAsJSON(fmt.Sprintf("%s/api/version/latest/parameter/2/station-set/all/period/latest-hour/data.json", s.url), &resp, nil); err != nil {
+	if err := core.Client.WithContext(ctx).GetAsJSON(fmt.Sprintf("%s/api/version/latest/parameter/2/station-set/all/period/latest-hour/data.json", s.url), &resp, nil); err != nil {
 		return
 	}
 
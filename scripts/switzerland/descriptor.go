@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "switzerland",
 	Description: "Switzerland: Federal Office for the Environment",
 	Mode:        core.AllAtOnce,
+	Host:        "www.hydrodata.ch",
 	DefaultOptions: func() interface{} {
 		return &optionsSwitzerland{}
 	},
@@ -1,6 +1,7 @@
 package switzerland
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -11,8 +12,8 @@ const altOpen = "<th>Station elevation</th>"
 const altClose = " m a.s.l."
 const td = "<td class=\"text-right\">"
 
-func parseAltitude(baseURL string, gauge *core.Gauge) {
-	raw, err := core.Client.GetAsString(baseURL+gauge.Code+".html", nil)
+func parseAltitude(ctx context.Context, baseURL string, gauge *core.Gauge) {
+	raw, err := core.Client.WithContext(ctx).GetAsString(baseURL+gauge.Code+".html", nil)
 	if err != nil || raw == "" {
 		return
 	}
@@ -29,9 +30,9 @@ func parseAltitude(baseURL string, gauge *core.Gauge) {
 	}
 }
 
-func gaugePageWorker(baseURL string, gauges <-chan *core.Gauge, results chan<- struct{}) {
+func gaugePageWorker(ctx context.Context, baseURL string, gauges <-chan *core.Gauge, results chan<- struct{}) {
 	for gauge := range gauges {
-		parseAltitude(baseURL, gauge)
+		parseAltitude(ctx, baseURL, gauge)
 		results <- struct{}{}
 	}
 }
@@ -1,15 +1,17 @@
 package switzerland
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptSwitzerland) fetchStations() (*locations, error) {
+func (s *scriptSwitzerland) fetchStations(ctx context.Context) (*locations, error) {
 	usr, pwd := s.options.Username, s.options.Password
 	if usr == "" {
 		usr = os.Getenv("SWITZERLAND_USER")
@@ -20,7 +22,7 @@ func (s *scriptSwitzerland) fetchStations() (*locations, error) {
 	if usr == "" || pwd == "" {
 		return nil, errors.New("username and password required")
 	}
-	req, _ := http.NewRequest("GET", s.xmlURL, nil)
+	req, _ := http.NewRequestWithContext(ctx, "GET", s.xmlURL, nil)
 	req.SetBasicAuth(usr, pwd)
 	resp, err := core.Client.Do(req, nil)
 
@@ -48,8 +50,8 @@ func getLocation(station station) (*core.Location, error) {
 	return &core.Location{Longitude: x, Latitude: y}, nil
 }
 
-func getParameters(station *station) (flow *parameter, level *parameter) {
-	// there will be at most one param for flow, and at most one for flow
+func getParameters(station *station) (flow *parameter, level *parameter, temp *parameter) {
+	// there will be at most one param for flow, at most one for level and at most one for temperature
 	for _, param := range station.Parameter {
 		switch param.Name {
 		case "Abfluss m3/s", "Abfluss l/s":
@@ -58,6 +60,9 @@ func getParameters(station *station) (flow *parameter, level *parameter) {
 		case "Pegel m ü. M.", "Pegel m":
 			scoped := param
 			level = &scoped
+		case "Wassertemperatur":
+			scoped := param
+			temp = &scoped
 		}
 	}
 	return
@@ -69,7 +74,7 @@ func (s *scriptSwitzerland) stationToGauge(station *station) (*core.Gauge, error
 		name += " (" + station.WaterBodyType + ")"
 	}
 
-	flowP, levelP := getParameters(station)
+	flowP, levelP, tempP := getParameters(station)
 
 	loc, err := getLocation(*station)
 	if err != nil {
@@ -93,12 +98,15 @@ func (s *scriptSwitzerland) stationToGauge(station *station) (*core.Gauge, error
 	if levelP != nil {
 		gauge.LevelUnit = levelP.Unit
 	}
+	if tempP != nil {
+		gauge.ParamUnits = map[core.Parameter]string{core.Temperature: "degC"}
+	}
 
 	return gauge, nil
 }
 
 func (s *scriptSwitzerland) stationToMeasurement(station *station) *core.Measurement {
-	flowP, levelP := getParameters(station)
+	flowP, levelP, tempP := getParameters(station)
 	if levelP == nil && flowP == nil {
 		return nil
 	}
@@ -121,11 +129,16 @@ func (s *scriptSwitzerland) stationToMeasurement(station *station) *core.Measure
 		// it's safe to overwrite. Timestamps are equal for all the params
 		result.Timestamp = core.HTime{Time: levelP.Datetime.Time}
 	}
+	if tempP != nil && tempP.Value.Text != "NaN" {
+		if t, err := strconv.ParseFloat(tempP.Value.Text, 64); err == nil {
+			result.Params = core.Params{core.Temperature: t}
+		}
+	}
 	return result
 }
 
-func (s *scriptSwitzerland) parseXMLGauges() (result core.Gauges, err error) {
-	dataRoot, err := s.fetchStations()
+func (s *scriptSwitzerland) parseXMLGauges(ctx context.Context) (result core.Gauges, err error) {
+	dataRoot, err := s.fetchStations(ctx)
 	if err != nil {
 		return
 	}
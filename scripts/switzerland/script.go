@@ -19,8 +19,8 @@ type scriptSwitzerland struct {
 	core.LoggingScript
 }
 
-func (s *scriptSwitzerland) ListGauges() (core.Gauges, error) {
-	gauges, err := s.parseXMLGauges()
+func (s *scriptSwitzerland) ListGauges(ctx context.Context) (core.Gauges, error) {
+	gauges, err := s.parseXMLGauges(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +32,7 @@ func (s *scriptSwitzerland) ListGauges() (core.Gauges, error) {
 	resultsCh := make(chan struct{}, numGauges)
 
 	for w := 1; w <= numWorkers; w++ {
-		go gaugePageWorker(s.gaugePageURLBase, jobsCh, resultsCh)
+		go gaugePageWorker(ctx, s.gaugePageURLBase, jobsCh, resultsCh)
 	}
 	for i := 0; i < numGauges; i++ {
 		jobsCh <- &(gauges[i])
@@ -45,10 +45,10 @@ func (s *scriptSwitzerland) ListGauges() (core.Gauges, error) {
 	return gauges, nil
 }
 
-func (s *scriptSwitzerland) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptSwitzerland) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	dataRoot, err := s.fetchStations()
+	dataRoot, err := s.fetchStations(ctx)
 	if err != nil {
 		errs <- err
 		return
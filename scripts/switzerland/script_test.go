@@ -1,6 +1,7 @@
 package switzerland
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -37,7 +38,7 @@ func TestSwitzerland_BasicAuth(t *testing.T) {
 		gaugePageURLBase: ts.URL + "/",
 		options:          optionsSwitzerland{Username: "foo", Password: "bar"},
 	}
-	_, err := s.ListGauges()
+	_, err := s.ListGauges(context.Background())
 	assert.Error(t, err)
 }
 
@@ -50,7 +51,7 @@ func TestSwitzerland_ListGauges(t *testing.T) {
 		gaugePageURLBase: ts.URL + "/",
 		options:          optionsSwitzerland{Username: "user", Password: "password"},
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID:   core.GaugeID{Script: "switzerland", Code: "2004"},
@@ -69,22 +70,24 @@ func TestSwitzerland_ListGauges(t *testing.T) {
 			Timezone:  "Europe/Zurich",
 		},
 		core.Gauge{
-			GaugeID:   core.GaugeID{Script: "switzerland", Code: "2009"},
-			LevelUnit: "m",
-			FlowUnit:  "m3/s",
-			Name:      "Rhône - Porte du Scex",
-			URL:       "https://www.hydrodaten.admin.ch/en/2009.html",
-			Location:  &core.Location{Latitude: 46.34956, Longitude: 6.88861, Altitude: 377},
-			Timezone:  "Europe/Zurich",
+			GaugeID:    core.GaugeID{Script: "switzerland", Code: "2009"},
+			LevelUnit:  "m",
+			FlowUnit:   "m3/s",
+			Name:       "Rhône - Porte du Scex",
+			URL:        "https://www.hydrodaten.admin.ch/en/2009.html",
+			ParamUnits: map[core.Parameter]string{core.Temperature: "degC"},
+			Location:   &core.Location{Latitude: 46.34956, Longitude: 6.88861, Altitude: 377},
+			Timezone:   "Europe/Zurich",
 		},
 		core.Gauge{
-			GaugeID:   core.GaugeID{Script: "switzerland", Code: "2011"},
-			FlowUnit:  "m3/s",
-			LevelUnit: "m",
-			Name:      "Rhône - Sion",
-			URL:       "https://www.hydrodaten.admin.ch/en/2011.html",
-			Location:  &core.Location{Latitude: 46.21908, Longitude: 7.3579, Altitude: 484},
-			Timezone:  "Europe/Zurich",
+			GaugeID:    core.GaugeID{Script: "switzerland", Code: "2011"},
+			FlowUnit:   "m3/s",
+			LevelUnit:  "m",
+			Name:       "Rhône - Sion",
+			URL:        "https://www.hydrodaten.admin.ch/en/2011.html",
+			ParamUnits: map[core.Parameter]string{core.Temperature: "degC"},
+			Location:   &core.Location{Latitude: 46.21908, Longitude: 7.3579, Altitude: 484},
+			Timezone:   "Europe/Zurich",
 		},
 	}
 	if assert.NoError(t, err) {
@@ -100,7 +103,7 @@ func TestSwitzerland_Harvest(t *testing.T) {
 		gaugePageURLBase: ts.URL + "/",
 		options:          optionsSwitzerland{Username: "user", Password: "password"},
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"2007": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"2007": {}})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID:   core.GaugeID{Script: "switzerland", Code: "2004"},
@@ -117,12 +120,14 @@ func TestSwitzerland_Harvest(t *testing.T) {
 			Timestamp: core.HTime{Time: time.Date(2021, time.August, 23, 6, 30, 0, 0, time.UTC)},
 			Level:     nulltype.NullFloat64Of(375.548),
 			Flow:      nulltype.NullFloat64Of(222.330),
+			Params:    core.Params{core.Temperature: 10.56},
 		},
 		&core.Measurement{
 			GaugeID:   core.GaugeID{Script: "switzerland", Code: "2011"},
 			Timestamp: core.HTime{Time: time.Date(2021, time.August, 23, 6, 30, 0, 0, time.UTC)},
 			Flow:      nulltype.NullFloat64Of(117.658),
 			Level:     nulltype.NullFloat64Of(483.631),
+			Params:    core.Params{core.Temperature: 9.58},
 		},
 	}
 	if assert.NoError(t, err) {
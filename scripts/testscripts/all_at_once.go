@@ -3,6 +3,7 @@ package testscripts
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/whitewater-guide/gorge/core"
 )
@@ -22,7 +23,7 @@ type scriptAllAtOnce struct {
 	core.LoggingScript
 }
 
-func (s *scriptAllAtOnce) ListGauges() (core.Gauges, error) {
+func (s *scriptAllAtOnce) ListGauges(ctx context.Context) (core.Gauges, error) {
 	res := make([]core.Gauge, s.options.Gauges)
 	for i := 0; i < s.options.Gauges; i++ {
 		res[i] = core.GenerateRandGauge(s.name, i)
@@ -36,7 +37,7 @@ func (s *scriptAllAtOnce) ListGauges() (core.Gauges, error) {
 	return res, nil
 }
 
-func (s *scriptAllAtOnce) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptAllAtOnce) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
@@ -50,6 +51,28 @@ func (s *scriptAllAtOnce) Harvest(ctx context.Context, recv chan<- *core.Measure
 	}
 }
 
+func (s *scriptAllAtOnce) BackfillPage() time.Duration {
+	return 24 * time.Hour
+}
+
+// Backfill generates hourly measurements within given period
+func (s *scriptAllAtOnce) Backfill(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec, from, to time.Time) {
+	defer close(recv)
+	defer close(errs)
+
+	for i := 0; i < s.options.Gauges; i++ {
+		for t := from.Truncate(time.Hour); !t.After(to); t = t.Add(time.Hour) {
+			m := core.GenerateRandMeasurement(s.name, fmt.Sprintf("g%03d", i), s.options.Value, s.options.Min, s.options.Max)
+			m.Timestamp = core.HTime{Time: t}
+			select {
+			case recv <- &m:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
 var AllAtOnce = &core.ScriptDescriptor{
 	Name:        "all_at_once",
 	Description: "Test script for all at once harvesting mode",
@@ -27,7 +27,7 @@ type scriptBatched struct {
 	core.LoggingScript
 }
 
-func (s *scriptBatched) ListGauges() (core.Gauges, error) {
+func (s *scriptBatched) ListGauges(ctx context.Context) (core.Gauges, error) {
 	res := make([]core.Gauge, s.options.Gauges)
 	for i := 0; i < s.options.Gauges; i++ {
 		res[i] = core.GenerateRandGauge(s.name, i)
@@ -35,11 +35,11 @@ func (s *scriptBatched) ListGauges() (core.Gauges, error) {
 	return res, nil
 }
 
-func (s *scriptBatched) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptBatched) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
-	for code := range codes {
+	for code := range spec.Codes {
 		m := core.GenerateRandMeasurement(s.name, code, s.options.Value, s.options.Min, s.options.Max)
 		select {
 		case recv <- &m:
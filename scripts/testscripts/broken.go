@@ -13,11 +13,11 @@ type scriptBroken struct {
 	core.LoggingScript
 }
 
-func (s *scriptBroken) ListGauges() (core.Gauges, error) {
+func (s *scriptBroken) ListGauges(ctx context.Context) (core.Gauges, error) {
 	return nil, errors.New("this script is always broken")
 }
 
-func (s *scriptBroken) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptBroken) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
@@ -21,7 +21,7 @@ type scriptOneByOne struct {
 	core.LoggingScript
 }
 
-func (s *scriptOneByOne) ListGauges() (core.Gauges, error) {
+func (s *scriptOneByOne) ListGauges(ctx context.Context) (core.Gauges, error) {
 	res := make([]core.Gauge, s.options.Gauges)
 	for i := 0; i < s.options.Gauges; i++ {
 		res[i] = core.GenerateRandGauge(s.name, i)
@@ -29,11 +29,11 @@ func (s *scriptOneByOne) ListGauges() (core.Gauges, error) {
 	return res, nil
 }
 
-func (s *scriptOneByOne) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptOneByOne) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
 
-	code, err := codes.Only()
+	code, err := spec.Codes.Only()
 	if err != nil {
 		errs <- err
 		return
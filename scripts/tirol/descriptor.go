@@ -8,6 +8,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "tirol",
 	Description: "Tyrol Hydro Online",
 	Mode:        core.AllAtOnce,
+	Host:        "wiski.tirol.gv.at",
 	DefaultOptions: func() interface{} {
 		return &optionsTirol{}
 	},
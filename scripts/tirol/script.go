@@ -22,9 +22,9 @@ var csvOptions = core.CSVStreamOptions{
 	HeaderHeight: 1,
 }
 
-func (s *scriptTirol) ListGauges() (result core.Gauges, err error) {
+func (s *scriptTirol) ListGauges(ctx context.Context) (result core.Gauges, err error) {
 	byCode := make(map[string]core.Gauge)
-	err = core.Client.StreamCSV(
+	err = core.Client.WithContext(ctx).StreamCSV(
 		s.csvURL,
 		func(row []string) error {
 			raw := fromRow(row)
@@ -53,10 +53,10 @@ func (s *scriptTirol) ListGauges() (result core.Gauges, err error) {
 	return
 }
 
-func (s *scriptTirol) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptTirol) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	err := core.Client.StreamCSV(
+	err := core.Client.WithContext(ctx).StreamCSV(
 		s.csvURL,
 		func(row []string) error {
 			m, err := s.getMeasurement(fromRow(row))
@@ -64,9 +64,9 @@ func (s *scriptTirol) Harvest(ctx context.Context, recv chan<- *core.Measurement
 				s.GetLogger().Error(err)
 				return nil
 			}
-			_, ok := codes[m.Code]
+			_, ok := spec.Codes[m.Code]
 			// special value that indicates broken gauge
-			if (ok || len(codes) == 0) && m.Level.Float64Value() != -777.0 {
+			if (ok || len(spec.Codes) == 0) && m.Level.Float64Value() != -777.0 {
 				recv <- &m
 			}
 			return nil
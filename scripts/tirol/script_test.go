@@ -1,6 +1,7 @@
 package tirol
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -24,7 +25,7 @@ Wattens;201657;Wattenbach;W;2019-12-28T14:00:00+0100;-777;cm;550.86;245119.15;24
 	}))
 	defer ts.Close()
 	s := scriptTirol{name: "tirol", csvURL: ts.URL}
-	gauges, err := s.ListGauges()
+	gauges, err := s.ListGauges(context.Background())
 	if assert.NoError(t, err) {
 		assert.ElementsMatch(
 			t,
@@ -65,7 +66,7 @@ Wattens;201657;Wattenbach;W;2019-12-28T14:00:00+0100;-777;cm;550.86;245119.15;24
 	defer ts.Close()
 	loc, _ := time.LoadLocation("Europe/Vienna")
 	s := scriptTirol{name: "tirol", csvURL: ts.URL}
-	res, err := core.HarvestSlice(&s, core.StringSet{"201657": {}, "201658": {}}, 0)
+	res, err := core.HarvestSlice(&s, core.StringSet{"201657": {}, "201658": {}})
 	if a.NoError(err) && a.Len(res, 1) {
 		a.Equal(res[0].Script, "tirol")
 		a.Equal(res[0].Code, "201657")
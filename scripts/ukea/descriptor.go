@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "ukea",
 	Description: "UK Environment Agency",
 	Mode:        core.AllAtOnce,
+	Host:        "environment.data.gov.uk",
 	DefaultOptions: func() interface{} {
 		return &optionsUkea{}
 	},
@@ -1,6 +1,7 @@
 package ukea
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -55,10 +56,20 @@ func selectMeasures(measures []measure) (levelUnit string, flowUnit string) {
 	return
 }
 
-func (s *scriptUkea) fetchList() (core.Gauges, error) {
+// Temperature measures are air temperature, so only rainfall is harvested in addition to level and flow
+func selectParamUnits(measures []measure) map[core.Parameter]string {
+	for _, m := range measures {
+		if m.Parameter == "rainfall" {
+			return map[core.Parameter]string{core.Rainfall: m.UnitName}
+		}
+	}
+	return nil
+}
+
+func (s *scriptUkea) fetchList(ctx context.Context) (core.Gauges, error) {
 	gauges := core.Gauges{}
 	var data stationsList
-	err := core.Client.GetAsJSON(s.url+"/id/stations.json?_limit=10000", &data, nil)
+	err := core.Client.WithContext(ctx).GetAsJSON(s.url+"/id/stations.json?_limit=10000", &data, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +93,7 @@ func (s *scriptUkea) fetchList() (core.Gauges, error) {
 			Timezone: "Europe/London",
 		}
 		g.LevelUnit, g.FlowUnit = selectMeasures(st.Measures)
+		g.ParamUnits = selectParamUnits(st.Measures)
 		if g.LevelUnit != "" || g.FlowUnit != "" {
 			gauges = append(gauges, g)
 		}
@@ -1,6 +1,8 @@
 package ukea
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -13,11 +15,25 @@ type reading struct {
 	levelMid string
 	level    nulltype.NullFloat64
 	flow     nulltype.NullFloat64
+	rainfall nulltype.NullFloat64
 }
 
-func (s *scriptUkea) getReadings(recv chan<- *core.Measurement, errs chan<- error) {
-	readings := map[string]reading{}
-	err := core.Client.StreamCSV(s.url+"/data/readings.csv?latest&_limit=10000", func(row []string) error {
+// add sets reading's flow, level or rainfall from measure value
+// For level, level-stage-i-15_min measure is preferred
+func (r *reading) add(mid string, value nulltype.NullFloat64) {
+	if strings.HasPrefix(mid, "flow") {
+		r.flow = value
+	} else if strings.HasPrefix(mid, "rainfall") {
+		r.rainfall = value
+	} else if strings.HasPrefix(mid, "level") && !strings.HasPrefix(r.levelMid, "level-stage-i-15_min") {
+		r.levelMid = mid
+		r.level = value
+	}
+}
+
+// streamReadings streams readings csv and calls cb for every valid row
+func streamReadings(ctx context.Context, url string, cb func(t time.Time, mid, code string, value nulltype.NullFloat64)) error {
+	return core.Client.WithContext(ctx).StreamCSV(url, func(row []string) error {
 		t, err := time.ParseInLocation("2006-01-02T15:04:05Z", row[0], time.UTC)
 		if err != nil {
 			return nil
@@ -28,23 +44,25 @@ func (s *scriptUkea) getReadings(recv chan<- *core.Measurement, errs chan<- erro
 		if err != nil {
 			return nil
 		}
+		cb(t, mid, code, value)
+		return nil
+	}, core.CSVStreamOptions{
+		HeaderHeight: 1,
+		NumColumns:   3,
+	})
+}
+
+func (s *scriptUkea) getReadings(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error) {
+	readings := map[string]reading{}
+	err := streamReadings(ctx, s.url+"/data/readings.csv?latest&_limit=10000", func(t time.Time, mid, code string, value nulltype.NullFloat64) {
 		r, ok := readings[code]
 		if !ok {
 			r = reading{
 				time: core.HTime{Time: t},
 			}
 		}
-		if strings.HasPrefix(mid, "flow") {
-			r.flow = value
-		} else if strings.HasPrefix(mid, "level") && !strings.HasPrefix(r.levelMid, "level-stage-i-15_min") {
-			r.levelMid = mid
-			r.level = value
-		}
+		r.add(mid, value)
 		readings[code] = r
-		return nil
-	}, core.CSVStreamOptions{
-		HeaderHeight: 1,
-		NumColumns:   3,
 	})
 	if err != nil {
 		errs <- err
@@ -52,16 +70,54 @@ func (s *scriptUkea) getReadings(recv chan<- *core.Measurement, errs chan<- erro
 	}
 
 	for k, v := range readings {
-		if v.level.Valid() || v.flow.Valid() {
-			recv <- &core.Measurement{
-				GaugeID: core.GaugeID{
-					Script: s.name,
-					Code:   k,
-				},
-				Timestamp: v.time,
-				Level:     v.level,
-				Flow:      v.flow,
+		s.send(recv, k, v)
+	}
+}
+
+// getHistoricalReadings gets all readings of one station within given period
+// Upstream only accepts dates, so readings outside of period can be returned
+func (s *scriptUkea) getHistoricalReadings(ctx context.Context, recv chan<- *core.Measurement, code string, from, to time.Time) error {
+	readings := map[int64]reading{}
+	url := fmt.Sprintf(
+		"%s/id/stations/%s/readings.csv?startdate=%s&enddate=%s&_sorted&_limit=10000",
+		s.url,
+		code,
+		from.UTC().Format("2006-01-02"),
+		to.UTC().Format("2006-01-02"),
+	)
+	err := streamReadings(ctx, url, func(t time.Time, mid, _ string, value nulltype.NullFloat64) {
+		r, ok := readings[t.Unix()]
+		if !ok {
+			r = reading{
+				time: core.HTime{Time: t},
 			}
 		}
+		r.add(mid, value)
+		readings[t.Unix()] = r
+	})
+	if err != nil {
+		return err
+	}
+	for _, v := range readings {
+		s.send(recv, code, v)
+	}
+	return nil
+}
+
+func (s *scriptUkea) send(recv chan<- *core.Measurement, code string, r reading) {
+	if r.level.Valid() || r.flow.Valid() || r.rainfall.Valid() {
+		m := &core.Measurement{
+			GaugeID: core.GaugeID{
+				Script: s.name,
+				Code:   code,
+			},
+			Timestamp: r.time,
+			Level:     r.level,
+			Flow:      r.flow,
+		}
+		if r.rainfall.Valid() {
+			m.Params = core.Params{core.Rainfall: r.rainfall.Float64Value()}
+		}
+		recv <- m
 	}
 }
@@ -2,6 +2,7 @@ package ukea
 
 import (
 	"context"
+	"time"
 
 	"github.com/whitewater-guide/gorge/core"
 )
@@ -16,12 +17,30 @@ type scriptUkea struct {
 	core.LoggingScript
 }
 
-func (s *scriptUkea) ListGauges() (core.Gauges, error) {
-	return s.fetchList()
+func (s *scriptUkea) ListGauges(ctx context.Context) (core.Gauges, error) {
+	return s.fetchList(ctx)
 }
 
-func (s *scriptUkea) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptUkea) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	s.getReadings(recv, errs)
+	s.getReadings(ctx, recv, errs)
+}
+
+// BackfillPage implements core.Backfiller interface
+func (s *scriptUkea) BackfillPage() time.Duration {
+	return 7 * 24 * time.Hour
+}
+
+// Backfill implements core.Backfiller interface
+// Upstream returns history of one station per request
+func (s *scriptUkea) Backfill(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec, from, to time.Time) {
+	defer close(recv)
+	defer close(errs)
+	for _, code := range spec.Codes.Slice() {
+		if err := s.getHistoricalReadings(ctx, recv, code, from, to); err != nil {
+			errs <- core.WrapErr(err, "failed to get historical readings").With("code", code)
+			return
+		}
+	}
 }
@@ -1,6 +1,7 @@
 package ukea
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -22,7 +23,7 @@ func TestUkea_ListGauges(t *testing.T) {
 		name: "ukea",
 		url:  ts.URL,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -57,8 +58,9 @@ func TestUkea_ListGauges(t *testing.T) {
 				Script: "ukea",
 				Code:   "F1906",
 			},
-			LevelUnit: "m",
-			FlowUnit:  "m3/s",
+			LevelUnit:  "m",
+			FlowUnit:   "m3/s",
+			ParamUnits: map[core.Parameter]string{core.Rainfall: "mm"},
 			Location: &core.Location{
 				Latitude:  54.08070,
 				Longitude: -2.02477,
@@ -86,12 +88,12 @@ func TestUkea_ListGauges(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	}
 	s.rloi = rloiWith
-	actual, err = s.ListGauges()
+	actual, err = s.ListGauges(context.Background())
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected[1:], actual)
 	}
 	s.rloi = rloiWithout
-	actual, err = s.ListGauges()
+	actual, err = s.ListGauges(context.Background())
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected[0:1], actual)
 	}
@@ -104,7 +106,7 @@ func TestUkea_Harvest(t *testing.T) {
 		name: "ukea",
 		url:  ts.URL,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -128,6 +130,17 @@ func TestUkea_Harvest(t *testing.T) {
 			},
 			Level: nulltype.NullFloat64Of(41.03),
 		},
+		// rainfall stations are not listed, their measurements are filtered out on next stages too
+		&core.Measurement{
+			GaugeID: core.GaugeID{
+				Script: "ukea",
+				Code:   "E1320",
+			},
+			Timestamp: core.HTime{
+				Time: time.Date(2020, time.May, 7, 9, 15, 0, 0, time.UTC),
+			},
+			Params: core.Params{core.Rainfall: 0},
+		},
 		&core.Measurement{
 			GaugeID: core.GaugeID{
 				Script: "ukea",
@@ -136,8 +149,49 @@ func TestUkea_Harvest(t *testing.T) {
 			Timestamp: core.HTime{
 				Time: time.Date(2020, time.May, 30, 4, 30, 0, 0, time.UTC),
 			},
-			Level: nulltype.NullFloat64Of(0.166),
-			Flow:  nulltype.NullFloat64Of(0.261),
+			Level:  nulltype.NullFloat64Of(0.166),
+			Flow:   nulltype.NullFloat64Of(0.261),
+			Params: core.Params{core.Rainfall: 0.2},
+		},
+	}
+	if assert.NoError(t, err) {
+		assert.ElementsMatch(t, expected, actual)
+	}
+}
+
+func TestUkea_Backfill(t *testing.T) {
+	ts := setupTestServer()
+	defer ts.Close()
+	s := scriptUkea{
+		name: "ukea",
+		url:  ts.URL,
+	}
+	actual, err := core.BackfillSlice(
+		&s,
+		core.StringSet{"2432TH": {}},
+		time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, time.May, 2, 0, 0, 0, 0, time.UTC),
+	)
+	expected := core.Measurements{
+		&core.Measurement{
+			GaugeID: core.GaugeID{
+				Script: "ukea",
+				Code:   "2432TH",
+			},
+			Timestamp: core.HTime{
+				Time: time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Level: nulltype.NullFloat64Of(0.925),
+		},
+		&core.Measurement{
+			GaugeID: core.GaugeID{
+				Script: "ukea",
+				Code:   "2432TH",
+			},
+			Timestamp: core.HTime{
+				Time: time.Date(2020, time.May, 1, 0, 15, 0, 0, time.UTC),
+			},
+			Level: nulltype.NullFloat64Of(0.924),
 		},
 	}
 	if assert.NoError(t, err) {
@@ -9,6 +9,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "ukraine",
 	Description: "Ukrainian Hydrometeorological Center",
 	Mode:        core.AllAtOnce,
+	Host:        "hydro.meteo.gov.ua",
 	DefaultOptions: func() interface{} {
 		return &optionsUkraine{}
 	},
@@ -1,6 +1,7 @@
 package ukraine
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"regexp"
@@ -41,8 +42,8 @@ var rDateLevel = regexp.MustCompile(`на (\d+ год. \d+\.\d+\.\d+): (-?\d+)с
 var rName = regexp.MustCompile(`Річка <b>(.+)</b><br/>`)
 var rPost = regexp.MustCompile(`Пост <b-->(.+)<br/>`)
 
-func (s *scriptUkraine) getAllRivers() (map[string]riverData, error) {
-	doc, err := client.GetAsDoc(s.urlDaily+"/kml_hydro_warn.kml", nil)
+func (s *scriptUkraine) getAllRivers(ctx context.Context) (map[string]riverData, error) {
+	doc, err := client.WithContext(ctx).GetAsDoc(s.urlDaily+"/kml_hydro_warn.kml", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +101,7 @@ func (s *scriptUkraine) getAllRivers() (map[string]riverData, error) {
 	return rivers, nil
 }
 
-func (s *scriptUkraine) harvest(measurements chan<- *core.Measurement, errs chan<- error) {
+func (s *scriptUkraine) harvest(ctx context.Context, measurements chan<- *core.Measurement, errs chan<- error) {
 	var wg sync.WaitGroup
 	waitHourlyData := map[string]bool{}
 	for _, code := range s.station2code {
@@ -115,7 +116,7 @@ func (s *scriptUkraine) harvest(measurements chan<- *core.Measurement, errs chan
 	wg.Go(func() {
 		defer close(riversReady)
 		var err error
-		rivers, err = s.getAllRivers()
+		rivers, err = s.getAllRivers(ctx)
 		if err != nil {
 			errs <- err
 			return
@@ -130,7 +131,7 @@ func (s *scriptUkraine) harvest(measurements chan<- *core.Measurement, errs chan
 	//load&save hourly-updated measurements
 	for station, code := range s.station2code {
 		wg.Go(func() {
-			ts := s.harvestSingle(code, station, measurements, errs)
+			ts := s.harvestSingle(ctx, code, station, measurements, errs)
 			//wait loading daily-updated measurements
 			//save as daily-updated if hourly-updated source 6 hours behind of daily-updated
 			<-riversReady
@@ -163,8 +164,8 @@ func (s *scriptUkraine) saveDailyMeasurements(measurements chan<- *core.Measurem
 	}
 }
 
-func (s *scriptUkraine) harvestSingle(code string, station string, measurements chan<- *core.Measurement, errs chan<- error) (lastTs time.Time) {
-	doc, err := s.getMeasurementsAsDoc(station)
+func (s *scriptUkraine) harvestSingle(ctx context.Context, code string, station string, measurements chan<- *core.Measurement, errs chan<- error) (lastTs time.Time) {
+	doc, err := s.getMeasurementsAsDoc(ctx, station)
 	if err != nil {
 		errs <- err
 		return
@@ -207,7 +208,7 @@ func (s *scriptUkraine) harvestSingle(code string, station string, measurements
 	return
 }
 
-func (s *scriptUkraine) getMeasurementsAsDoc(station string) (*goquery.Document, error) {
+func (s *scriptUkraine) getMeasurementsAsDoc(ctx context.Context, station string) (*goquery.Document, error) {
 	now := time.Now().In(s.timezone)
 	params := url.Values{}
 	params.Set("station", station)
@@ -217,7 +218,7 @@ func (s *scriptUkraine) getMeasurementsAsDoc(station string) (*goquery.Document,
 	if s.addStation2url {
 		dataUrl += "/" + station
 	}
-	resp, _, err := client.PostForm(dataUrl, params, nil)
+	resp, _, err := client.WithContext(ctx).PostForm(dataUrl, params, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -18,8 +18,8 @@ type scriptUkraine struct {
 	station2code   map[string]string
 }
 
-func (s *scriptUkraine) ListGauges() (core.Gauges, error) {
-	rivers, err := s.getAllRivers()
+func (s *scriptUkraine) ListGauges(ctx context.Context) (core.Gauges, error) {
+	rivers, err := s.getAllRivers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -30,8 +30,8 @@ func (s *scriptUkraine) ListGauges() (core.Gauges, error) {
 	return gauges, nil
 }
 
-func (s *scriptUkraine) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptUkraine) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	s.harvest(recv, errs)
+	s.harvest(ctx, recv, errs)
 }
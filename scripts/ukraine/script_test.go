@@ -1,6 +1,7 @@
 package ukraine
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -40,7 +41,7 @@ func TestUkraine_ListGauges(t *testing.T) {
 	s, cls := setupScript(nil)
 	defer cls()
 
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauge{
 		GaugeID: core.GaugeID{
 			Script: "ukraine",
@@ -65,7 +66,7 @@ func TestUkraine_Harvest(t *testing.T) {
 	s, cls := setupScript(nil)
 	defer cls()
 
-	actual, err := core.HarvestSlice(s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(s, core.StringSet{})
 	expected1 := &core.Measurement{
 		GaugeID: core.GaugeID{
 			Script: "ukraine",
@@ -126,7 +127,7 @@ func TestUkraine_Harvest(t *testing.T) {
 
 	s, cls = setupScript(map[string]string{"/kml_hydro_warn.kml": "kml_hydro_warn_empty.kml"})
 	defer cls()
-	actual, err = core.HarvestSlice(s, core.StringSet{}, 0)
+	actual, err = core.HarvestSlice(s, core.StringSet{})
 	if assert.NoError(t, err) {
 		assert.NotContains(t, actual, expected1)
 		assert.NotContains(t, actual, expected2)
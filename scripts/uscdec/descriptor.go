@@ -8,6 +8,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "uscdec",
 	Description: "U.S. California Data Exchange Center",
 	Mode:        core.AllAtOnce,
+	Host:        "cdec.water.ca.gov",
 	DefaultOptions: func() interface{} {
 		return &optionsUSCDEC{}
 	},
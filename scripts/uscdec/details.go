@@ -12,7 +12,7 @@ import (
 )
 
 func (s *scriptUSCDEC) parseDetails(ctx context.Context, code string) (*core.Gauge, error) {
-	doc, err := core.Client.GetAsDoc(fmt.Sprintf("%s/staMeta?station_id=%s", s.url, code), nil)
+	doc, err := core.Client.WithContext(ctx).GetAsDoc(fmt.Sprintf("%s/staMeta?station_id=%s", s.url, code), nil)
 	if err != nil {
 		return nil, err
 	}
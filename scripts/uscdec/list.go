@@ -1,6 +1,7 @@
 package uscdec
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -14,9 +15,11 @@ import (
 
 var tz, _ = time.LoadLocation("US/Pacific")
 
-func (s *scriptUSCDEC) parseList() (core.Measurements, error) {
-	client := core.NewClient(core.ClientOptions{Timeout: 120}, s.GetLogger())
-	doc, err := client.GetAsDoc(fmt.Sprintf("%s/getAll?sens_num=20", s.url), nil)
+func (s *scriptUSCDEC) parseList(ctx context.Context) (core.Measurements, error) {
+	// this page is very slow
+	ctx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	defer cancel()
+	doc, err := core.Client.WithContext(ctx).GetAsDoc(fmt.Sprintf("%s/getAll?sens_num=20", s.url), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -23,8 +23,8 @@ type scriptUSCDEC struct {
 //go:embed cache.json
 var gaugesCacheJson embed.FS
 
-func (s *scriptUSCDEC) ListGauges() (core.Gauges, error) {
-	msmnt, err := s.parseList()
+func (s *scriptUSCDEC) ListGauges(ctx context.Context) (core.Gauges, error) {
+	msmnt, err := s.parseList(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +45,7 @@ func (s *scriptUSCDEC) ListGauges() (core.Gauges, error) {
 	for _, m := range msmnt {
 		if _, ok := cachedCodes[m.Code]; ok {
 			continue
-		} else if g, err := s.parseDetails(context.Background(), m.Code); err != nil {
+		} else if g, err := s.parseDetails(ctx, m.Code); err != nil {
 			s.GetLogger().Warn(err)
 		} else if g != nil {
 			cachedCodes[g.Code] = *g
@@ -55,10 +55,10 @@ func (s *scriptUSCDEC) ListGauges() (core.Gauges, error) {
 	return slices.Collect(maps.Values(cachedCodes)), nil
 }
 
-func (s *scriptUSCDEC) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptUSCDEC) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	if msmnts, err := s.parseList(); err != nil {
+	if msmnts, err := s.parseList(ctx); err != nil {
 		errs <- err
 	} else {
 		for _, m := range msmnts {
@@ -74,7 +74,7 @@ func TestUscdec_Harvest(t *testing.T) {
 		name: "uscdec",
 		url:  ts.URL,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected1 := &core.Measurement{
 		GaugeID: core.GaugeID{
 			Script: "uscdec",
@@ -11,6 +11,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "usgs",
 	Description: "U.S. Geological Survey National Water Information System",
 	Mode:        core.Batched,
+	Host:        "waterservices.usgs.gov",
 	DefaultOptions: func() interface{} {
 		return &optionsUSGS{
 			BatchSize: 200, // has to be tuned in production not to hit URL limits
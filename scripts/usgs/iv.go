@@ -1,28 +1,33 @@
 package usgs
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/mattn/go-nulltype"
 
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptUSGS) listInstantaneousValues(codes string, recv chan<- *core.Measurement, errs chan<- error) {
+// listInstantaneousValues requests instantaneous values for given codes, period is either modifiedSince or startDT and endDT query parameters
+// Values that are ignored by gauge options are dropped
+func (s *scriptUSGS) listInstantaneousValues(ctx context.Context, codes string, period string, gaugeOptions core.GaugeOptions, recv chan<- *core.Measurement, errs chan<- error) {
 	var root ivRoot
-	url := fmt.Sprintf("%s/iv/?format=json&sites=%s&modifiedSince=PT1H&parameterCd=%s,%s&siteType=ST&siteStatus=active", s.url, codes, paramFlow, paramLevel)
-	err := core.Client.GetAsJSON(url, &root, nil)
+	url := fmt.Sprintf("%s/iv/?format=json&sites=%s&%s&parameterCd=%s,%s,%s&siteType=ST&siteStatus=active", s.url, codes, period, paramFlow, paramLevel, paramTemperature)
+	err := core.Client.WithContext(ctx).GetAsJSON(url, &root, nil)
 	if err != nil {
 		errs <- err
 		return
 	}
-	// Flow and level for same station and timestamp will be present as 2 separate items
+	// Flow, level and water temperature for same station and timestamp will be present as separate items
 	byCodeAndTime := map[string]map[int64]core.Measurement{}
 	for _, ts := range root.Value.TimeSeries {
 		// code := ts.SourceInfo.SiteCode[0].AgencyCode + ":" + ts.SourceInfo.SiteCode[0].Value
 		code := ts.SourceInfo.SiteCode[0].Value
-		isFlow := ts.Variable.VariableCode[0].Value == paramFlow
+		parameterCd := ts.Variable.VariableCode[0].Value
 		noDataValue := ts.Variable.NoDataValue
 		for _, v := range ts.Values {
 			if len(v.Value) == 0 || v.Value[0].Value == "" {
@@ -52,19 +57,53 @@ func (s *scriptUSGS) listInstantaneousValues(codes string, recv chan<- *core.Mea
 					},
 				}
 			}
-			if isFlow {
+			switch parameterCd {
+			case paramFlow:
 				m.Flow = nulltype.NullFloat64Of(vf)
-			} else {
+			case paramLevel:
 				m.Level = nulltype.NullFloat64Of(vf)
+			case paramTemperature:
+				m.Params = core.Params{core.Temperature: vf}
+			default:
+				continue
 			}
+			addQualifiers(&m, v.Value[0].Qualifiers)
 			byTime[when.Unix()] = m
 			byCodeAndTime[code] = byTime
 		}
 	}
-	for _, byTime := range byCodeAndTime {
+	for code, byTime := range byCodeAndTime {
+		opts, _ := gaugeOptions[code].(*optionsUSGS)
 		for _, m := range byTime {
 			mm := m
+			if opts != nil && !opts.apply(&mm) {
+				continue
+			}
 			recv <- &mm
 		}
 	}
 }
+
+// addQualifiers sets quality and provisional flag of measurement from USGS data qualifiers
+// "P" means provisional and "A" means approved data, other qualifiers, e.g. "e" (estimated), are kept as quality
+// Flow and level can have different qualifiers, in this case they're merged
+func addQualifiers(m *core.Measurement, qualifiers []string) {
+	quality := core.StringSet{}
+	for _, q := range strings.Split(m.Quality, ",") {
+		if q != "" {
+			quality[q] = struct{}{}
+		}
+	}
+	for _, q := range qualifiers {
+		switch q {
+		case "P":
+			m.Provisional = true
+		case "A":
+		default:
+			quality[q] = struct{}{}
+		}
+	}
+	codes := quality.Slice()
+	sort.Strings(codes)
+	m.Quality = strings.Join(codes, ",")
+}
@@ -2,14 +2,20 @@ package usgs
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"strings"
+	"time"
 
+	"github.com/mattn/go-nulltype"
 	"github.com/whitewater-guide/gorge/core"
 )
 
 type optionsUSGS struct {
-	StateCD   string `desc:"State code"`
-	BatchSize int    `desc:"Number of gauges in batch"`
+	StateCD     string `desc:"State code"`
+	BatchSize   int    `desc:"Number of gauges in batch"`
+	IgnoreLevel bool   `desc:"Do not harvest water level, usually set for single gauge"`
+	IgnoreFlow  bool   `desc:"Do not harvest discharge, usually set for single gauge"`
 }
 
 // GetBatchSize Implements core.BatchableOptions interface
@@ -17,6 +23,22 @@ func (o optionsUSGS) GetBatchSize() int {
 	return o.BatchSize
 }
 
+// HonoursGaugeOptions Implements core.GaugeOptionsBatchable interface
+func (o optionsUSGS) HonoursGaugeOptions() bool {
+	return true
+}
+
+// apply resets values that gauge does not harvest. Returns false if measurement has no values left
+func (o *optionsUSGS) apply(m *core.Measurement) bool {
+	if o.IgnoreLevel {
+		m.Level = nulltype.NullFloat64{}
+	}
+	if o.IgnoreFlow {
+		m.Flow = nulltype.NullFloat64{}
+	}
+	return m.HasValues()
+}
+
 type scriptUSGS struct {
 	name    string
 	url     string
@@ -24,16 +46,13 @@ type scriptUSGS struct {
 	core.LoggingScript
 }
 
-func (s *scriptUSGS) ListGauges() (core.Gauges, error) {
+func (s *scriptUSGS) ListGauges(ctx context.Context) (core.Gauges, error) {
 	gMap := map[string]core.Gauge{}
-	// fetch twice to correctly set level and flow units
-	err := s.listStations(false, gMap)
-	if err != nil {
-		return nil, err
-	}
-	err = s.listStations(true, gMap)
-	if err != nil {
-		return nil, err
+	// fetch once per parameter to correctly set level, flow and water temperature units
+	for _, parameterCd := range []string{paramLevel, paramFlow, paramTemperature} {
+		if err := s.listStations(ctx, parameterCd, gMap); err != nil {
+			return nil, err
+		}
 	}
 	result := make([]core.Gauge, len(gMap))
 	i := 0
@@ -45,19 +64,45 @@ func (s *scriptUSGS) ListGauges() (core.Gauges, error) {
 	return result, nil
 }
 
-func (s *scriptUSGS) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptUSGS) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
+	s.harvestChunks(ctx, recv, errs, spec, modifiedSince(spec, time.Now()))
+}
+
+// modifiedSince returns period to request from upstream: since oldest cached measurement of harvested sites, but not longer than 1 hour
+func modifiedSince(spec core.HarvestSpec, now time.Time) string {
+	window := spec.Since.Window(spec.Codes, now, time.Hour)
+	if window >= time.Hour {
+		return "modifiedSince=PT1H"
+	}
+	return fmt.Sprintf("modifiedSince=PT%dM", int(math.Max(1, math.Ceil(window.Minutes()))))
+}
+
+// BackfillPage implements core.Backfiller interface
+func (s *scriptUSGS) BackfillPage() time.Duration {
+	return 7 * 24 * time.Hour
+}
+
+// Backfill implements core.Backfiller interface
+func (s *scriptUSGS) Backfill(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec, from, to time.Time) {
+	defer close(recv)
+	defer close(errs)
+	period := fmt.Sprintf("startDT=%s&endDT=%s", from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+	s.harvestChunks(ctx, recv, errs, spec, period)
+}
+
+func (s *scriptUSGS) harvestChunks(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec, period string) {
 	codez := []string{}
 	// send in chunks of 100
-	for code := range codes {
+	for code := range spec.Codes {
 		codez = append(codez, code)
 		if len(codez) >= 100 {
-			s.listInstantaneousValues(strings.Join(codez, ","), recv, errs)
+			s.listInstantaneousValues(ctx, strings.Join(codez, ","), period, spec.GaugeOptions, recv, errs)
 			codez = []string{}
 		}
 	}
 	if len(codez) > 0 {
-		s.listInstantaneousValues(strings.Join(codez, ","), recv, errs)
+		s.listInstantaneousValues(ctx, strings.Join(codez, ","), period, spec.GaugeOptions, recv, errs)
 	}
 }
@@ -1,6 +1,7 @@
 package usgs
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -26,17 +27,18 @@ func TestUSGS_ListGauges(t *testing.T) {
 		url:     ts.URL,
 		stateCd: "wa",
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
 				Script: "usgs",
 				Code:   "12010000",
 			},
-			FlowUnit:  "ft3/s",
-			LevelUnit: "ft",
-			Name:      "NASELLE RIVER NEAR NASELLE, WA",
-			URL:       "https://waterdata.usgs.gov/nwis/inventory?agency_code=USGS&site_no=12010000",
+			FlowUnit:   "ft3/s",
+			LevelUnit:  "ft",
+			ParamUnits: map[core.Parameter]string{core.Temperature: "degC"},
+			Name:       "NASELLE RIVER NEAR NASELLE, WA",
+			URL:        "https://waterdata.usgs.gov/nwis/inventory?agency_code=USGS&site_no=12010000",
 			Location: &core.Location{
 				Latitude:  46.37399,
 				Longitude: -123.74348,
@@ -88,7 +90,7 @@ func TestUSGS_Harvest(t *testing.T) {
 		url:     ts.URL,
 		stateCd: "wa",
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{"12010000": {}, "12025100": {}}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{"12010000": {}, "12025100": {}})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -98,11 +100,71 @@ func TestUSGS_Harvest(t *testing.T) {
 			Timestamp: core.HTime{
 				Time: time.Date(2020, time.May, 14, 14, 30, 0, 0, time.UTC),
 			},
-			Flow:  nulltype.NullFloat64Of(316),
-			Level: nulltype.NullFloat64Of(5.26),
+			Flow:        nulltype.NullFloat64Of(316),
+			Level:       nulltype.NullFloat64Of(5.26),
+			Params:      core.Params{core.Temperature: 11.2},
+			Provisional: true,
 		},
 	}
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected, actual)
 	}
 }
+
+func TestUSGS_HarvestGaugeOptions(t *testing.T) {
+	ts := setupTestServer()
+	defer ts.Close()
+	s := scriptUSGS{
+		name:    "usgs",
+		url:     ts.URL,
+		stateCd: "wa",
+	}
+	in := make(chan *core.Measurement)
+	errCh := make(chan error, 1)
+	out := core.SinkToSlice(context.Background(), in)
+	s.Harvest(context.Background(), in, errCh, core.HarvestSpec{
+		Codes:        core.StringSet{"12010000": {}, "12025100": {}},
+		GaugeOptions: core.GaugeOptions{"12010000": &optionsUSGS{IgnoreLevel: true}},
+	})
+	actual := <-out
+	if assert.NoError(t, <-errCh) && assert.Len(t, actual, 1) {
+		assert.Equal(t, nulltype.NullFloat64Of(316), actual[0].Flow)
+		assert.False(t, actual[0].Level.Valid())
+	}
+}
+
+func TestUSGS_Backfill(t *testing.T) {
+	ts := setupTestServer()
+	defer ts.Close()
+	s := scriptUSGS{
+		name:    "usgs",
+		url:     ts.URL,
+		stateCd: "wa",
+	}
+	actual, err := core.BackfillSlice(
+		&s,
+		core.StringSet{"12010000": {}},
+		time.Date(2020, time.May, 14, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, time.May, 15, 0, 0, 0, 0, time.UTC),
+	)
+	if assert.NoError(t, err) && assert.Len(t, actual, 1) {
+		assert.Equal(t, time.Date(2020, time.May, 14, 14, 30, 0, 0, time.UTC), actual[0].Timestamp.Time)
+	}
+}
+
+func TestUSGS_ModifiedSince(t *testing.T) {
+	now := time.Date(2020, time.May, 14, 15, 0, 0, 0, time.UTC)
+	codes := core.StringSet{"12010000": {}, "12025100": {}}
+	assert.Equal(t, "modifiedSince=PT1H", modifiedSince(core.HarvestSpec{Codes: codes}, now))
+
+	spec := core.HarvestSpec{Codes: codes, Since: core.SinceMap{
+		"12010000": now.Add(-15 * time.Minute).Unix(),
+		"12025100": now.Add(-90 * time.Second).Unix(),
+	}}
+	assert.Equal(t, "modifiedSince=PT15M", modifiedSince(spec, now))
+	spec.Since = core.SinceMap{
+		"12010000": now.Add(-3 * time.Hour).Unix(),
+		"12025100": now.Unix(),
+	}
+	assert.Equal(t, "modifiedSince=PT1H", modifiedSince(spec, now))
+}
@@ -1,6 +1,7 @@
 package usgs
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -8,24 +9,19 @@ import (
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptUSGS) listStations(flow bool, gauges map[string]core.Gauge) error {
-	// Select parameter https://help.waterdata.usgs.gov/parameter_cd?group_cd=PHY
-	parameterCd, levelUnit, flowUnit := paramLevel, "ft", "" // Gage height, feet
-	if flow {
-		parameterCd, levelUnit, flowUnit = paramFlow, "", "ft3/s" // Discharge, cubic feet per second
-	}
-	return core.Client.StreamCSV(
+// listStations lists sites that measure given parameter https://help.waterdata.usgs.gov/parameter_cd?group_cd=PHY
+// and sets units of this parameter
+func (s *scriptUSGS) listStations(ctx context.Context, parameterCd string, gauges map[string]core.Gauge) error {
+	return core.Client.WithContext(ctx).StreamCSV(
 		fmt.Sprintf("%s/site/?format=rdb&stateCd=%s&siteType=ST&parameterCd=%s&siteStatus=all&hasDataTypeCd=iv", s.url, s.stateCd, parameterCd),
 		func(row []string) error {
 			g, ok := gauges[row[1]]
 
-			if ok {
-				if flow {
-					g.FlowUnit = flowUnit
-				} else {
-					g.LevelUnit = levelUnit
+			if !ok {
+				// sites that measure only water temperature are not gauges
+				if parameterCd == paramTemperature {
+					return nil
 				}
-			} else {
 				lat, err := strconv.ParseFloat(row[4], 64)
 				if err != nil {
 					return nil
@@ -44,10 +40,8 @@ func (s *scriptUSGS) listStations(flow bool, gauges map[string]core.Gauge) error
 						Script: s.name,
 						Code:   row[1],
 					},
-					Name:      row[2],
-					URL:       fmt.Sprintf("https://waterdata.usgs.gov/nwis/inventory?agency_code=%s&site_no=%s", row[0], row[1]),
-					LevelUnit: levelUnit,
-					FlowUnit:  flowUnit,
+					Name: row[2],
+					URL:  fmt.Sprintf("https://waterdata.usgs.gov/nwis/inventory?agency_code=%s&site_no=%s", row[0], row[1]),
 					Location: &core.Location{
 						Latitude:  core.TruncCoord(lat),
 						Longitude: core.TruncCoord(lng),
@@ -56,6 +50,14 @@ func (s *scriptUSGS) listStations(flow bool, gauges map[string]core.Gauge) error
 					Timezone: zone,
 				}
 			}
+			switch parameterCd {
+			case paramFlow:
+				g.FlowUnit = "ft3/s"
+			case paramLevel:
+				g.LevelUnit = "ft"
+			case paramTemperature:
+				g.ParamUnits = map[core.Parameter]string{core.Temperature: "degC"}
+			}
 			gauges[row[1]] = g
 			return nil
 		},
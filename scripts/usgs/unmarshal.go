@@ -6,8 +6,9 @@ import (
 )
 
 const (
-	paramFlow  = "00060" // Discharge, cubic feet per second
-	paramLevel = "00065" // Gage height, feet
+	paramFlow        = "00060" // Discharge, cubic feet per second
+	paramLevel       = "00065" // Gage height, feet
+	paramTemperature = "00010" // Temperature, water, degrees Celsius
 )
 
 type ivRoot struct {
@@ -41,8 +42,9 @@ type variable struct {
 	NoDataValue  nulltype.NullFloat64        `json:"noDataValue"`
 }
 type value struct {
-	Value    string     `json:"value"`
-	DateTime core.HTime `json:"dateTime"`
+	Value      string     `json:"value"`
+	Qualifiers []string   `json:"qualifiers"`
+	DateTime   core.HTime `json:"dateTime"`
 }
 type values struct {
 	Value []value `json:"value"`
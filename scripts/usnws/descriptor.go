@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "usnws",
 	Description: "U.S. National Oceanic and Atmospheric Administration's National Weather Service",
 	Mode:        core.AllAtOnce,
+	Host:        "mapservices.weather.noaa.gov",
 	DefaultOptions: func() interface{} {
 		return &optionsUsnws{
 			pageSize:   100, // defaults to 5000 if not mentioned at all, total around 10500
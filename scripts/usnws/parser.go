@@ -1,6 +1,7 @@
 package usnws
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -38,9 +39,10 @@ type countResponse struct {
 	Count int `json:"count"`
 }
 
-func (s *scriptUsnws) parseJson(gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
+func (s *scriptUsnws) parseJson(ctx context.Context, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
+	client := core.Client.WithContext(ctx)
 	var cntResp countResponse
-	if err := core.Client.GetAsJSON(s.url+"?where=1%3D1&text=&objectIds=&time=&timeRelation=esriTimeRelationOverlaps&geometry=&geometryType=esriGeometryEnvelope&inSR=&spatialRel=esriSpatialRelIntersects&distance=&units=esriSRUnit_Foot&relationParam=&outFields=&returnGeometry=true&returnTrueCurves=false&maxAllowableOffset=&geometryPrecision=&outSR=&havingClause=&returnIdsOnly=false&returnCountOnly=true&orderByFields=&groupByFieldsForStatistics=&outStatistics=&returnZ=false&returnM=false&gdbVersion=&historicMoment=&returnDistinctValues=false&resultOffset=&resultRecordCount=&returnExtentOnly=false&sqlFormat=none&datumTransformation=&parameterValues=&rangeValues=&quantizationParameters=&featureEncoding=esriDefault&f=pjson", &cntResp, nil); err != nil {
+	if err := client.GetAsJSON(s.url+"?where=1%3D1&text=&objectIds=&time=&timeRelation=esriTimeRelationOverlaps&geometry=&geometryType=esriGeometryEnvelope&inSR=&spatialRel=esriSpatialRelIntersects&distance=&units=esriSRUnit_Foot&relationParam=&outFields=&returnGeometry=true&returnTrueCurves=false&maxAllowableOffset=&geometryPrecision=&outSR=&havingClause=&returnIdsOnly=false&returnCountOnly=true&orderByFields=&groupByFieldsForStatistics=&outStatistics=&returnZ=false&returnM=false&gdbVersion=&historicMoment=&returnDistinctValues=false&resultOffset=&resultRecordCount=&returnExtentOnly=false&sqlFormat=none&datumTransformation=&parameterValues=&rangeValues=&quantizationParameters=&featureEncoding=esriDefault&f=pjson", &cntResp, nil); err != nil {
 		errs <- err
 		return
 	}
@@ -49,7 +51,7 @@ func (s *scriptUsnws) parseJson(gauges chan<- *core.Gauge, measurements chan<- *
 	g := new(errgroup.Group)
 	for i := 0; i < s.numWorkers; i++ {
 		g.Go(func() error {
-			return s.worker(jobs, gauges, measurements)
+			return s.worker(client, jobs, gauges, measurements)
 		})
 	}
 	for offset := 0; offset < cntResp.Count; offset += s.pageSize {
@@ -61,11 +63,11 @@ func (s *scriptUsnws) parseJson(gauges chan<- *core.Gauge, measurements chan<- *
 	}
 }
 
-func (s *scriptUsnws) worker(jobs <-chan int, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement) error {
+func (s *scriptUsnws) worker(client *core.HTTPClient, jobs <-chan int, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement) error {
 	for offset := range jobs {
 		var resp response
 		// if err := core.Client.GetAsJSON(fmt.Sprintf("%s?f=json&where=(1%%3D1)%%20AND%%20(1%%3D1)&returnGeometry=false&spatialRel=esriSpatialRelIntersects&outFields=objectid,gaugelid,location,latitude,longitude,waterbody,state,obstime,units,secunit,url,observed,secvalue&orderByFields=objectid%%20ASC&outSR=102100&resultOffset=%d&resultRecordCount=%d", s.url, offset, s.pageSize), &resp, nil); err != nil {
-		if err := core.Client.GetAsJSON(fmt.Sprintf("%s?f=json&where=(1%%3D1)%%20AND%%20(1%%3D1)&returnGeometry=false&spatialRel=esriSpatialRelIntersects&outFields=objectid,gaugelid,location,latitude,longitude,waterbody,state,obstime,units,secunit,url,observed,secvalue&orderByFields=objectid%%20ASC&outSR=4326&resultOffset=%d&resultRecordCount=%d", s.url, offset, s.pageSize), &resp, nil); err != nil {
+		if err := client.GetAsJSON(fmt.Sprintf("%s?f=json&where=(1%%3D1)%%20AND%%20(1%%3D1)&returnGeometry=false&spatialRel=esriSpatialRelIntersects&outFields=objectid,gaugelid,location,latitude,longitude,waterbody,state,obstime,units,secunit,url,observed,secvalue&orderByFields=objectid%%20ASC&outSR=4326&resultOffset=%d&resultRecordCount=%d", s.url, offset, s.pageSize), &resp, nil); err != nil {
 			return err
 		}
 		for _, feat := range resp.Features {
@@ -18,20 +18,20 @@ type scriptUsnws struct {
 	numWorkers int
 }
 
-func (s *scriptUsnws) ListGauges() (core.Gauges, error) {
+func (s *scriptUsnws) ListGauges(ctx context.Context) (core.Gauges, error) {
 	defer core.CloseTimezoneDb()
 	gaugesCh := make(chan *core.Gauge)
 	errCh := make(chan error)
 	go func() {
 		defer close(gaugesCh)
 		defer close(errCh)
-		s.parseJson(gaugesCh, nil, errCh)
+		s.parseJson(ctx, gaugesCh, nil, errCh)
 	}()
 	return core.GaugeSinkToSlice(gaugesCh, errCh)
 }
 
-func (s *scriptUsnws) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptUsnws) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	s.parseJson(nil, recv, errs)
+	s.parseJson(ctx, nil, recv, errs)
 }
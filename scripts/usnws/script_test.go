@@ -1,6 +1,7 @@
 package usnws
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -26,7 +27,7 @@ func TestUsnws_ListGauges(t *testing.T) {
 		pageSize:   1,
 		numWorkers: 2,
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -91,7 +92,7 @@ func TestUsnws_Harvest(t *testing.T) {
 		pageSize:   1,
 		numWorkers: 2,
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
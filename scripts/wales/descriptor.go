@@ -10,6 +10,7 @@ var Descriptor = &core.ScriptDescriptor{
 	Name:        "wales",
 	Description: "Natural Resources Wales",
 	Mode:        core.AllAtOnce,
+	Host:        "api.naturalresources.wales",
 	DefaultOptions: func() interface{} {
 		return &optionsWales{}
 	},
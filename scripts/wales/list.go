@@ -1,6 +1,7 @@
 package wales
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -8,7 +9,7 @@ import (
 	"github.com/whitewater-guide/gorge/core"
 )
 
-func (s *scriptWales) fetchList(gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
+func (s *scriptWales) fetchList(ctx context.Context, gauges chan<- *core.Gauge, measurements chan<- *core.Measurement, errs chan<- error) {
 	key := s.options.Key
 	if key == "" {
 		key = os.Getenv("WALES_KEY")
@@ -18,7 +19,7 @@ func (s *scriptWales) fetchList(gauges chan<- *core.Gauge, measurements chan<- *
 		return
 	}
 	var data []stationData
-	err := core.Client.GetAsJSON(
+	err := core.Client.WithContext(ctx).GetAsJSON(
 		s.url,
 		&data,
 		&core.RequestOptions{
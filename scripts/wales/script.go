@@ -17,19 +17,19 @@ type scriptWales struct {
 	core.LoggingScript
 }
 
-func (s *scriptWales) ListGauges() (core.Gauges, error) {
+func (s *scriptWales) ListGauges(ctx context.Context) (core.Gauges, error) {
 	gaugesCh := make(chan *core.Gauge)
 	errCh := make(chan error)
 	go func() {
 		defer close(gaugesCh)
 		defer close(errCh)
-		s.fetchList(gaugesCh, nil, errCh)
+		s.fetchList(ctx, gaugesCh, nil, errCh)
 	}()
 	return core.GaugeSinkToSlice(gaugesCh, errCh)
 }
 
-func (s *scriptWales) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, codes core.StringSet, since int64) {
+func (s *scriptWales) Harvest(ctx context.Context, recv chan<- *core.Measurement, errs chan<- error, spec core.HarvestSpec) {
 	defer close(recv)
 	defer close(errs)
-	s.fetchList(nil, recv, errs)
+	s.fetchList(ctx, nil, recv, errs)
 }
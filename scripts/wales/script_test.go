@@ -1,6 +1,7 @@
 package wales
 
 import (
+	"context"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -25,7 +26,7 @@ func TestWales_Auth(t *testing.T) {
 		url:     ts.URL + "/data.json",
 		options: optionsWales{Key: "__bad__"},
 	}
-	_, err := s.ListGauges()
+	_, err := s.ListGauges(context.Background())
 	assert.Error(t, err)
 }
 
@@ -37,7 +38,7 @@ func TestWales_ListGauges(t *testing.T) {
 		url:     ts.URL,
 		options: optionsWales{Key: testutils.TestAuthKey},
 	}
-	actual, err := s.ListGauges()
+	actual, err := s.ListGauges(context.Background())
 	expected := core.Gauges{
 		core.Gauge{
 			GaugeID: core.GaugeID{
@@ -81,7 +82,7 @@ func TestWales_Harvest(t *testing.T) {
 		url:     ts.URL,
 		options: optionsWales{Key: testutils.TestAuthKey},
 	}
-	actual, err := core.HarvestSlice(&s, core.StringSet{}, 0)
+	actual, err := core.HarvestSlice(&s, core.StringSet{})
 	expected := core.Measurements{
 		&core.Measurement{
 			GaugeID: core.GaugeID{
@@ -55,7 +55,27 @@ func seedEndpointTest(db storage.DatabaseManager, cache storage.CacheManager) {
 			Level:     nulltype.NullFloat64Of(-100),
 			Flow:      nulltype.NullFloat64Of(-100),
 		},
+		{
+			GaugeID: core.GaugeID{
+				Script: "all_at_once",
+				Code:   "g001",
+			},
+			Timestamp: core.HTime{Time: time.Now().Add(-1 * time.Hour).UTC()},
+			Level:     nulltype.NullFloat64Of(0.3048),
+			Flow:      nulltype.NullFloat64Of(0.028316846592),
+		},
 	}))
+	// nolint:errcheck
+	db.SaveJobRun(core.JobRun{
+		JobID:     "48f979ec-268b-11ea-978f-2e728ce88125",
+		Script:    "all_at_once",
+		StartedAt: core.HTime{Time: time.Date(2020, time.May, 1, 10, 0, 0, 0, time.UTC)},
+		Duration:  core.Duration{Duration: 2 * time.Second},
+		Harvested: 11,
+		Filters:   []core.FilterStats{{Name: "codes", In: 11, Out: 1}},
+		Saved:     1,
+		Cached:    1,
+	})
 	cache.SaveStatus("48f979ec-268b-11ea-978f-2e728ce88125", "g000", nil, 10)                     // nolint:errcheck
 	cache.SaveStatus("48f979ec-268b-11ea-978f-2e728ce88125", "g001", errors.New("test error"), 0) // nolint:errcheck
 	cache.SaveStatus("48f979ec-268b-11ea-978f-2e728ce88125", "", errors.New("test error"), 0)     // nolint:errcheck
@@ -69,8 +89,40 @@ func seedEndpointTest(db storage.DatabaseManager, cache storage.CacheManager) {
 			Level:     nulltype.NullFloat64Of(-100),
 			Flow:      nulltype.NullFloat64Of(-100),
 		},
+		{
+			GaugeID: core.GaugeID{
+				Script: "all_at_once",
+				Code:   "g_old",
+			},
+			Timestamp: core.HTime{Time: time.Now().Add(-1 * time.Hour).UTC()},
+			Level:     nulltype.NullFloat64Of(1),
+			Flow:      nulltype.NullFloat64Of(1),
+		},
 	}))
 
+	db.SaveGauges("all_at_once", []core.Gauge{ // nolint:errcheck
+		{
+			GaugeID:   core.GaugeID{Script: "all_at_once", Code: "g000"},
+			Name:      "Test gauge #0",
+			LevelUnit: "m",
+			FlowUnit:  "m3/s",
+			Timezone:  "UTC",
+		},
+		{
+			GaugeID:   core.GaugeID{Script: "all_at_once", Code: "g001"},
+			Name:      "Test gauge #1",
+			LevelUnit: "m",
+			FlowUnit:  "m3/s",
+			Timezone:  "UTC",
+		},
+	})
+	db.AddAlias(core.GaugeAlias{Script: "all_at_once", OldCode: "g_old", NewCode: "g001"}) // nolint:errcheck
+
+	db.AddRatingCurve(core.RatingCurve{ // nolint:errcheck
+		GaugeID: core.GaugeID{Script: "all_at_once", Code: "g001"},
+		Table:   []core.RatingPoint{{Level: 0, Flow: 0}, {Level: 1, Flow: 10}},
+	})
+
 	// time.Sleep(10 * time.Millisecond)
 }
 
@@ -109,6 +161,57 @@ func TestEndpoint(t *testing.T) {
 				{"name": "one_by_one", "mode": "oneByOne", "description": "Test script for one by one harvesting mode"}
 			]`,
 		},
+		{
+			name: "script schema - success",
+			path: "/scripts/all_at_once/schema",
+			resp: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"title": "all_at_once",
+				"description": "Test script for all at once harvesting mode",
+				"type": "object",
+				"properties": {
+					"options": { "$ref": "#/definitions/options" },
+					"gauges": {
+						"type": "object",
+						"description": "Gauge codes with gauge-level options",
+						"additionalProperties": { "oneOf": [{ "type": "null" }, { "$ref": "#/definitions/options" }] }
+					}
+				},
+				"definitions": {
+					"options": {
+						"type": "object",
+						"additionalProperties": false,
+						"properties": {
+							"Gauges": { "type": "integer", "description": "Number of gauges", "default": 10 },
+							"Value": { "type": "number", "description": "Set this to return fixed value. Has priority over min/max" },
+							"Min": { "type": "number", "description": "Set this and max to return random values within interval", "default": 10 },
+							"Max": { "type": "number", "description": "Set this and min to return random values within interval", "default": 20 },
+							"noLocation": { "type": "boolean", "description": "Generate gauges without locations" },
+							"noAltitude": { "type": "boolean", "description": "Generate gauges with 0 altitude" },
+							"outliers": "<<PRESENCE>>"
+						}
+					}
+				}
+			}`,
+		},
+		{
+			name: "list filters",
+			path: "/filters",
+			resp: `[
+				{"name": "partition", "description": "<<PRESENCE>>", "default": true, "required": true},
+				{"name": "codes", "description": "<<PRESENCE>>", "default": true, "required": false},
+				{"name": "outliers", "description": "<<PRESENCE>>", "default": true, "required": false},
+				{"name": "latest", "description": "<<PRESENCE>>", "default": true, "required": false},
+				{"name": "dedup", "description": "<<PRESENCE>>", "default": false, "required": false},
+				{"name": "window", "description": "<<PRESENCE>>", "default": false, "required": false}
+			]`,
+		},
+		{
+			name: "script schema - bad script",
+			path: "/scripts/foo/schema",
+			code: http.StatusNotFound,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
 		{
 			name:   "upstream gauges - success",
 			method: "POST",
@@ -194,6 +297,14 @@ func TestEndpoint(t *testing.T) {
 			code:   http.StatusInternalServerError,
 			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
 		},
+		{
+			name:   "upstream gauges - bad timeout",
+			method: "POST",
+			body:   "{}",
+			path:   "/upstream/all_at_once/gauges?timeout=foo",
+			code:   http.StatusBadRequest,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
 		{
 			name:   "upstream measurements - bad script",
 			path:   "/upstream/foo/measurements",
@@ -329,6 +440,88 @@ func TestEndpoint(t *testing.T) {
 					"options": {"gauges": 11}
 			}`,
 		},
+		{
+			name:   "backfill job",
+			method: "POST",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/backfill",
+			body:   `{"from": "2000-01-01T00:00:00Z", "to": "2000-01-03T00:00:00Z"}`,
+			code:   http.StatusAccepted,
+			resp: `{
+				"jobId": "48f979ec-268b-11ea-978f-2e728ce88125",
+				"state": "running",
+				"from": "2000-01-01T00:00:00Z",
+				"to": "2000-01-03T00:00:00Z",
+				"pages": 2,
+				"pagesDone": 0,
+				"saved": 0,
+				"startedAt": "<<PRESENCE>>"
+			}`,
+		},
+		{
+			name:   "backfill job - bad period",
+			method: "POST",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/backfill",
+			body:   `{"from": "2000-01-03T00:00:00Z", "to": "2000-01-01T00:00:00Z"}`,
+			code:   http.StatusBadRequest,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "backfill job - bad code",
+			method: "POST",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/backfill",
+			body:   `{"from": "2000-01-01T00:00:00Z", "to": "2000-01-03T00:00:00Z", "codes": ["foo"]}`,
+			code:   http.StatusBadRequest,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "backfill job - not found",
+			method: "POST",
+			path:   "/jobs/24e45a47-7ae2-453a-afa3-153392e2460b/backfill",
+			body:   `{"from": "2000-01-01T00:00:00Z", "to": "2000-01-03T00:00:00Z"}`,
+			code:   http.StatusNotFound,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name: "get job backfill - not found",
+			path: "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/backfill",
+			code: http.StatusNotFound,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name: "list catalog gauges",
+			path: "/gauges/all_at_once",
+			resp: `[
+				{"script": "all_at_once", "code": "g000", "name": "Test gauge #0", "levelUnit": "m", "flowUnit": "m3/s", "timezone": "UTC"},
+				{"script": "all_at_once", "code": "g001", "name": "Test gauge #1", "levelUnit": "m", "flowUnit": "m3/s", "timezone": "UTC"}
+			]`,
+		},
+		{
+			name: "list catalog gauges - unknown script",
+			path: "/gauges/foo",
+			resp: `[]`,
+		},
+		{
+			name: "get catalog gauge",
+			path: "/gauges/all_at_once/g001",
+			resp: `{"script": "all_at_once", "code": "g001", "name": "Test gauge #1", "levelUnit": "m", "flowUnit": "m3/s", "timezone": "UTC"}`,
+		},
+		{
+			name: "get catalog gauge - not found",
+			path: "/gauges/all_at_once/g999",
+			code: http.StatusNotFound,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name: "get catalog gauge history",
+			path: "/gauges/all_at_once/g001/history",
+			resp: `[{
+				"script": "all_at_once",
+				"code": "g001",
+				"kind": "added",
+				"gauge": {"script": "all_at_once", "code": "g001", "name": "Test gauge #1", "levelUnit": "m", "flowUnit": "m3/s", "timezone": "UTC"},
+				"timestamp": "<<PRESENCE>>"
+			}]`,
+		},
 		{
 			name: "get job gauges",
 			path: "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/gauges",
@@ -403,6 +596,320 @@ func TestEndpoint(t *testing.T) {
 				"options": null
 			}`,
 		},
+		{
+			name:   "add job - retry policy",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"retry": {"attempts": 3, "backoff": "1m"}
+			}`,
+			path: "/jobs",
+			resp: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"options": null,
+				"retry": {"attempts": 3, "backoff": "1m0s", "maxBackoff": "0s"}
+			}`,
+		},
+		{
+			name:   "add job - bad retry policy",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"retry": {"attempts": 3}
+			}`,
+			path: "/jobs",
+			code: http.StatusBadRequest,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "add job - timeout",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"timeout": "5m"
+			}`,
+			path: "/jobs",
+			resp: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"options": null,
+				"timeout": "5m0s"
+			}`,
+		},
+		{
+			name:   "add job - bad timeout",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"timeout": "-5m"
+			}`,
+			path: "/jobs",
+			code: http.StatusBadRequest,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "add job - adaptive",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "one_by_one",
+				"gauges": {"g001": {}},
+				"adaptive": { "minInterval": "5m", "maxInterval": "24h" }
+			}`,
+			path: "/jobs",
+			resp: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "one_by_one",
+				"gauges": {"g001": {}},
+				"cron": "",
+				"options": null,
+				"adaptive": { "minInterval": "5m0s", "maxInterval": "24h0m0s" }
+			}`,
+		},
+		{
+			name:   "add job - bad adaptive",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "one_by_one",
+				"gauges": {"g001": {}},
+				"adaptive": { "minInterval": "1h", "maxInterval": "5m" }
+			}`,
+			path: "/jobs",
+			code: http.StatusBadRequest,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "add job - adaptive all at once",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"adaptive": { "minInterval": "5m", "maxInterval": "1h" }
+			}`,
+			path: "/jobs",
+			code: http.StatusInternalServerError,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "add job - all gauges",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "one_by_one",
+				"gauges": {"g001": {"value": 1}},
+				"options": {"gauges": 3},
+				"allGauges": { "name": "#[12]$" }
+			}`,
+			path: "/jobs",
+			resp: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "one_by_one",
+				"gauges": {"g001": {"value": 1}, "g002": null},
+				"cron": "",
+				"options": {"gauges": 3},
+				"allGauges": { "name": "#[12]$" }
+			}`,
+		},
+		{
+			name:   "add job - bad gauge selector",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "one_by_one",
+				"gauges": {},
+				"allGauges": { "bbox": [1, 2, 3] }
+			}`,
+			path: "/jobs",
+			code: http.StatusBadRequest,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "add job - all gauges broken upstream",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "broken",
+				"gauges": {},
+				"cron": "* * * * *",
+				"allGauges": {}
+			}`,
+			path: "/jobs",
+			code: http.StatusInternalServerError,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "validate job - all gauges",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "one_by_one",
+				"gauges": {},
+				"allGauges": { "name": "^foo$" }
+			}`,
+			path: "/jobs/validate",
+			resp: `{
+				"valid": false,
+				"problems": [{ "field": "allGauges", "message": "no upstream gauges match selector" }]
+			}`,
+		},
+		{
+			name:   "validate job - valid",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "one_by_one",
+				"gauges": {"g001": {}, "g002": null}
+			}`,
+			path: "/jobs/validate",
+			resp: `{ "valid": true, "problems": [] }`,
+		},
+		{
+			name:   "validate job - problems",
+			method: "POST",
+			body: `{
+				"id": "foo",
+				"script": "all_at_once",
+				"gauges": {"g001": {}, "g042": {}},
+				"cron": "bad",
+				"options": {"gauges": 3},
+				"timeout": "-1s"
+			}`,
+			path: "/jobs/validate",
+			resp: `{
+				"valid": false,
+				"problems": [
+					{ "field": "id", "message": "job id must be valid uuid" },
+					{ "field": "timeout", "message": "job timeout must be positive" },
+					{ "field": "cron", "message": "<<PRESENCE>>" },
+					{ "field": "gauges", "code": "g042", "message": "gauge is not found in upstream" }
+				]
+			}`,
+		},
+		{
+			name:   "validate job - gauge options",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "one_by_one",
+				"gauges": {"g001": {"foo": 1}}
+			}`,
+			path: "/jobs/validate",
+			resp: `{
+				"valid": false,
+				"problems": [{ "field": "gauges", "code": "g001", "message": "<<PRESENCE>>" }]
+			}`,
+		},
+		{
+			name:   "validate job - unknown script",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "foo",
+				"gauges": {"g001": {}}
+			}`,
+			path: "/jobs/validate",
+			resp: `{
+				"valid": false,
+				"problems": [{ "field": "script", "message": "unknown script 'foo'" }]
+			}`,
+		},
+		{
+			name:   "validate job - bad json",
+			method: "POST",
+			body:   `{ "id": `,
+			path:   "/jobs/validate",
+			code:   http.StatusBadRequest,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "add job - revise save mode",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"options": {"gauges": 3},
+				"saveMode": "revise"
+			}`,
+			path: "/jobs",
+			resp: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"options": {"gauges": 3},
+				"saveMode": "revise"
+			}`,
+		},
+		{
+			name:   "add job - bad save mode",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"saveMode": "foo"
+			}`,
+			path: "/jobs",
+			code: http.StatusBadRequest,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "add job - bad filters",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"filters": {"foo": true}
+			}`,
+			path: "/jobs",
+			code: http.StatusBadRequest,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "add job - with filters",
+			method: "POST",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"filters": {"dedup": true, "latest": {"window": "48h"}}
+			}`,
+			path: "/jobs",
+			resp: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges": {"g001": {}},
+				"cron": "* * * * *",
+				"options": null,
+				"filters": {"dedup": true, "latest": {"window": "48h"}}
+			}`,
+		},
 		{
 			name:   "add job - bad payload",
 			method: "POST",
@@ -474,6 +981,162 @@ func TestEndpoint(t *testing.T) {
 			code:   http.StatusInternalServerError,
 			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
 		},
+		{
+			name:   "update job",
+			method: "PUT",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125",
+			body: `{
+				"id": "48f979ec-268b-11ea-978f-2e728ce88125",
+				"script": "all_at_once",
+				"gauges":  {"g001": {}},
+				"cron": "5 * * * *",
+				"options": {"gauges": 3}
+			}`,
+			resp: `{
+				"id": "48f979ec-268b-11ea-978f-2e728ce88125",
+				"script": "all_at_once",
+				"gauges":  {"g001": {}},
+				"cron": "5 * * * *",
+				"options": {"gauges": 3}
+			}`,
+		},
+		{
+			name:   "update job - bad cron",
+			method: "PUT",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125",
+			body: `{
+				"id": "48f979ec-268b-11ea-978f-2e728ce88125",
+				"script": "all_at_once",
+				"gauges":  {"g001": {}},
+				"cron": "a * * * *",
+				"options": {"gauges": 3}
+			}`,
+			code: http.StatusInternalServerError,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "update job - id mismatch",
+			method: "PUT",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges":  {"g001": {}},
+				"cron": "5 * * * *"
+			}`,
+			code: http.StatusBadRequest,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "update job - not found",
+			method: "PUT",
+			path:   "/jobs/24e45a47-7ae2-453a-afa3-153392e2460b",
+			body: `{
+				"id": "24e45a47-7ae2-453a-afa3-153392e2460b",
+				"script": "all_at_once",
+				"gauges":  {"g001": {}},
+				"cron": "5 * * * *"
+			}`,
+			code: http.StatusNotFound,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "harvest stats",
+			method: "GET",
+			path:   "/harvests/stats",
+			resp: `{
+				"global": { "limit": 0, "running": 0, "queued": 0, "started": 0, "avgWait": "0s", "maxWait": "0s" },
+				"scripts": {},
+				"hosts": {}
+			}`,
+		},
+		{
+			name:   "list job runs",
+			method: "GET",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/runs",
+			resp: `[{
+				"id": "<<PRESENCE>>",
+				"jobId": "48f979ec-268b-11ea-978f-2e728ce88125",
+				"script": "all_at_once",
+				"startedAt": "2020-05-01T10:00:00Z",
+				"duration": "2s",
+				"harvested": 11,
+				"filters": [{ "name": "codes", "in": 11, "out": 1 }],
+				"saved": 1,
+				"cached": 1
+			}]`,
+		},
+		{
+			name:   "list job runs - by code",
+			method: "GET",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/runs?code=g000",
+			resp:   `[]`,
+		},
+		{
+			name:   "list job runs - bad limit",
+			method: "GET",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/runs?limit=0",
+			code:   http.StatusBadRequest,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "run job",
+			method: "POST",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/run",
+			resp:   `{ "lastRun": "<<PRESENCE>>", "count": 1 }`,
+		},
+		{
+			name:   "run job - single gauge",
+			method: "POST",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/run?code=g000",
+			resp:   `{ "lastRun": "<<PRESENCE>>", "count": 1 }`,
+		},
+		{
+			name:   "run job - unknown gauge",
+			method: "POST",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/run?code=g100",
+			code:   http.StatusBadRequest,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "run job - not found",
+			method: "POST",
+			path:   "/jobs/24e45a47-7ae2-453a-afa3-153392e2460b/run",
+			code:   http.StatusNotFound,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "pause job",
+			method: "POST",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/pause",
+			resp: `{
+					"id": "48f979ec-268b-11ea-978f-2e728ce88125",
+					"script": "all_at_once",
+					"gauges":  {"g000": {}},
+					"cron":   "0 0 * * *",
+					"options": {"gauges": 11},
+					"paused": true
+			}`,
+		},
+		{
+			name:   "resume job - not paused",
+			method: "POST",
+			path:   "/jobs/48f979ec-268b-11ea-978f-2e728ce88125/resume",
+			resp: `{
+					"id": "48f979ec-268b-11ea-978f-2e728ce88125",
+					"script": "all_at_once",
+					"gauges":  {"g000": {}},
+					"cron":   "0 0 * * *",
+					"options": {"gauges": 11}
+			}`,
+		},
+		{
+			name:   "pause job - not found",
+			method: "POST",
+			path:   "/jobs/24e45a47-7ae2-453a-afa3-153392e2460b/pause",
+			code:   http.StatusNotFound,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
 		{
 			name:   "delete job - success",
 			method: "DELETE",
@@ -498,7 +1161,43 @@ func TestEndpoint(t *testing.T) {
 		{
 			name: "measurements/latest success",
 			path: "/measurements/latest?scripts=broken,all_at_once",
-			resp: `[{"script": "broken", "code": "g000", "timestamp": "<<PRESENCE>>", "flow": -100, "level": -100}]`,
+			resp: `[
+				"<<UNORDERED>>",
+				{"script": "broken", "code": "g000", "timestamp": "<<PRESENCE>>", "flow": -100, "level": -100},
+				{"script": "all_at_once", "code": "g001", "timestamp": "<<PRESENCE>>", "flow": 1, "level": 1}
+			]`,
+		},
+		{
+			name: "measurements/latest of renamed gauge",
+			path: "/measurements/latest?scripts=all_at_once",
+			resp: `[{"script": "all_at_once", "code": "g001", "timestamp": "<<PRESENCE>>", "flow": 1, "level": 1}]`,
+		},
+		{
+			name: "measurements in imperial units",
+			path: "/measurements/all_at_once/g001?units=imperial",
+			resp: `[{"script": "all_at_once", "code": "g001", "timestamp": "<<PRESENCE>>", "flow": 1, "level": 1}]`,
+		},
+		{
+			name: "measurements in si units",
+			path: "/measurements/all_at_once/g001?units=si",
+			resp: `[{"script": "all_at_once", "code": "g001", "timestamp": "<<PRESENCE>>", "flow": 0.028316846592, "level": 0.3048}]`,
+		},
+		{
+			name: "measurements/latest in imperial units, gauge not in catalog",
+			path: "/measurements/latest?scripts=broken&units=imperial",
+			resp: `[{"script": "broken", "code": "g000", "timestamp": "<<PRESENCE>>", "flow": null, "level": null}]`,
+		},
+		{
+			name: "measurements - bad units",
+			path: "/measurements/all_at_once/g001?units=foo",
+			code: http.StatusBadRequest,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name: "measurements/latest - bad units",
+			path: "/measurements/latest?scripts=broken&units=foo",
+			code: http.StatusBadRequest,
+			resp: `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
 		},
 		{
 			name: "measurements - bad query",
@@ -511,6 +1210,100 @@ func TestEndpoint(t *testing.T) {
 			path: fmt.Sprintf("/measurements/broken/g000/nearest?to=%d", time.Now().Add(-15*time.Minute).UTC().Unix()),
 			resp: `{"script": "broken", "code": "g000", "timestamp": "<<PRESENCE>>", "flow": -100, "level": -100}`,
 		},
+		{
+			name: "measurements of renamed gauge",
+			path: "/measurements/all_at_once/g_old",
+			resp: `[{"script": "all_at_once", "code": "g001", "timestamp": "<<PRESENCE>>", "flow": 0.028316846592, "level": 0.3048}]`,
+		},
+		{
+			name: "list aliases",
+			path: "/aliases",
+			resp: `[{"script": "all_at_once", "oldCode": "g_old", "newCode": "g001"}]`,
+		},
+		{
+			name: "list aliases of script",
+			path: "/aliases/broken",
+			resp: `[]`,
+		},
+		{
+			name:   "add alias",
+			path:   "/aliases",
+			method: "POST",
+			body:   `{"script": "broken", "oldCode": "g000", "newCode": "g100"}`,
+			resp:   `{"alias": {"script": "broken", "oldCode": "g000", "newCode": "g100"}, "migrated": 1}`,
+		},
+		{
+			name:   "add alias - bad request",
+			path:   "/aliases",
+			method: "POST",
+			body:   `{"script": "broken", "oldCode": "g000"}`,
+			code:   http.StatusBadRequest,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "add alias - cycle",
+			path:   "/aliases",
+			method: "POST",
+			body:   `{"script": "all_at_once", "oldCode": "g001", "newCode": "g_old"}`,
+			code:   http.StatusBadRequest,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "delete alias",
+			path:   "/aliases/all_at_once/g_old",
+			method: "DELETE",
+			resp:   `{"success": true}`,
+		},
+		{
+			name:   "delete alias - not found",
+			path:   "/aliases/all_at_once/g999",
+			method: "DELETE",
+			code:   http.StatusNotFound,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name: "list rating curves",
+			path: "/ratings/all_at_once",
+			resp: `[{"id": 1, "script": "all_at_once", "code": "g001", "table": [{"level": 0, "flow": 0}, {"level": 1, "flow": 10}]}]`,
+		},
+		{
+			name: "list rating curves of renamed gauge",
+			path: "/ratings/all_at_once/g_old",
+			resp: `[{"id": 1, "script": "all_at_once", "code": "g001", "table": [{"level": 0, "flow": 0}, {"level": 1, "flow": 10}]}]`,
+		},
+		{
+			name:   "add rating curve",
+			path:   "/ratings",
+			method: "POST",
+			body:   `{"script": "all_at_once", "code": "g000", "validFrom": "2020-01-01T00:00:00Z", "power": {"c": 10, "h0": 0.1, "b": 1.6}}`,
+			resp:   `{"id": 2, "script": "all_at_once", "code": "g000", "validFrom": "2020-01-01T00:00:00Z", "power": {"c": 10, "h0": 0.1, "b": 1.6}}`,
+		},
+		{
+			name:   "add rating curve - bad table",
+			path:   "/ratings",
+			method: "POST",
+			body:   `{"script": "all_at_once", "code": "g000", "table": [{"level": 1, "flow": 10}, {"level": 0, "flow": 0}]}`,
+			code:   http.StatusBadRequest,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name:   "delete rating curve",
+			path:   "/ratings/1",
+			method: "DELETE",
+			resp:   `{"success": true}`,
+		},
+		{
+			name:   "delete rating curve - not found",
+			path:   "/ratings/100",
+			method: "DELETE",
+			code:   http.StatusNotFound,
+			resp:   `{ "error": "<<PRESENCE>>", "status": "<<PRESENCE>>", "request_id": "<<PRESENCE>>" }`,
+		},
+		{
+			name: "measurement revisions",
+			path: "/measurements/all_at_once/g001/revisions",
+			resp: `[]`,
+		},
 		{
 			name: "measurements/nearest fail",
 			path: fmt.Sprintf("/measurements/broken/g000/nearest?to=%d", time.Now().Add(333*time.Minute).UTC().Unix()),
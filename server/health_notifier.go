@@ -58,6 +58,10 @@ func (job healthNotifierJob) Run() {
 	threshold := time.Now().Add(-time.Duration(job.cfg.Threshold) * time.Hour)
 
 	for _, j := range jobs {
+		// paused jobs are not harvested, so they're expected to have no recent successes
+		if j.Paused {
+			continue
+		}
 		if status, ok := statuses[j.ID]; ok {
 			// because this is supposed to run daily and our job are scheduled hourly or more frequently,
 			// having last success == nil for a day is cosidered unhealthy
@@ -41,6 +41,18 @@ func seedHealthNotifierTests(db storage.DatabaseManager, cache storage.CacheMana
 		return nil
 	})
 	cache.SaveStatus("e0b198ad-d7cd-4d2b-aeb0-ad83992bc851", "", errors.New("test error"), 0) // nolint:errcheck
+
+	// Paused job, it's not harvested so it's not unhealthy
+	// nolint:errcheck
+	db.AddJob(core.JobDescription{
+		ID:     "5d0e4b9e-8a47-4b6f-9f3b-0c1f2b6c3a10",
+		Script: "one_by_one",
+		Gauges: map[string]json.RawMessage{"o001": json.RawMessage("{}")},
+		Paused: true,
+	}, func(job core.JobDescription) error {
+		return nil
+	})
+	cache.SaveStatus("5d0e4b9e-8a47-4b6f-9f3b-0c1f2b6c3a10", "", errors.New("test error"), 0) // nolint:errcheck
 }
 
 func TestHealthNotifier(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/octago/sflags/gen/gpflag"
 	"github.com/spf13/cobra"
@@ -58,6 +59,8 @@ func main() {
 				fx.Invoke(startServer),
 				fx.Invoke(startHealthNotifier),
 				fx.WithLogger(newFxLogger),
+				// running harvests are given grace period to finish on shutdown
+				fx.StopTimeout(time.Duration(cfg.Scheduler.Grace)*time.Second+fx.DefaultTimeout),
 			)
 			app.Run()
 			return nil
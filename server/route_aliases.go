@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+func (s *Server) handleListAliases() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		script := chi.URLParam(r, "script")
+		aliases, err := s.database.ListAliases(script)
+		if err != nil {
+			s.renderError(w, r, err, "failed to list aliases", http.StatusInternalServerError)
+			return
+		}
+		if aliases == nil {
+			aliases = []core.GaugeAlias{}
+		}
+		render.JSON(w, r, aliases)
+	}
+}
+
+func (s *Server) handleAddAlias() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var alias core.GaugeAlias
+		if err := render.Bind(r, &alias); err != nil {
+			s.renderError(w, r, err, "bad alias", http.StatusBadRequest)
+			return
+		}
+		migrated, err := s.database.AddAlias(alias)
+		if errors.Is(err, core.ErrAliasCycle) {
+			s.renderError(w, r, err, "bad alias", http.StatusBadRequest)
+			return
+		} else if err != nil {
+			s.renderError(w, r, err, "failed to add alias", http.StatusInternalServerError)
+			return
+		}
+		s.logger.WithField("script", alias.Script).
+			WithField("oldCode", alias.OldCode).
+			WithField("newCode", alias.NewCode).
+			Infof("added alias, migrated %d measurements", migrated)
+		s.moveCachedGauge(alias.Script, alias.OldCode)
+		render.JSON(w, r, map[string]interface{}{"alias": alias, "migrated": migrated})
+	}
+}
+
+func (s *Server) handleDeleteAlias() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		script, code := chi.URLParam(r, "script"), chi.URLParam(r, "code")
+		found, err := s.database.DeleteAlias(script, code)
+		if err != nil {
+			s.renderError(w, r, err, "failed to delete alias", http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			s.renderError(w, r, errors.New("not found"), "not found", http.StatusNotFound)
+			return
+		}
+		s.logger.WithField("script", script).WithField("oldCode", code).Info("deleted alias")
+		render.JSON(w, r, map[string]interface{}{"success": true})
+	}
+}
+
+// moveCachedGauge moves latest measurement and gauge statuses of renamed gauge to its current code in cache
+// Cache is safe to lose, so errors are only logged
+func (s *Server) moveCachedGauge(script, oldCode string) {
+	newCode := s.resolveCode(script, oldCode)
+	logger := s.logger.WithField("script", script).WithField("oldCode", oldCode).WithField("newCode", newCode)
+	if err := s.cache.MoveLatestMeasurement(script, oldCode, newCode); err != nil {
+		logger.Warnf("failed to move latest measurement: %v", err)
+	}
+	jobs, err := s.database.ListJobs()
+	if err != nil {
+		logger.Warnf("failed to move gauge statuses: %v", err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Script != script {
+			continue
+		}
+		if err := s.cache.MoveGaugeStatus(job.ID, oldCode, newCode); err != nil {
+			logger.WithField("jobID", job.ID).Warnf("failed to move gauge status: %v", err)
+		}
+	}
+}
+
+// resolveLatest renames old gauge codes of latest measurements to current codes
+// If measurement is present under both codes, the most recent one is kept
+func (s *Server) resolveLatest(measurements map[core.GaugeID]core.Measurement) map[core.GaugeID]core.Measurement {
+	aliases, err := s.database.ListAliases("")
+	if err != nil {
+		s.logger.Warnf("failed to load gauge aliases: %v", err)
+		return measurements
+	}
+	if len(aliases) == 0 {
+		return measurements
+	}
+	byScript := map[string]core.CodeAliases{}
+	result := make(map[core.GaugeID]core.Measurement, len(measurements))
+	for id, m := range measurements {
+		codes, ok := byScript[id.Script]
+		if !ok {
+			codes = core.NewCodeAliases(id.Script, aliases)
+			byScript[id.Script] = codes
+		}
+		if resolved, err := codes.Resolve(id.Code); err == nil {
+			m.Code = resolved
+		}
+		if e, ok := result[m.GaugeID]; ok && !e.Timestamp.Before(m.Timestamp.Time) {
+			continue
+		}
+		result[m.GaugeID] = m
+	}
+	return result
+}
+
+// resolveCode returns current code of gauge, in case it was renamed in upstream
+// If aliases cannot be loaded or resolved, code is returned as is
+func (s *Server) resolveCode(script, code string) string {
+	if script == "" || code == "" {
+		return code
+	}
+	aliases, err := s.database.ListAliases(script)
+	if err != nil {
+		s.logger.Warnf("failed to load gauge aliases: %v", err)
+		return code
+	}
+	resolved, err := core.NewCodeAliases(script, aliases).Resolve(code)
+	if err != nil {
+		return code
+	}
+	return resolved
+}
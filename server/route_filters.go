@@ -0,0 +1,14 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+func (s *Server) handleListFilters() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, core.Filters.List())
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+func (s *Server) handleListGauges() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		script := chi.URLParam(r, "script")
+		gauges, err := s.database.ListGauges(script)
+		if err != nil {
+			s.renderError(w, r, err, "failed to list gauges", http.StatusInternalServerError)
+			return
+		}
+		render.JSON(w, r, gauges)
+	}
+}
+
+func (s *Server) handleGetGauge() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		script, code := chi.URLParam(r, "script"), chi.URLParam(r, "code")
+		gauge, err := s.database.GetGauge(script, code)
+		if err != nil {
+			s.renderError(w, r, err, "failed to get gauge", http.StatusInternalServerError)
+			return
+		}
+		if gauge == nil {
+			s.renderError(w, r, errors.New("not found"), "not found", http.StatusNotFound)
+			return
+		}
+		render.JSON(w, r, gauge)
+	}
+}
+
+func (s *Server) handleGetGaugeHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		script, code := chi.URLParam(r, "script"), chi.URLParam(r, "code")
+		changes, err := s.database.ListGaugeChanges(script, code)
+		if err != nil {
+			s.renderError(w, r, err, "failed to list gauge history", http.StatusInternalServerError)
+			return
+		}
+		render.JSON(w, r, changes)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -17,6 +18,11 @@ func (s *Server) handleAddJob() http.HandlerFunc {
 			s.renderError(w, r, err, "bad job description", http.StatusBadRequest)
 			return
 		}
+		description, err = s.scheduler.SelectGauges(r.Context(), description)
+		if err != nil {
+			s.renderError(w, r, err, "failed to select gauges", http.StatusInternalServerError)
+			return
+		}
 
 		err = s.database.AddJob(description, s.scheduler.AddJob)
 		if err != nil {
@@ -33,6 +39,79 @@ func (s *Server) handleAddJob() http.HandlerFunc {
 	}
 }
 
+func (s *Server) handleValidateJob() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var description core.JobDescription
+		// render.Bind is not used, because binding problems are reported along with others
+		err := render.DecodeJSON(r.Body, &description)
+		if err != nil {
+			s.renderError(w, r, core.WrapErr(err, "failed to decode job description"), "bad job description", http.StatusBadRequest)
+			return
+		}
+		problems := s.scheduler.ValidateJob(r.Context(), description)
+		if problems == nil {
+			problems = []core.JobProblem{}
+		}
+		render.JSON(w, r, core.JobValidation{Valid: len(problems) == 0, Problems: problems})
+	}
+}
+
+func (s *Server) handleUpdateJob() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID := chi.URLParam(r, "jobId")
+		var description core.JobDescription
+		err := render.Bind(r, &description)
+		if err != nil {
+			s.renderError(w, r, err, "bad job description", http.StatusBadRequest)
+			return
+		}
+		if description.ID != jobID {
+			s.renderError(w, r, errors.New("job id cannot be changed"), "bad job description", http.StatusBadRequest)
+			return
+		}
+		job, err := s.database.GetJob(jobID)
+		if err != nil {
+			s.renderError(w, r, err, "failed to get job", http.StatusInternalServerError)
+			return
+		}
+		if job == nil {
+			s.renderError(w, r, errors.New("not found"), "not found", http.StatusNotFound)
+			return
+		}
+		description, err = s.scheduler.SelectGauges(r.Context(), description)
+		if err != nil {
+			s.renderError(w, r, err, "failed to select gauges", http.StatusInternalServerError)
+			return
+		}
+
+		var removed []string
+		err = s.database.UpdateJob(description, func(prev, job core.JobDescription) error {
+			for code := range prev.Gauges {
+				if _, ok := job.Gauges[code]; !ok {
+					removed = append(removed, code)
+				}
+			}
+			description = job
+			return s.scheduler.UpdateJob(job)
+		})
+		if err != nil {
+			s.renderError(w, r, err, "failed to update job", http.StatusInternalServerError)
+			return
+		}
+		// statuses of gauges that are still in the job are kept
+		if err := s.cache.DeleteGaugeStatuses(jobID, removed); err != nil {
+			s.logger.WithField("id", jobID).Warnf("failed to delete statuses of removed gauges: %v", err)
+		}
+
+		s.logger.WithField("codes", core.GaugesCodes(description.Gauges)).
+			WithField("script", description.Script).
+			WithField("id", description.ID).
+			Info("updated job")
+
+		render.JSON(w, r, description)
+	}
+}
+
 func (s *Server) handleDeleteJob() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		jobID := chi.URLParam(r, "jobId")
@@ -46,6 +125,110 @@ func (s *Server) handleDeleteJob() http.HandlerFunc {
 	}
 }
 
+func (s *Server) handleSetJobPaused(paused bool) http.HandlerFunc {
+	action := "resume"
+	if paused {
+		action = "pause"
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID := chi.URLParam(r, "jobId")
+		job, err := s.database.GetJob(jobID)
+		if err != nil {
+			s.renderError(w, r, err, "failed to get job", http.StatusInternalServerError)
+			return
+		}
+		if job == nil {
+			s.renderError(w, r, errors.New("not found"), "not found", http.StatusNotFound)
+			return
+		}
+		// already paused or resumed
+		if job.Paused == paused {
+			render.JSON(w, r, *job)
+			return
+		}
+		err = s.database.SetJobPaused(jobID, paused, func(job core.JobDescription) error {
+			if paused {
+				return s.scheduler.PauseJob(job.ID)
+			}
+			return s.scheduler.ResumeJob(job)
+		})
+		if err != nil {
+			s.renderError(w, r, err, "failed to "+action+" job", http.StatusInternalServerError)
+			return
+		}
+		job.Paused = paused
+		s.logger.WithField("id", jobID).Infof("%sd job", action)
+		render.JSON(w, r, *job)
+	}
+}
+
+func (s *Server) handleRunJob() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID := chi.URLParam(r, "jobId")
+		code := r.URL.Query().Get("code")
+		job, err := s.database.GetJob(jobID)
+		if err != nil {
+			s.renderError(w, r, err, "failed to get job", http.StatusInternalServerError)
+			return
+		}
+		if job == nil {
+			s.renderError(w, r, errors.New("not found"), "not found", http.StatusNotFound)
+			return
+		}
+		if _, ok := job.Gauges[code]; code != "" && !ok {
+			s.renderError(w, r, errors.New("job has no such gauge"), "bad gauge code", http.StatusBadRequest)
+			return
+		}
+		if mode, err := s.registry.GetMode(job.Script); err == nil && mode == core.OneByOne && code == "" {
+			s.renderError(w, r, errors.New("gauge code is required to run one-by-one job"), "gauge code is required", http.StatusBadRequest)
+			return
+		}
+		status, err := s.scheduler.RunJob(jobID, code)
+		if err != nil {
+			s.renderError(w, r, err, "failed to run job", http.StatusInternalServerError)
+			return
+		}
+		s.logger.WithField("id", jobID).
+			WithField("code", code).
+			WithField("count", status.Count).
+			Info("ran job")
+		render.JSON(w, r, status)
+	}
+}
+
+const (
+	defaultJobRunsLimit = 100
+	maxJobRunsLimit     = 1000
+)
+
+func (s *Server) handleListJobRuns() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID := chi.URLParam(r, "jobId")
+		q := r.URL.Query()
+		limit := defaultJobRunsLimit
+		if limitS := q.Get("limit"); limitS != "" {
+			l, err := strconv.Atoi(limitS)
+			if err != nil || l <= 0 || l > maxJobRunsLimit {
+				s.renderError(w, r, errors.New("limit must be between 1 and 1000"), "bad limit", http.StatusBadRequest)
+				return
+			}
+			limit = l
+		}
+		runs, err := s.database.ListJobRuns(jobID, q.Get("code"), limit)
+		if err != nil {
+			s.renderError(w, r, err, "failed to list job runs", http.StatusInternalServerError)
+			return
+		}
+		render.JSON(w, r, runs)
+	}
+}
+
+func (s *Server) handleHarvestStats() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, s.scheduler.HarvestStats())
+	}
+}
+
 func (s *Server) handleGetJob() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		jobID := chi.URLParam(r, "jobId")
@@ -112,3 +295,49 @@ func (s *Server) handleListJobs() http.HandlerFunc {
 		render.JSON(w, r, jobs)
 	}
 }
+
+func (s *Server) handleBackfillJob() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID := chi.URLParam(r, "jobId")
+		job, err := s.database.GetJob(jobID)
+		if err != nil {
+			s.renderError(w, r, err, "failed to get job", http.StatusInternalServerError)
+			return
+		}
+		if job == nil {
+			s.renderError(w, r, errors.New("not found"), "not found", http.StatusNotFound)
+			return
+		}
+		var req core.BackfillRequest
+		if err := render.Bind(r, &req); err != nil {
+			s.renderError(w, r, err, "bad backfill request", http.StatusBadRequest)
+			return
+		}
+		status, err := s.scheduler.Backfill(*job, req)
+		if errors.Is(err, core.ErrBackfillRunning) {
+			s.renderError(w, r, err, "backfill is already running", http.StatusConflict)
+			return
+		} else if err != nil {
+			s.renderError(w, r, err, "failed to start backfill", http.StatusBadRequest)
+			return
+		}
+		s.logger.WithField("id", jobID).
+			WithField("from", req.From).
+			WithField("to", req.To).
+			Info("started backfill")
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, status)
+	}
+}
+
+func (s *Server) handleGetJobBackfill() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobID := chi.URLParam(r, "jobId")
+		status := s.scheduler.GetBackfill(jobID)
+		if status == nil {
+			s.renderError(w, r, errors.New("not found"), "backfill not found", http.StatusNotFound)
+			return
+		}
+		render.JSON(w, r, status)
+	}
+}
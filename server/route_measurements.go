@@ -12,10 +12,19 @@ import (
 	"github.com/whitewater-guide/gorge/storage"
 )
 
+// parseUnits returns unit system requested via "units" query parameter, or empty string if values must be returned as is
+func parseUnits(r *http.Request) (core.UnitSystem, error) {
+	units := r.URL.Query().Get("units")
+	if units == "" {
+		return "", nil
+	}
+	return core.ParseUnitSystem(units)
+}
+
 func (s *Server) handleGetMeasurements() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		script := chi.URLParam(r, "script")
-		code := chi.URLParam(r, "code")
+		code := s.resolveCode(script, chi.URLParam(r, "code"))
 		q := r.URL.Query()
 		toS := q.Get("to")
 		fromS := q.Get("from")
@@ -25,6 +34,11 @@ func (s *Server) handleGetMeasurements() http.HandlerFunc {
 			s.renderError(w, r, err, "failed to create measurements query", http.StatusBadRequest)
 			return
 		}
+		units, err := parseUnits(r)
+		if err != nil {
+			s.renderError(w, r, err, "failed to create measurements query", http.StatusBadRequest)
+			return
+		}
 
 		measurements, err := s.database.GetMeasurements(*query)
 
@@ -33,6 +47,13 @@ func (s *Server) handleGetMeasurements() http.HandlerFunc {
 			return
 		}
 
+		if units != "" {
+			if err := s.units.convert(units, measurements); err != nil {
+				s.renderError(w, r, err, "failed to convert measurements", http.StatusInternalServerError)
+				return
+			}
+		}
+
 		render.JSON(w, r, measurements)
 	}
 }
@@ -40,7 +61,7 @@ func (s *Server) handleGetMeasurements() http.HandlerFunc {
 func (s *Server) handleGetNearest() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		script := chi.URLParam(r, "script")
-		code := chi.URLParam(r, "code")
+		code := s.resolveCode(script, chi.URLParam(r, "code"))
 		q := r.URL.Query()
 
 		toI, err := strconv.ParseInt(q.Get("to"), 10, 64)
@@ -48,6 +69,11 @@ func (s *Server) handleGetNearest() http.HandlerFunc {
 			s.renderError(w, r, err, "failed to get nearest measurement", http.StatusInternalServerError)
 			return
 		}
+		units, err := parseUnits(r)
+		if err != nil {
+			s.renderError(w, r, err, "failed to get nearest measurement", http.StatusBadRequest)
+			return
+		}
 
 		measurement, err := s.database.GetNearestMeasurement(script, code, time.Unix(toI, 0), time.Hour)
 
@@ -56,6 +82,15 @@ func (s *Server) handleGetNearest() http.HandlerFunc {
 			return
 		}
 
+		if units != "" && measurement != nil {
+			converted := []core.Measurement{*measurement}
+			if err := s.units.convert(units, converted); err != nil {
+				s.renderError(w, r, err, "failed to convert measurements", http.StatusInternalServerError)
+				return
+			}
+			measurement = &converted[0]
+		}
+
 		render.JSON(w, r, measurement)
 	}
 }
@@ -63,8 +98,13 @@ func (s *Server) handleGetNearest() http.HandlerFunc {
 func (s *Server) handleGetLatest() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		script := chi.URLParam(r, "script")
-		code := chi.URLParam(r, "code")
+		code := s.resolveCode(script, chi.URLParam(r, "code"))
 		scripts := r.URL.Query().Get("scripts")
+		units, err := parseUnits(r)
+		if err != nil {
+			s.renderError(w, r, err, "failed to get latest measurements", http.StatusBadRequest)
+			return
+		}
 		q := map[string]core.StringSet{}
 		if script != "" && code != "" {
 			q[script] = core.StringSet{code: {}}
@@ -76,16 +116,38 @@ func (s *Server) handleGetLatest() http.HandlerFunc {
 			}
 		}
 		measurements, err := s.cache.LoadLatestMeasurements(q)
+		if err != nil {
+			s.renderError(w, r, err, "failed to get latest measurements", http.StatusInternalServerError)
+			return
+		}
+		if code == "" {
+			measurements = s.resolveLatest(measurements)
+		}
 		res := make([]core.Measurement, len(measurements))
 		i := 0
 		for _, m := range measurements {
 			res[i] = m
 			i++
 		}
+		if units != "" {
+			if err := s.units.convert(units, res); err != nil {
+				s.renderError(w, r, err, "failed to convert measurements", http.StatusInternalServerError)
+				return
+			}
+		}
+		render.JSON(w, r, res)
+	}
+}
+
+func (s *Server) handleGetRevisions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		script := chi.URLParam(r, "script")
+		code := s.resolveCode(script, chi.URLParam(r, "code"))
+		revisions, err := s.database.ListMeasurementRevisions(script, code)
 		if err != nil {
-			s.renderError(w, r, err, "failed to get latest measurements", http.StatusInternalServerError)
+			s.renderError(w, r, err, "failed to list measurement revisions", http.StatusInternalServerError)
 			return
 		}
-		render.JSON(w, r, res)
+		render.JSON(w, r, revisions)
 	}
 }
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+func (s *Server) handleListRatingCurves() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		script, code := chi.URLParam(r, "script"), chi.URLParam(r, "code")
+		curves, err := s.database.ListRatingCurves(script, s.resolveCode(script, code))
+		if err != nil {
+			s.renderError(w, r, err, "failed to list rating curves", http.StatusInternalServerError)
+			return
+		}
+		if curves == nil {
+			curves = []core.RatingCurve{}
+		}
+		render.JSON(w, r, curves)
+	}
+}
+
+func (s *Server) handleAddRatingCurve() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var curve core.RatingCurve
+		if err := render.Bind(r, &curve); err != nil {
+			s.renderError(w, r, err, "bad rating curve", http.StatusBadRequest)
+			return
+		}
+		curve.Code = s.resolveCode(curve.Script, curve.Code)
+		id, err := s.database.AddRatingCurve(curve)
+		if err != nil {
+			s.renderError(w, r, err, "failed to add rating curve", http.StatusInternalServerError)
+			return
+		}
+		curve.ID = id
+		s.logger.WithField("script", curve.Script).WithField("code", curve.Code).WithField("ratingId", id).Info("added rating curve")
+		render.JSON(w, r, curve)
+	}
+}
+
+func (s *Server) handleDeleteRatingCurve() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			s.renderError(w, r, err, "bad rating curve id", http.StatusBadRequest)
+			return
+		}
+		found, err := s.database.DeleteRatingCurve(id)
+		if err != nil {
+			s.renderError(w, r, err, "failed to delete rating curve", http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			s.renderError(w, r, errors.New("not found"), "not found", http.StatusNotFound)
+			return
+		}
+		s.logger.WithField("ratingId", id).Info("deleted rating curve")
+		render.JSON(w, r, map[string]interface{}{"success": true})
+	}
+}
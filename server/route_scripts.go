@@ -3,7 +3,9 @@ package main
 import (
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/whitewater-guide/gorge/core"
 )
 
 func (s *Server) handleListScripts() http.HandlerFunc {
@@ -11,3 +13,15 @@ func (s *Server) handleListScripts() http.HandlerFunc {
 		render.JSON(w, r, s.registry.List())
 	}
 }
+
+func (s *Server) handleGetScriptSchema() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := chi.URLParam(r, "name")
+		schema, err := s.registry.GetSchema(name)
+		if err == core.ErrScriptNotFound {
+			s.renderError(w, r, core.WrapErr(err, "script not found").With("script", name), "script not found", http.StatusNotFound)
+			return
+		}
+		render.JSON(w, r, schema)
+	}
+}
@@ -16,7 +16,19 @@ import (
 
 func (s *Server) handleUpstreamGauges() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		errorMsg := "failed to list gauges"
 		name := chi.URLParam(r, "script")
+		ctx := r.Context()
+		if timeoutS := r.URL.Query().Get("timeout"); timeoutS != "" {
+			timeout, err := strconv.ParseInt(timeoutS, 10, 64)
+			if err != nil {
+				s.renderError(w, r, core.WrapErr(err, "failed to parse timeout query parameter").With("timeout", timeoutS), errorMsg, http.StatusBadRequest)
+				return
+			}
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+			defer cancel()
+		}
 
 		script, _, err := s.registry.CreateFromReader(name, r.Body)
 
@@ -29,12 +41,17 @@ func (s *Server) handleUpstreamGauges() http.HandlerFunc {
 			return
 		}
 
-		result, err := script.ListGauges()
+		result, err := script.ListGauges(ctx)
 		sort.Sort(result)
 		if err != nil {
-			s.renderError(w, r, err, "failed to list gauges", http.StatusInternalServerError)
+			s.renderError(w, r, err, errorMsg, http.StatusInternalServerError)
 			return
 		}
+		for i := range result {
+			if err := core.Units.ValidateGauge(&result[i]); err != nil {
+				s.logger.Warn(err)
+			}
+		}
 
 		render.JSON(w, r, result)
 	}
@@ -81,11 +98,18 @@ func (s *Server) handleUpstreamMeasurements() http.HandlerFunc {
 
 		in := make(chan *core.Measurement)
 		errCh := make(chan error, 1)
-		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		ctx, cancel := context.WithTimeout(r.Context(), time.Minute)
 		defer cancel()
 		var out <-chan *core.Measurement = in
 
-		go script.Harvest(ctx, in, errCh, codes, since)
+		spec := core.HarvestSpec{Codes: codes, Since: core.SinceMap{}}
+		// since is same for every requested code
+		if since > 0 {
+			for code := range codes {
+				spec.Since[code] = since
+			}
+		}
+		go script.Harvest(ctx, in, errCh, spec)
 		if len(codes) >= 1 {
 			out = core.FilterMeasurements(ctx, in, logger, core.CodesFilter{Codes: codes})
 		}
@@ -33,6 +33,7 @@ type Server struct {
 	registry  *core.ScriptRegistry
 	router    *chi.Mux
 	scheduler core.JobScheduler
+	units     *gaugeUnits
 	debug     bool
 }
 
@@ -51,20 +52,46 @@ func (s *Server) routes() {
 	s.router.Route(s.endpoint, func(r chi.Router) {
 		r.Get("/version", s.handleVersion())
 		r.Get("/scripts", s.handleListScripts())
+		r.Get("/scripts/{name}/schema", s.handleGetScriptSchema())
+		r.Get("/filters", s.handleListFilters())
 
 		r.Post("/upstream/{script}/gauges", s.handleUpstreamGauges())
 		r.Post("/upstream/{script}/measurements", s.handleUpstreamMeasurements())
 
 		r.Get("/jobs", s.handleListJobs())
+		r.Get("/harvests/stats", s.handleHarvestStats())
 		r.Get("/jobs/{jobId}", s.handleGetJob())
 		r.Get("/jobs/{jobId}/gauges", s.handleGetJobGauges())
+		r.Get("/jobs/{jobId}/backfill", s.handleGetJobBackfill())
+		r.Post("/jobs/{jobId}/backfill", s.handleBackfillJob())
+		r.Post("/jobs/{jobId}/run", s.handleRunJob())
+		r.Get("/jobs/{jobId}/runs", s.handleListJobRuns())
+		r.Post("/jobs/{jobId}/pause", s.handleSetJobPaused(true))
+		r.Post("/jobs/{jobId}/resume", s.handleSetJobPaused(false))
 		r.Post("/jobs", s.handleAddJob())
+		r.Post("/jobs/validate", s.handleValidateJob())
+		r.Put("/jobs/{jobId}", s.handleUpdateJob())
 		r.Delete("/jobs/{jobId}", s.handleDeleteJob())
 
+		r.Get("/aliases", s.handleListAliases())
+		r.Get("/aliases/{script}", s.handleListAliases())
+		r.Post("/aliases", s.handleAddAlias())
+		r.Delete("/aliases/{script}/{code}", s.handleDeleteAlias())
+
+		r.Get("/ratings/{script}", s.handleListRatingCurves())
+		r.Get("/ratings/{script}/{code}", s.handleListRatingCurves())
+		r.Post("/ratings", s.handleAddRatingCurve())
+		r.Delete("/ratings/{id}", s.handleDeleteRatingCurve())
+
+		r.Get("/gauges/{script}", s.handleListGauges())
+		r.Get("/gauges/{script}/{code}", s.handleGetGauge())
+		r.Get("/gauges/{script}/{code}/history", s.handleGetGaugeHistory())
+
 		r.Get("/measurements/{script}", s.handleGetMeasurements())
 		r.Get("/measurements/{script}/{code}", s.handleGetMeasurements())
 		r.Get("/measurements/{script}/{code}/latest", s.handleGetLatest())
 		r.Get("/measurements/{script}/{code}/nearest", s.handleGetNearest())
+		r.Get("/measurements/{script}/{code}/revisions", s.handleGetRevisions())
 		r.Get("/measurements/latest", s.handleGetLatest())
 	})
 	if s.debug {
@@ -90,6 +117,7 @@ func newServer(p ServerParams) *Server {
 		scheduler: p.Scheduler,
 		logger:    p.Logger,
 	}
+	result.units = newGaugeUnits(p.Db, result.logger.WithField("module", "units"))
 
 	core.Client = core.NewClient(p.Cfg.HTTP, result.logger.WithField("client", "http"))
 
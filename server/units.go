@@ -0,0 +1,56 @@
+package main
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/whitewater-guide/gorge/core"
+	"github.com/whitewater-guide/gorge/storage"
+)
+
+// gaugeUnits provides units of gauges, so that measurements can be converted to requested unit system
+// Units are read from gauge catalog, upstreams are never queried
+type gaugeUnits struct {
+	database storage.DatabaseManager
+	logger   *logrus.Entry
+}
+
+func newGaugeUnits(database storage.DatabaseManager, logger *logrus.Entry) *gaugeUnits {
+	return &gaugeUnits{
+		database: database,
+		logger:   logger,
+	}
+}
+
+func (u *gaugeUnits) getGauges(script string) (map[string]core.Gauge, error) {
+	list, err := u.database.ListGauges(script)
+	if err != nil {
+		return nil, core.WrapErr(err, "failed to list catalog gauges").With("script", script)
+	}
+	gauges := make(map[string]core.Gauge, len(list))
+	for _, g := range list {
+		gauges[g.Code] = g
+	}
+	return gauges, nil
+}
+
+// convert converts measurements in place to given unit system
+// Values which cannot be converted, for example of gauges that are not in catalog, are reset to null
+func (u *gaugeUnits) convert(sys core.UnitSystem, measurements []core.Measurement) error {
+	byScript := map[string]map[string]core.Gauge{}
+	for i := range measurements {
+		m := &measurements[i]
+		gauges, ok := byScript[m.Script]
+		if !ok {
+			var err error
+			gauges, err = u.getGauges(m.Script)
+			if err != nil {
+				return err
+			}
+			byScript[m.Script] = gauges
+		}
+		g := gauges[m.Code]
+		if err := core.Units.ConvertMeasurement(m, &g, sys); err != nil {
+			u.logger.WithField("script", m.Script).WithField("code", m.Code).Warnf("failed to convert measurement: %v", err)
+		}
+	}
+	return nil
+}
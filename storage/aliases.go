@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// migrateAliasQuery moves measurements of old code to new code, unless new code already has measurement with same timestamp
+// Parameters are numbered in order of appearance, because sqlite binds them this way
+const migrateAliasQuery = `UPDATE measurements SET code = $1
+WHERE script = $2 AND code = $3 AND NOT EXISTS (
+	SELECT 1 FROM measurements m2 WHERE m2.script = $4 AND m2.code = $5 AND m2.timestamp = measurements.timestamp
+)`
+
+const upsertAliasQuery = `INSERT INTO gauge_aliases (script, old_code, new_code) VALUES ($1, $2, $3)
+ON CONFLICT (script, old_code) DO UPDATE SET new_code = excluded.new_code`
+
+// ListAliases implements DatabaseManager interface
+func (mgr *DbManager) ListAliases(script string) ([]core.GaugeAlias, error) {
+	return listAliases(mgr.db, script)
+}
+
+// AddAlias implements DatabaseManager interface
+func (mgr *DbManager) AddAlias(alias core.GaugeAlias) (int, error) {
+	tx, err := mgr.db.Beginx()
+	if err != nil {
+		return 0, core.WrapErr(err, "failed to begin add alias transaction")
+	}
+	aliases, err := listAliases(tx, alias.Script)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	codes := core.NewCodeAliases(alias.Script, aliases)
+	codes[alias.OldCode] = alias.NewCode
+	target, err := codes.Resolve(alias.OldCode)
+	if err != nil {
+		tx.Rollback()
+		return 0, core.WrapErr(err, "failed to add alias").With("script", alias.Script).With("newCode", alias.NewCode)
+	}
+
+	if _, err := tx.Exec(upsertAliasQuery, alias.Script, alias.OldCode, alias.NewCode); err != nil {
+		tx.Rollback()
+		return 0, core.WrapErr(err, "failed to save alias").With("script", alias.Script).With("oldCode", alias.OldCode)
+	}
+	res, err := tx.Exec(migrateAliasQuery, target, alias.Script, alias.OldCode, alias.Script, target)
+	if err != nil {
+		tx.Rollback()
+		return 0, core.WrapErr(err, "failed to migrate measurements").With("script", alias.Script).With("oldCode", alias.OldCode)
+	}
+	migrated, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, core.WrapErr(err, "failed to count migrated measurements")
+	}
+	// these are duplicates of measurements that new code already has
+	if _, err := tx.Exec("DELETE FROM measurements WHERE script = $1 AND code = $2", alias.Script, alias.OldCode); err != nil {
+		tx.Rollback()
+		return 0, core.WrapErr(err, "failed to delete duplicate measurements").With("script", alias.Script).With("oldCode", alias.OldCode)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, core.WrapErr(err, "failed to commit add alias transaction")
+	}
+	return int(migrated), nil
+}
+
+// DeleteAlias implements DatabaseManager interface
+func (mgr *DbManager) DeleteAlias(script, oldCode string) (bool, error) {
+	res, err := mgr.db.Exec("DELETE FROM gauge_aliases WHERE script = $1 AND old_code = $2", script, oldCode)
+	if err != nil {
+		return false, core.WrapErr(err, "failed to delete alias").With("script", script).With("oldCode", oldCode)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, core.WrapErr(err, "failed to count deleted aliases")
+	}
+	return cnt > 0, nil
+}
+
+func listAliases(q queryer, script string) ([]core.GaugeAlias, error) {
+	result := []core.GaugeAlias{}
+	var err error
+	if script == "" {
+		err = q.Select(&result, "SELECT script, old_code, new_code FROM gauge_aliases ORDER BY script, old_code")
+	} else {
+		err = q.Select(&result, "SELECT script, old_code, new_code FROM gauge_aliases WHERE script = $1 ORDER BY old_code", script)
+	}
+	if err != nil {
+		return nil, core.WrapErr(err, "failed to list aliases").With("script", script)
+	}
+	return result, nil
+}
@@ -101,6 +101,9 @@ func (cache *BboltCacheManager) saveStatusAt(jobID, code string, err error, coun
 		if e := sub.Put([]byte(prefix+":error"), []byte(errStr)); e != nil {
 			return e
 		}
+		if e := sub.Put([]byte(prefix+":retries"), []byte("0")); e != nil {
+			return e
+		}
 		if count > 0 {
 			if e := sub.Put([]byte(prefix+":success"), []byte(ts.Format(time.RFC3339))); e != nil {
 				return e
@@ -115,6 +118,25 @@ func (cache *BboltCacheManager) SaveStatus(jobID, code string, err error, count
 	return cache.saveStatusAt(jobID, code, err, count, time.Now().UTC())
 }
 
+// SaveRetry implements CacheManager interface.
+func (cache *BboltCacheManager) SaveRetry(jobID, code string, attempt int) error {
+	subBucketName, prefix := "jobs", jobID
+	if code != "" {
+		subBucketName, prefix = jobID, code
+	}
+	return cache.db.Update(func(tx *bbolt.Tx) error {
+		statusBucket := tx.Bucket([]byte(NSStatus))
+		if statusBucket == nil {
+			return errors.New("status bucket not found")
+		}
+		sub, e := statusBucket.CreateBucketIfNotExists([]byte(subBucketName))
+		if e != nil {
+			return e
+		}
+		return sub.Put([]byte(prefix+":retries"), []byte(strconv.Itoa(attempt)))
+	})
+}
+
 func (cache *BboltCacheManager) loadStatuses(subBucketName string) (map[string]core.Status, error) {
 	m := make(map[string]string)
 	err := cache.db.View(func(tx *bbolt.Tx) error {
@@ -138,6 +160,61 @@ func (cache *BboltCacheManager) loadStatuses(subBucketName string) (map[string]c
 	return parseStatusFields(m)
 }
 
+// DeleteGaugeStatuses implements CacheManager interface.
+func (cache *BboltCacheManager) DeleteGaugeStatuses(jobID string, codes []string) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	return cache.db.Update(func(tx *bbolt.Tx) error {
+		statusBucket := tx.Bucket([]byte(NSStatus))
+		if statusBucket == nil {
+			return nil
+		}
+		sub := statusBucket.Bucket([]byte(jobID))
+		if sub == nil {
+			return nil
+		}
+		for _, code := range codes {
+			for _, prop := range statusProps {
+				if e := sub.Delete([]byte(code + ":" + prop)); e != nil {
+					return e
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// MoveGaugeStatus implements CacheManager interface.
+func (cache *BboltCacheManager) MoveGaugeStatus(jobID, oldCode, newCode string) error {
+	if oldCode == newCode {
+		return nil
+	}
+	return cache.db.Update(func(tx *bbolt.Tx) error {
+		statusBucket := tx.Bucket([]byte(NSStatus))
+		if statusBucket == nil {
+			return nil
+		}
+		sub := statusBucket.Bucket([]byte(jobID))
+		if sub == nil {
+			return nil
+		}
+		keep := sub.Get([]byte(newCode+":time")) != nil
+		for _, prop := range statusProps {
+			oldKey := []byte(oldCode + ":" + prop)
+			if v := sub.Get(oldKey); v != nil && !keep {
+				if e := sub.Put([]byte(newCode+":"+prop), append([]byte{}, v...)); e != nil {
+					return e
+				}
+			}
+			if e := sub.Delete(oldKey); e != nil {
+				return e
+			}
+		}
+		return nil
+	})
+}
+
 // LoadJobStatuses implements CacheManager interface.
 func (cache *BboltCacheManager) LoadJobStatuses() (map[string]core.Status, error) {
 	return cache.loadStatuses("jobs")
@@ -210,7 +287,7 @@ func (cache *BboltCacheManager) SaveLatestMeasurements(ctx context.Context, in <
 				if !ok {
 					break outer
 				}
-				if !m.Flow.Valid() && !m.Level.Valid() {
+				if !m.HasValues() {
 					continue
 				}
 				if e, ok := byGauge[m.GaugeID]; ok {
@@ -250,3 +327,34 @@ func (cache *BboltCacheManager) SaveLatestMeasurements(ctx context.Context, in <
 	}()
 	return errCh
 }
+
+// MoveLatestMeasurement implements CacheManager interface.
+func (cache *BboltCacheManager) MoveLatestMeasurement(script, oldCode, newCode string) error {
+	if oldCode == newCode {
+		return nil
+	}
+	return cache.db.Update(func(tx *bbolt.Tx) error {
+		latestBucket := tx.Bucket([]byte(NSLatest))
+		if latestBucket == nil {
+			return nil
+		}
+		scriptBucket := latestBucket.Bucket([]byte(script))
+		if scriptBucket == nil {
+			return nil
+		}
+		oldRaw := scriptBucket.Get([]byte(oldCode))
+		if oldRaw == nil {
+			return nil
+		}
+		raw, move, err := movedLatest(oldRaw, scriptBucket.Get([]byte(newCode)), newCode)
+		if err != nil {
+			return err
+		}
+		if move {
+			if err := scriptBucket.Put([]byte(newCode), raw); err != nil {
+				return err
+			}
+		}
+		return scriptBucket.Delete([]byte(oldCode))
+	})
+}
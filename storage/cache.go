@@ -46,6 +46,9 @@ const (
 	NSLatest = "latest"
 )
 
+// statusProps are fields of single job/gauge status
+var statusProps = []string{"time", "success", "count", "error", "retries"}
+
 // parseStatusFields converts a flat key→value map of "<id>:<prop>" fields
 // into a map of core.Status values. Shared by Redis and bbolt implementations.
 func parseStatusFields(m map[string]string) (map[string]core.Status, error) {
@@ -74,6 +77,12 @@ func parseStatusFields(m map[string]string) (map[string]core.Status, error) {
 				return nil, core.WrapErr(err, fmt.Sprintf("failed parse count '%s' from cache", value))
 			}
 			status.Count = count
+		case "retries":
+			retries, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, core.WrapErr(err, fmt.Sprintf("failed parse retries '%s' from cache", value))
+			}
+			status.Retries = retries
 		case "error":
 			status.Error = value
 		}
@@ -139,6 +148,7 @@ func (cache *RedisCacheManager) saveStatusWithTime(jobID, code string, err error
 		fmt.Sprintf("%s:time", prefix), ts.Format(time.RFC3339),
 		fmt.Sprintf("%s:count", prefix), strconv.Itoa(count),
 		fmt.Sprintf("%s:error", prefix), errStr, // always set to override previous error
+		fmt.Sprintf("%s:retries", prefix), "0",
 	}
 	// in case of error count is 0, so we do not overwrite success, keeping last success timestamp
 	if count > 0 {
@@ -157,6 +167,78 @@ func (cache *RedisCacheManager) SaveStatus(jobID, code string, err error, count
 	return cache.saveStatusWithTime(jobID, code, err, count, time.Now().UTC())
 }
 
+// SaveRetry implements CacheManager interface
+func (cache *RedisCacheManager) SaveRetry(jobID, code string, attempt int) error {
+	conn := cache.pool.Get()
+	defer conn.Close()
+	key, prefix := fmt.Sprintf("%s:jobs", NSStatus), jobID
+	if code != "" {
+		key, prefix = fmt.Sprintf("%s:%s", NSStatus, jobID), code
+	}
+	if _, err := conn.Do("HSET", key, fmt.Sprintf("%s:retries", prefix), strconv.Itoa(attempt)); err != nil {
+		return core.WrapErr(err, "failed to save retry").With("jobID", jobID).With("code", code)
+	}
+	return nil
+}
+
+// DeleteGaugeStatuses implements CacheManager interface
+func (cache *RedisCacheManager) DeleteGaugeStatuses(jobID string, codes []string) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	conn := cache.pool.Get()
+	defer conn.Close()
+	args := []interface{}{fmt.Sprintf("%s:%s", NSStatus, jobID)}
+	for _, code := range codes {
+		for _, prop := range statusProps {
+			args = append(args, fmt.Sprintf("%s:%s", code, prop))
+		}
+	}
+	if _, err := conn.Do("HDEL", args...); err != nil {
+		return core.WrapErr(err, "failed to delete gauge statuses").With("jobID", jobID)
+	}
+	return nil
+}
+
+// MoveGaugeStatus implements CacheManager interface
+func (cache *RedisCacheManager) MoveGaugeStatus(jobID, oldCode, newCode string) error {
+	if oldCode == newCode {
+		return nil
+	}
+	conn := cache.pool.Get()
+	defer conn.Close()
+	key := fmt.Sprintf("%s:%s", NSStatus, jobID)
+	// watch key, so that status saved by concurrent harvest is not overwritten
+	if _, err := conn.Do("WATCH", key); err != nil {
+		return core.WrapErr(err, "failed to watch gauge statuses").With("jobID", jobID)
+	}
+	hdel := []interface{}{key}
+	for _, prop := range statusProps {
+		hdel = append(hdel, fmt.Sprintf("%s:%s", oldCode, prop))
+	}
+	values, err := redis.Values(conn.Do("HMGET", append(hdel, fmt.Sprintf("%s:time", newCode))...))
+	if err != nil {
+		return core.WrapErr(err, "failed to get gauge status").With("jobID", jobID).With("code", oldCode)
+	}
+	conn.Send("MULTI") //nolint:errcheck
+	if values[len(statusProps)] == nil {
+		hmset := []interface{}{key}
+		for i, prop := range statusProps {
+			if values[i] != nil {
+				hmset = append(hmset, fmt.Sprintf("%s:%s", newCode, prop), values[i])
+			}
+		}
+		if len(hmset) > 1 {
+			conn.Send("HMSET", hmset...) //nolint:errcheck
+		}
+	}
+	conn.Send("HDEL", hdel...) //nolint:errcheck
+	if _, err := redis.Values(conn.Do("EXEC")); err != nil {
+		return core.WrapErr(err, "failed to move gauge status").With("jobID", jobID).With("code", oldCode)
+	}
+	return nil
+}
+
 // LoadLatestMeasurements implements CacheManager interface
 func (cache *RedisCacheManager) LoadLatestMeasurements(from map[string]core.StringSet) (map[core.GaugeID]core.Measurement, error) {
 	result := make(map[core.GaugeID]core.Measurement)
@@ -241,7 +323,7 @@ func (cache *RedisCacheManager) SaveLatestMeasurements(ctx context.Context, in <
 				if !ok {
 					break outer
 				}
-				if !m.Flow.Valid() && !m.Level.Valid() {
+				if !m.HasValues() {
 					continue
 				}
 				if e, ok := byGauge[m.GaugeID]; ok {
@@ -290,6 +372,64 @@ func (cache *RedisCacheManager) SaveLatestMeasurements(ctx context.Context, in <
 	return errCh
 }
 
+// MoveLatestMeasurement implements CacheManager interface
+func (cache *RedisCacheManager) MoveLatestMeasurement(script, oldCode, newCode string) error {
+	if oldCode == newCode {
+		return nil
+	}
+	conn := cache.pool.Get()
+	defer conn.Close()
+	key := fmt.Sprintf("%s:%s", NSLatest, script)
+	// watch key, so that measurement saved by concurrent harvest is not overwritten
+	if _, err := conn.Do("WATCH", key); err != nil {
+		return core.WrapErr(err, "failed to watch last measurements").With("script", script)
+	}
+	raws, err := redis.ByteSlices(conn.Do("HMGET", key, oldCode, newCode))
+	if err != nil {
+		return core.WrapErr(err, "failed to get last measurements").With("script", script).With("code", oldCode)
+	}
+	if raws[0] == nil {
+		_, err := conn.Do("UNWATCH")
+		return err
+	}
+	old, move, err := movedLatest(raws[0], raws[1], newCode)
+	if err != nil {
+		conn.Do("UNWATCH") //nolint:errcheck
+		return err
+	}
+	conn.Send("MULTI") //nolint:errcheck
+	if move {
+		conn.Send("HSET", key, newCode, old) //nolint:errcheck
+	}
+	conn.Send("HDEL", key, oldCode) //nolint:errcheck
+	if _, err := redis.Values(conn.Do("EXEC")); err != nil {
+		return core.WrapErr(err, "failed to move last measurement").With("script", script).With("code", oldCode)
+	}
+	return nil
+}
+
+// movedLatest returns latest measurement of old code renamed to new code,
+// and whether it should replace current latest measurement of new code
+// Shared by Redis and bbolt implementations.
+func movedLatest(oldRaw, newRaw []byte, newCode string) ([]byte, bool, error) {
+	var old core.Measurement
+	if err := json.Unmarshal(oldRaw, &old); err != nil {
+		return nil, false, core.WrapErr(err, "failed to unmarshal last measurement").With("value", string(oldRaw))
+	}
+	if newRaw != nil {
+		var current core.Measurement
+		if err := json.Unmarshal(newRaw, &current); err != nil {
+			return nil, false, core.WrapErr(err, "failed to unmarshal last measurement").With("value", string(newRaw))
+		}
+		if !current.Timestamp.Before(old.Timestamp.Time) {
+			return nil, false, nil
+		}
+	}
+	old.Code = newCode
+	raw, _ := json.Marshal(old)
+	return raw, true, nil
+}
+
 // Start implements CacheManager interface
 func (cache *RedisCacheManager) Start() error {
 	cache.pool = &redis.Pool{
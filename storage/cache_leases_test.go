@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisAcquireLease(t *testing.T) {
+	mgr := &EmbeddedCacheManager{}
+	require.NoError(t, mgr.Start())
+	defer mgr.Close()
+
+	acquire := func(key, holder string) bool {
+		ok, err := mgr.AcquireLease(key, holder, time.Minute)
+		require.NoError(t, err)
+		return ok
+	}
+	assert.True(t, acquire("a", "first"))
+	assert.False(t, acquire("a", "second"), "held by another instance")
+	assert.True(t, acquire("a", "first"), "extended by holder")
+	assert.True(t, acquire("b", "second"), "different lease")
+
+	mgr.srv.FastForward(2 * time.Minute)
+	assert.True(t, acquire("a", "second"), "expired lease is taken over")
+	assert.False(t, acquire("a", "first"))
+}
@@ -123,6 +123,61 @@ func (s *cacheStatusSuite) TestLoadGaugeStatusesForInexistingJob() {
 	}
 }
 
+func (s *cacheStatusSuite) TestDeleteGaugeStatuses() {
+	t := s.T()
+	require.NoError(t, s.mgr.DeleteGaugeStatuses(obo, []string{"code_err", "code_missing"}))
+	require.NoError(t, s.mgr.DeleteGaugeStatuses("does_not_exist", []string{"code_ok"}))
+	actual, err := s.mgr.LoadGaugeStatuses(obo)
+	if assert.NoError(t, err) {
+		assert.Len(t, actual, 2)
+		assert.Contains(t, actual, "code_ok")
+		assert.Contains(t, actual, "code_err_only")
+	}
+}
+
+func (s *cacheStatusSuite) TestMoveGaugeStatus() {
+	t := s.T()
+	require.NoError(t, s.mgr.MoveGaugeStatus(obo, "code_ok", "code_new"))
+	require.NoError(t, s.mgr.MoveGaugeStatus(obo, "code_err_only", "code_err"))
+	require.NoError(t, s.mgr.MoveGaugeStatus(obo, "code_missing", "code_new2"))
+	actual, err := s.mgr.LoadGaugeStatuses(obo)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]core.Status{
+			"code_new": {
+				LastRun:     core.HTime{Time: seedT2},
+				LastSuccess: &core.HTime{Time: seedT2},
+				Count:       33,
+			},
+			"code_err": {
+				LastRun:     core.HTime{Time: seedT2},
+				LastSuccess: &core.HTime{Time: seedT1},
+				Count:       0,
+				Error:       "gauge error",
+			},
+		}, actual)
+	}
+}
+
+func (s *cacheStatusSuite) TestSaveRetry() {
+	t := s.T()
+	require.NoError(t, s.mgr.SaveRetry(aErr, "", 2))
+	require.NoError(t, s.mgr.SaveRetry(obo, "code_err", 3))
+	jobs, err := s.mgr.LoadJobStatuses()
+	require.NoError(t, err)
+	assert.Equal(t, 2, jobs[aErr].Retries)
+	assert.Equal(t, "script error", jobs[aErr].Error)
+	gauges, err := s.mgr.LoadGaugeStatuses(obo)
+	require.NoError(t, err)
+	assert.Equal(t, 3, gauges["code_err"].Retries)
+	assert.Equal(t, 0, gauges["code_ok"].Retries)
+
+	// next harvest resets retries
+	require.NoError(t, s.mgr.saveStatusAt(aErr, "", nil, 5, seedT2))
+	jobs, err = s.mgr.LoadJobStatuses()
+	require.NoError(t, err)
+	assert.Equal(t, 0, jobs[aErr].Retries)
+}
+
 func (s *cacheStatusSuite) TestSaveStatus() {
 	t := s.T()
 	nowHTime := core.HTime{Time: now}
@@ -417,6 +472,11 @@ func (s *cacheLatestSuite) TestSaveLatestMeasurements() {
 			Flow:      nulltype.NullFloat64Of(4),
 			Level:     nulltype.NullFloat64Of(4),
 		},
+		{
+			GaugeID:   core.GaugeID{Script: "one_by_one", Code: "o003"},
+			Timestamp: core.HTime{Time: time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			Params:    core.Params{core.Temperature: 12.5},
+		},
 	}
 	ctx := context.Background()
 	in := core.GenFromSlice(ctx, data)
@@ -444,6 +504,7 @@ func (s *cacheLatestSuite) TestSaveLatestMeasurements() {
 	assert.Equal(t, core.Measurement{GaugeID: core.GaugeID{Script: "one_by_one", Code: "o000"}, Timestamp: t2019, Flow: nulltype.NullFloat64Of(4), Level: nulltype.NullFloat64Of(4)}, res[core.GaugeID{Script: "one_by_one", Code: "o000"}])
 	assert.Equal(t, core.Measurement{GaugeID: core.GaugeID{Script: "one_by_one", Code: "o001"}, Timestamp: t2017, Flow: nulltype.NullFloat64Of(1), Level: nulltype.NullFloat64{}}, res[core.GaugeID{Script: "one_by_one", Code: "o001"}])
 	assert.Equal(t, core.Measurement{GaugeID: core.GaugeID{Script: "one_by_one", Code: "o002"}, Timestamp: t2017, Flow: nulltype.NullFloat64Of(2), Level: nulltype.NullFloat64{}}, res[core.GaugeID{Script: "one_by_one", Code: "o002"}])
+	assert.Equal(t, core.Measurement{GaugeID: core.GaugeID{Script: "one_by_one", Code: "o003"}, Timestamp: t2019, Params: core.Params{core.Temperature: 12.5}}, res[core.GaugeID{Script: "one_by_one", Code: "o003"}])
 }
 
 func (s *cacheLatestSuite) TestSaveLatestMeasurementsCanceled() {
@@ -462,6 +523,38 @@ func (s *cacheLatestSuite) TestSaveLatestMeasurementsCanceled() {
 	assert.Empty(t, res)
 }
 
+func (s *cacheLatestSuite) TestMoveLatestMeasurement() {
+	t := s.T()
+	data := []core.Measurement{
+		{
+			GaugeID:   core.GaugeID{Script: "all_at_once", Code: "a010"},
+			Timestamp: core.HTime{Time: time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			Flow:      nulltype.NullFloat64Of(200),
+		},
+		{
+			GaugeID:   core.GaugeID{Script: "all_at_once", Code: "a011"},
+			Timestamp: core.HTime{Time: time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			Flow:      nulltype.NullFloat64Of(300),
+		},
+	}
+	ctx := context.Background()
+	require.NoError(t, <-s.mgr.SaveLatestMeasurements(ctx, core.GenFromSlice(ctx, data)))
+
+	require.NoError(t, s.mgr.MoveLatestMeasurement("all_at_once", "a000", "a_new"))     // to new code
+	require.NoError(t, s.mgr.MoveLatestMeasurement("all_at_once", "a001", "a010"))      // replaces older measurement
+	require.NoError(t, s.mgr.MoveLatestMeasurement("all_at_once", "a002", "a011"))      // newer measurement is kept
+	require.NoError(t, s.mgr.MoveLatestMeasurement("all_at_once", "a_missing", "a012")) // nothing to move
+
+	res, err := s.mgr.LoadLatestMeasurements(map[string]core.StringSet{"all_at_once": {}})
+	require.NoError(t, err)
+	flows := map[string]float64{}
+	for id, m := range res {
+		assert.Equal(t, id.Code, m.Code)
+		flows[id.Code] = m.Flow.Float64Value()
+	}
+	assert.Equal(t, map[string]float64{"a_new": 100, "a010": 101, "a011": 300}, flows)
+}
+
 func (s *cacheLatestSuite) TestGetLatestMeasurements() {
 	t := s.T()
 	tests := []struct {
@@ -21,12 +21,14 @@ type DbManager struct {
 	nearestDayClause string
 	// defaultStart is sql expression for starting period of measurements slice
 	defaultStart string
+	// secondsBetweenClause is sql expression for number of seconds from second timestamp to first one
+	secondsBetweenClause string
 	// saveChunkSize indicates how many measurements will be written in on query
 	// when set to 0, no limit will be enforced, which might lead to hitting max variables limits or other limits
 	saveChunkSize int
 }
 
-const saveMeasurementsQuery = "INSERT INTO measurements (timestamp, script, code, flow, level) VALUES (:timestamp, :script, :code, :flow, :level) ON CONFLICT DO NOTHING"
+const saveMeasurementsQuery = "INSERT INTO measurements (timestamp, script, code, flow, level, params, quality, provisional, flow_derived) VALUES (:timestamp, :script, :code, :flow, :level, :params, :quality, :provisional, :flow_derived) ON CONFLICT DO NOTHING"
 
 // obtainConnection waits for postgres to start, because containers start in random order
 func obtainConnection(driver, address string, timeout, retries int64) (*sqlx.DB, error) {
@@ -56,6 +58,11 @@ func (mgr *DbManager) saveMeasurementsChunk(chunk []*core.Measurement) (int, err
 
 // SaveMeasurements implements DatabaseManager interface
 func (mgr *DbManager) SaveMeasurements(ctx context.Context, in <-chan *core.Measurement) (<-chan int, <-chan error) {
+	return mgr.saveMeasurements(ctx, in, mgr.saveMeasurementsChunk)
+}
+
+// saveMeasurements reads measurements from the channel and writes them in chunks using saveChunk function
+func (mgr *DbManager) saveMeasurements(ctx context.Context, in <-chan *core.Measurement, saveChunk func(chunk []*core.Measurement) (int, error)) (<-chan int, <-chan error) {
 	savedCh := make(chan int, 1)
 	errCh := make(chan error, 1)
 	go func() {
@@ -64,13 +71,13 @@ func (mgr *DbManager) SaveMeasurements(ctx context.Context, in <-chan *core.Meas
 		var chunk []*core.Measurement
 		total, count := 0, 0
 		for m := range core.Cancelable(ctx, in) {
-			if m.Flow.Float64Value() == 0.0 && m.Level.Float64Value() == 0.0 || !m.Flow.Valid() && !m.Level.Valid() {
+			if (m.Flow.Float64Value() == 0.0 && m.Level.Float64Value() == 0.0 || !m.Flow.Valid() && !m.Level.Valid()) && len(m.Params) == 0 {
 				continue
 			}
 			chunk = append(chunk, m)
 			count++
 			if count == mgr.saveChunkSize && mgr.saveChunkSize != 0 {
-				saved, err := mgr.saveMeasurementsChunk(chunk)
+				saved, err := saveChunk(chunk)
 				if err != nil {
 					errCh <- core.WrapErr(err, "failed to save measurements")
 					return
@@ -86,7 +93,7 @@ func (mgr *DbManager) SaveMeasurements(ctx context.Context, in <-chan *core.Meas
 			return
 		default:
 			if count > 0 {
-				saved, err := mgr.saveMeasurementsChunk(chunk)
+				saved, err := saveChunk(chunk)
 				if err != nil {
 					errCh <- core.WrapErr(err, "failed to save measurements")
 				}
@@ -145,7 +152,7 @@ func (mgr *DbManager) GetNearestMeasurement(script, code string, to time.Time, t
 
 // ListJobs implements DatabaseManager interface
 func (mgr *DbManager) ListJobs() ([]core.JobDescription, error) {
-	rows, err := mgr.db.Query("SELECT id, description FROM jobs")
+	rows, err := mgr.db.Query("SELECT id, description, paused FROM jobs")
 	if err != nil {
 		return nil, err
 	}
@@ -154,8 +161,9 @@ func (mgr *DbManager) ListJobs() ([]core.JobDescription, error) {
 	for rows.Next() {
 		var id string
 		var description string
+		var paused bool
 		var job core.JobDescription
-		err := rows.Scan(&id, &description)
+		err := rows.Scan(&id, &description, &paused)
 		if err != nil {
 			rows.Close()
 			return nil, err
@@ -165,6 +173,7 @@ func (mgr *DbManager) ListJobs() ([]core.JobDescription, error) {
 			rows.Close()
 			return nil, err
 		}
+		job.Paused = paused
 		result = append(result, job)
 	}
 
@@ -176,11 +185,15 @@ func (mgr *DbManager) GetJob(id string) (*core.JobDescription, error) {
 	var result struct {
 		ID          string
 		Description *core.JobDescription
+		Paused      bool
 	}
-	err := mgr.db.Get(&result, "SELECT * FROM jobs WHERE id = $1", id)
+	err := mgr.db.Get(&result, "SELECT id, description, paused FROM jobs WHERE id = $1", id)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
+	if result.Description != nil {
+		result.Description.Paused = result.Paused
+	}
 	return result.Description, err
 }
 
@@ -195,7 +208,7 @@ func (mgr *DbManager) AddJob(job core.JobDescription, onSave func(job core.JobDe
 		return core.WrapErr(err, "failed to begin add job transaction")
 	}
 
-	_, err = tx.Exec("INSERT INTO jobs (id, description) VALUES ($1, $2)", job.ID, descr)
+	_, err = tx.Exec("INSERT INTO jobs (id, description, paused) VALUES ($1, $2, $3)", job.ID, descr, job.Paused)
 	if err != nil {
 		tx.Rollback()
 		return core.WrapErr(err, "failed to insert job").With("description", string(descr)).With("id", job.ID)
@@ -249,6 +262,94 @@ func (mgr *DbManager) DeleteJob(id string, onDelete func(id string) error) error
 	return nil
 }
 
+// UpdateJob implements DatabaseManager interface
+func (mgr *DbManager) UpdateJob(job core.JobDescription, onUpdate func(prev, job core.JobDescription) error) error {
+	tx, err := mgr.db.Begin()
+	if err != nil {
+		return core.WrapErr(err, "failed to begin update job transaction")
+	}
+	var prevDescr string
+	var paused bool
+	err = tx.QueryRow("SELECT description, paused FROM jobs WHERE id = $1", job.ID).Scan(&prevDescr, &paused)
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return (&core.Error{Msg: "job not found in database"}).With("jobId", job.ID)
+	} else if err != nil {
+		tx.Rollback()
+		return core.WrapErr(err, "failed to get job").With("jobId", job.ID)
+	}
+	var prev core.JobDescription
+	if err := json.Unmarshal([]byte(prevDescr), &prev); err != nil {
+		tx.Rollback()
+		return core.WrapErr(err, "failed to unmarshal job description").With("jobId", job.ID)
+	}
+	prev.Paused, job.Paused = paused, paused
+
+	descr, err := json.Marshal(job)
+	if err != nil {
+		tx.Rollback()
+		return core.WrapErr(err, "failed to marshal job description")
+	}
+	_, err = tx.Exec("UPDATE jobs SET description = $1 WHERE id = $2", descr, job.ID)
+	if err != nil {
+		tx.Rollback()
+		return core.WrapErr(err, "failed to update job").With("description", string(descr)).With("id", job.ID)
+	}
+
+	updateErr := onUpdate(prev, job)
+	if updateErr != nil {
+		tx.Rollback()
+		return updateErr
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return core.WrapErr(err, "failed to commit update job transaction")
+	}
+	return nil
+}
+
+// SetJobPaused implements DatabaseManager interface
+func (mgr *DbManager) SetJobPaused(id string, paused bool, onChange func(job core.JobDescription) error) error {
+	tx, err := mgr.db.Begin()
+	if err != nil {
+		return core.WrapErr(err, "failed to begin pause job transaction")
+	}
+	var descr string
+	err = tx.QueryRow("SELECT description FROM jobs WHERE id = $1", id).Scan(&descr)
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return (&core.Error{Msg: "job not found in database"}).With("jobId", id)
+	} else if err != nil {
+		tx.Rollback()
+		return core.WrapErr(err, "failed to get job").With("jobId", id)
+	}
+	var job core.JobDescription
+	if err := json.Unmarshal([]byte(descr), &job); err != nil {
+		tx.Rollback()
+		return core.WrapErr(err, "failed to unmarshal job description").With("jobId", id)
+	}
+	job.Paused = paused
+
+	_, err = tx.Exec("UPDATE jobs SET paused = $1 WHERE id = $2", paused, id)
+	if err != nil {
+		tx.Rollback()
+		return core.WrapErr(err, "failed to update job").With("jobId", id)
+	}
+
+	changeErr := onChange(job)
+	if changeErr != nil {
+		tx.Rollback()
+		return changeErr
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return core.WrapErr(err, "failed to commit pause job transaction")
+	}
+	return nil
+}
+
 // Close implements DatabaseManager interface
 func (mgr *DbManager) Close() error {
 	return mgr.db.Close()
@@ -147,6 +147,42 @@ func cleanup(db *sqlx.DB) {
 	if err != nil {
 		log.Fatalf("failed to clean up measurements")
 	}
+	_, err = db.Exec("DELETE FROM gauges")
+	if err != nil {
+		log.Fatalf("failed to clean up gauges")
+	}
+	_, err = db.Exec("DELETE FROM gauge_changes")
+	if err != nil {
+		log.Fatalf("failed to clean up gauge changes")
+	}
+	_, err = db.Exec("DELETE FROM gauge_aliases")
+	if err != nil {
+		log.Fatalf("failed to clean up gauge aliases")
+	}
+	_, err = db.Exec("DELETE FROM measurement_revisions")
+	if err != nil {
+		log.Fatalf("failed to clean up measurement revisions")
+	}
+	_, err = db.Exec("DELETE FROM rating_curves")
+	if err != nil {
+		log.Fatalf("failed to clean up rating curves")
+	}
+	_, err = db.Exec("DELETE FROM leases")
+	if err != nil {
+		log.Fatalf("failed to clean up leases")
+	}
+	_, err = db.Exec("DELETE FROM job_runs")
+	if err != nil {
+		log.Fatalf("failed to clean up job runs")
+	}
+	_, err = db.Exec("DELETE FROM job_run_codes")
+	if err != nil {
+		log.Fatalf("failed to clean up job run codes")
+	}
+	_, err = db.Exec("DELETE FROM publication_intervals")
+	if err != nil {
+		log.Fatalf("failed to clean up publication intervals")
+	}
 }
 
 type DbTestSuite struct {
@@ -370,6 +406,111 @@ func (s *DbTestSuite) TestSaveMeasurements() {
 
 }
 
+func (s *DbTestSuite) TestReviseMeasurements() {
+	t := s.T()
+	s.SetupTest()
+	ts := core.HTime{Time: time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	gauge := core.GaugeID{Script: "all_at_once", Code: "a003"}
+	save := func(save func(context.Context, <-chan *core.Measurement) (<-chan int, <-chan error), ms ...core.Measurement) int {
+		savedCh, errCh := save(context.Background(), core.GenFromSlice(context.Background(), ms))
+		cnt, err := <-savedCh, <-errCh
+		assert.NoError(t, err)
+		return cnt
+	}
+	get := func() core.Measurement {
+		actual, err := s.mgr.GetMeasurements(MeasurementsQuery{Script: gauge.Script, Code: gauge.Code, From: date(2018, time.December, 31)})
+		if assert.NoError(t, err) && assert.Len(t, actual, 1) {
+			return actual[0]
+		}
+		return core.Measurement{}
+	}
+
+	provisional := core.Measurement{GaugeID: gauge, Timestamp: ts, Level: nulltype.NullFloat64Of(10), Provisional: true, Quality: "e"}
+	assert.Equal(t, 1, save(s.mgr.ReviseMeasurements, provisional))
+	assert.Equal(t, "e", get().Quality)
+	assert.True(t, get().Provisional)
+
+	// insert mode ignores corrections
+	corrected := core.Measurement{GaugeID: gauge, Timestamp: ts, Level: nulltype.NullFloat64Of(11)}
+	assert.Equal(t, 0, save(s.mgr.SaveMeasurements, corrected))
+	actual := get()
+	assert.Equal(t, 10.0, actual.Level.Float64Value())
+
+	// same values are not revisions
+	assert.Equal(t, 0, save(s.mgr.ReviseMeasurements, provisional))
+
+	assert.Equal(t, 1, save(s.mgr.ReviseMeasurements, corrected))
+	actual = get()
+	assert.Equal(t, 11.0, actual.Level.Float64Value())
+	assert.False(t, actual.Provisional)
+	assert.Equal(t, "", actual.Quality)
+
+	// approved values are not replaced with provisional
+	assert.Equal(t, 0, save(s.mgr.ReviseMeasurements, provisional))
+	actual = get()
+	assert.Equal(t, 11.0, actual.Level.Float64Value())
+
+	revisions, err := s.mgr.ListMeasurementRevisions(gauge.Script, gauge.Code)
+	if assert.NoError(t, err) && assert.Len(t, revisions, 1) {
+		assert.Equal(t, 10.0, revisions[0].Previous.Level.Float64Value())
+		assert.True(t, revisions[0].Previous.Provisional)
+		assert.Equal(t, 11.0, revisions[0].Current.Level.Float64Value())
+		assert.False(t, revisions[0].RevisedAt.IsZero())
+	}
+
+	// chunk with new, revised, unchanged and repeated measurements of different gauges
+	other := core.GaugeID{Script: "all_at_once", Code: "a004"}
+	later := core.HTime{Time: ts.Add(time.Hour)}
+	assert.Equal(t, 3, save(
+		s.mgr.ReviseMeasurements,
+		core.Measurement{GaugeID: gauge, Timestamp: ts, Level: nulltype.NullFloat64Of(12)},
+		core.Measurement{GaugeID: gauge, Timestamp: later, Level: nulltype.NullFloat64Of(13)},
+		core.Measurement{GaugeID: other, Timestamp: ts, Level: nulltype.NullFloat64Of(14)},
+		core.Measurement{GaugeID: other, Timestamp: ts, Level: nulltype.NullFloat64Of(14)},
+	))
+	revisions, err = s.mgr.ListMeasurementRevisions(gauge.Script, gauge.Code)
+	if assert.NoError(t, err) && assert.Len(t, revisions, 2) {
+		assert.Equal(t, 11.0, revisions[0].Previous.Level.Float64Value())
+		assert.Equal(t, 12.0, revisions[0].Current.Level.Float64Value())
+	}
+}
+
+func (s *DbTestSuite) TestSaveMeasurementsParams() {
+	t := s.T()
+	s.SetupTest()
+	ts := core.HTime{Time: time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	input := []core.Measurement{
+		{
+			GaugeID:   core.GaugeID{Script: "all_at_once", Code: "a003"},
+			Timestamp: ts,
+			Level:     nulltype.NullFloat64Of(10),
+			Params:    core.Params{core.Temperature: 12.5, core.Velocity: 1.2},
+		},
+		{
+			GaugeID:   core.GaugeID{Script: "all_at_once", Code: "a004"},
+			Timestamp: ts,
+			Params:    core.Params{core.Rainfall: 3},
+		},
+	}
+	in := core.GenFromSlice(context.Background(), input)
+	savedCh, errCh := s.mgr.SaveMeasurements(context.Background(), in)
+	cnt := <-savedCh
+	err := <-errCh
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, cnt)
+		for _, m := range input {
+			actual, err := s.mgr.GetMeasurements(MeasurementsQuery{Script: m.Script, Code: m.Code, From: date(2018, time.December, 31)})
+			if assert.NoError(t, err) && assert.Len(t, actual, 1) {
+				assert.Equal(t, m.Params, actual[0].Params)
+			}
+		}
+		seeded, err := s.mgr.GetMeasurements(MeasurementsQuery{Script: "all_at_once", Code: "a001", From: date(2018, time.January, 1)})
+		if assert.NoError(t, err) && assert.NotEmpty(t, seeded) {
+			assert.Nil(t, seeded[0].Params)
+		}
+	}
+}
+
 func (s *DbTestSuite) TestSaveMeasurementsChunks() {
 	t := s.T()
 
@@ -617,3 +758,367 @@ func (s *DbTestSuite) TestGetNearestMeasurement() {
 	}
 
 }
+
+func (s *DbTestSuite) TestSaveGauges() {
+	t := s.T()
+	g1 := core.Gauge{GaugeID: core.GaugeID{Script: "all_at_once", Code: "a001"}, Name: "Gauge 1", LevelUnit: "m"}
+	g2 := core.Gauge{GaugeID: core.GaugeID{Script: "all_at_once", Code: "a002"}, Name: "Gauge 2", FlowUnit: "m3/s"}
+	g3 := core.Gauge{GaugeID: core.GaugeID{Script: "all_at_once", Code: "a003"}, Name: "Gauge 3", Timezone: "UTC"}
+
+	refreshed, err := s.mgr.GetCatalogRefreshTime("one_by_one")
+	if assert.NoError(t, err) {
+		assert.True(t, refreshed.IsZero())
+	}
+
+	before := time.Now().Add(-time.Second)
+	changes, err := s.mgr.SaveGauges("all_at_once", []core.Gauge{g2, g1})
+	if assert.NoError(t, err) {
+		assert.Len(t, changes, 2)
+	}
+	refreshed, err = s.mgr.GetCatalogRefreshTime("all_at_once")
+	if assert.NoError(t, err) {
+		assert.True(t, refreshed.After(before))
+	}
+
+	g2Moved := g2
+	g2Moved.Location = &core.Location{Latitude: 10, Longitude: 20}
+	g2Moved.URL = "http://example.com"
+	changes, err = s.mgr.SaveGauges("all_at_once", []core.Gauge{g2Moved, g3})
+	if assert.NoError(t, err) && assert.Len(t, changes, 3) {
+		assert.Equal(t, core.GaugeRemoved, changes[0].Kind)
+		assert.Equal(t, core.GaugeChanged, changes[1].Kind)
+		assert.Equal(t, []string{"location"}, changes[1].Fields)
+		assert.Equal(t, core.GaugeAdded, changes[2].Kind)
+	}
+
+	gauges, err := s.mgr.ListGauges("all_at_once")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []core.Gauge{g2Moved, g3}, gauges)
+	}
+	gauges, err = s.mgr.ListGauges("one_by_one")
+	if assert.NoError(t, err) {
+		assert.Empty(t, gauges)
+	}
+
+	g, err := s.mgr.GetGauge("all_at_once", "a002")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &g2Moved, g)
+	}
+	g, err = s.mgr.GetGauge("all_at_once", "a001")
+	if assert.NoError(t, err) {
+		assert.Nil(t, g)
+	}
+
+	history, err := s.mgr.ListGaugeChanges("all_at_once", "a002")
+	if assert.NoError(t, err) && assert.Len(t, history, 2) {
+		assert.Equal(t, core.GaugeChanged, history[0].Kind)
+		assert.Equal(t, &g2, history[0].Previous)
+		assert.Equal(t, &g2Moved, history[0].Gauge)
+		assert.Equal(t, core.GaugeAdded, history[1].Kind)
+	}
+	history, err = s.mgr.ListGaugeChanges("all_at_once", "a001")
+	if assert.NoError(t, err) && assert.Len(t, history, 2) {
+		assert.Equal(t, core.GaugeRemoved, history[0].Kind)
+		assert.Equal(t, &g1, history[0].Gauge)
+	}
+}
+
+func (s *DbTestSuite) TestAddAlias() {
+	t := s.T()
+	// a003 has measurement with same timestamp as a001, it must not overwrite it
+	_, err := s.mgr.db.Exec(
+		"INSERT INTO measurements (timestamp, script, code, flow, level) VALUES ($1, 'all_at_once', 'a003', 1, 1), ($2, 'all_at_once', 'a003', 2, 2)",
+		core.HTime{Time: *date(2018, time.January, 1)},
+		core.HTime{Time: *date(2018, time.January, 2)},
+	)
+	s.Require().NoError(err)
+
+	migrated, err := s.mgr.AddAlias(core.GaugeAlias{Script: "all_at_once", OldCode: "a002", NewCode: "a001"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, migrated)
+	}
+	migrated, err = s.mgr.AddAlias(core.GaugeAlias{Script: "all_at_once", OldCode: "a003", NewCode: "a002"})
+	if assert.NoError(t, err) {
+		// chain a003 -> a002 -> a001, measurement on 2018-01-01 is duplicate
+		assert.Equal(t, 0, migrated)
+	}
+	_, err = s.mgr.AddAlias(core.GaugeAlias{Script: "all_at_once", OldCode: "a001", NewCode: "a003"})
+	assert.Error(t, err, "cycles are not allowed")
+
+	aliases, err := s.mgr.ListAliases("all_at_once")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []core.GaugeAlias{
+			{Script: "all_at_once", OldCode: "a002", NewCode: "a001"},
+			{Script: "all_at_once", OldCode: "a003", NewCode: "a002"},
+		}, aliases)
+	}
+
+	from, to := date(2018, time.January, 1), date(2018, time.January, 3)
+	old, err := s.mgr.GetMeasurements(MeasurementsQuery{Script: "all_at_once", Code: "a002", From: from, To: to})
+	if assert.NoError(t, err) {
+		assert.Empty(t, old)
+	}
+	old, err = s.mgr.GetMeasurements(MeasurementsQuery{Script: "all_at_once", Code: "a003", From: from, To: to})
+	if assert.NoError(t, err) {
+		assert.Empty(t, old)
+	}
+	current, err := s.mgr.GetMeasurements(MeasurementsQuery{Script: "all_at_once", Code: "a001", From: from, To: to})
+	if assert.NoError(t, err) && assert.Len(t, current, 3) {
+		assert.Equal(t, 333.0, current[1].Flow.Float64Value())
+		assert.Equal(t, 100.0, current[2].Flow.Float64Value())
+	}
+
+	deleted, err := s.mgr.DeleteAlias("all_at_once", "a003")
+	if assert.NoError(t, err) {
+		assert.True(t, deleted)
+	}
+	deleted, err = s.mgr.DeleteAlias("all_at_once", "a003")
+	if assert.NoError(t, err) {
+		assert.False(t, deleted)
+	}
+}
+
+func (s *DbTestSuite) TestRatingCurves() {
+	t := s.T()
+	validFrom := core.HTime{Time: *date(2018, time.January, 1)}
+	power := core.RatingCurve{
+		GaugeID:   core.GaugeID{Script: "all_at_once", Code: "a001"},
+		ValidFrom: &validFrom,
+		Power:     &core.PowerLaw{C: 10, H0: 0.5, B: 1.5},
+	}
+	table := core.RatingCurve{
+		GaugeID: core.GaugeID{Script: "all_at_once", Code: "a002"},
+		Table:   []core.RatingPoint{{Level: 0, Flow: 0}, {Level: 1, Flow: 10}},
+	}
+	id1, err := s.mgr.AddRatingCurve(power)
+	s.Require().NoError(err)
+	id2, err := s.mgr.AddRatingCurve(table)
+	s.Require().NoError(err)
+	assert.NotEqual(t, id1, id2)
+	power.ID, table.ID = id1, id2
+
+	curves, err := s.mgr.ListRatingCurves("all_at_once", "")
+	if assert.NoError(t, err) && assert.Len(t, curves, 2) {
+		assert.Equal(t, power.Power, curves[0].Power)
+		assert.True(t, validFrom.Equal(curves[0].ValidFrom.Time))
+		assert.Equal(t, table, curves[1])
+	}
+	curves, err = s.mgr.ListRatingCurves("all_at_once", "a002")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []core.RatingCurve{table}, curves)
+	}
+
+	deleted, err := s.mgr.DeleteRatingCurve(id2)
+	if assert.NoError(t, err) {
+		assert.True(t, deleted)
+	}
+	deleted, err = s.mgr.DeleteRatingCurve(id2)
+	if assert.NoError(t, err) {
+		assert.False(t, deleted)
+	}
+}
+
+func (s *DbTestSuite) TestAcquireLease() {
+	t := s.T()
+	acquire := func(key, holder string, ttl time.Duration) bool {
+		ok, err := s.mgr.AcquireLease(key, holder, ttl)
+		s.Require().NoError(err)
+		return ok
+	}
+	assert.True(t, acquire("a", "first", time.Minute))
+	assert.False(t, acquire("a", "second", time.Minute), "held by another instance")
+	assert.True(t, acquire("a", "first", time.Minute), "extended by holder")
+	assert.True(t, acquire("b", "second", time.Minute), "different lease")
+
+	assert.True(t, acquire("c", "first", 0))
+	assert.True(t, acquire("c", "second", time.Minute), "expired lease is taken over")
+	assert.False(t, acquire("c", "first", time.Minute))
+}
+
+func (s *DbTestSuite) TestSetJobPaused() {
+	t := s.T()
+	const id = "0d67638c-2189-11ea-978f-2e728ce88125"
+	var changed core.JobDescription
+	err := s.mgr.SetJobPaused(id, true, func(job core.JobDescription) error {
+		changed = job
+		return nil
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, id, changed.ID)
+		assert.True(t, changed.Paused)
+	}
+	job, err := s.mgr.GetJob(id)
+	if assert.NoError(t, err) && assert.NotNil(t, job) {
+		assert.True(t, job.Paused)
+	}
+
+	err = s.mgr.SetJobPaused(id, false, func(job core.JobDescription) error {
+		return errors.New("boom")
+	})
+	assert.Error(t, err)
+	jobs, err := s.mgr.ListJobs()
+	if assert.NoError(t, err) {
+		for _, j := range jobs {
+			assert.Equal(t, j.ID == id, j.Paused, "job %s", j.ID)
+		}
+	}
+
+	err = s.mgr.SetJobPaused("b2162fe8-218a-11ea-978f-2e728ce88125", true, func(job core.JobDescription) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+func (s *DbTestSuite) TestUpdateJob() {
+	t := s.T()
+	const id = "0d67638c-2189-11ea-978f-2e728ce88125"
+	s.Require().NoError(s.mgr.SetJobPaused(id, true, func(job core.JobDescription) error { return nil }))
+	input := core.JobDescription{
+		ID:      id,
+		Script:  "all_at_once",
+		Gauges:  map[string]json.RawMessage{"a003": []byte("{}")},
+		Cron:    "9 * * * *",
+		Options: json.RawMessage(`{"foo": "qux"}`),
+	}
+
+	err := s.mgr.UpdateJob(input, func(prev, job core.JobDescription) error {
+		return errors.New("boom")
+	})
+	if assert.Error(t, err) {
+		job, err := s.mgr.GetJob(id)
+		if assert.NoError(t, err) && assert.NotNil(t, job) {
+			assert.Equal(t, "one_by_one", job.Script)
+		}
+	}
+
+	var prevJob, newJob core.JobDescription
+	err = s.mgr.UpdateJob(input, func(prev, job core.JobDescription) error {
+		prevJob, newJob = prev, job
+		return nil
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "one_by_one", prevJob.Script)
+		assert.True(t, newJob.Paused, "paused flag is kept")
+		job, err := s.mgr.GetJob(id)
+		if assert.NoError(t, err) && assert.NotNil(t, job) {
+			assert.Equal(t, "9 * * * *", job.Cron)
+			assert.True(t, job.Paused)
+		}
+		assert.Equal(t, 2, countJobs(s.mgr.db))
+	}
+
+	input.ID = "b2162fe8-218a-11ea-978f-2e728ce88125"
+	err = s.mgr.UpdateJob(input, func(prev, job core.JobDescription) error { return nil })
+	assert.Error(t, err)
+}
+
+func (s *DbTestSuite) TestJobRuns() {
+	t := s.T()
+	const id = "0d67638c-2189-11ea-978f-2e728ce88125"
+	start := time.Date(2020, time.May, 1, 10, 0, 0, 0, time.UTC)
+	runs := []core.JobRun{
+		{JobID: id, Script: "one_by_one", Code: "g000", StartedAt: core.HTime{Time: start}, Saved: 1},
+		{JobID: id, Script: "one_by_one", Code: "g001", StartedAt: core.HTime{Time: start.Add(time.Minute)}, Error: "boom"},
+		{
+			JobID:     id,
+			Script:    "one_by_one",
+			Code:      "g000",
+			StartedAt: core.HTime{Time: start.Add(time.Hour)},
+			Duration:  core.Duration{Duration: 2 * time.Second},
+			Harvested: 3,
+			Filters:   []core.FilterStats{{Name: "latest", In: 3, Out: 2}},
+			Saved:     2,
+			Cached:    2,
+		},
+		{JobID: "b2162fe8-218a-11ea-978f-2e728ce88125", Script: "all_at_once", StartedAt: core.HTime{Time: start}},
+	}
+	for _, r := range runs {
+		s.Require().NoError(s.mgr.SaveJobRun(r))
+	}
+
+	actual, err := s.mgr.ListJobRuns(id, "", 10)
+	if assert.NoError(t, err) && assert.Len(t, actual, 3) {
+		assert.NotZero(t, actual[0].ID)
+		actual[0].ID = 0
+		assert.True(t, start.Add(time.Hour).Equal(actual[0].StartedAt.Time))
+		actual[0].StartedAt = runs[2].StartedAt
+		assert.Equal(t, runs[2], actual[0])
+		assert.Equal(t, "boom", actual[1].Error)
+	}
+	actual, err = s.mgr.ListJobRuns(id, "g000", 1)
+	if assert.NoError(t, err) && assert.Len(t, actual, 1) {
+		assert.Equal(t, 2, actual[0].Saved)
+	}
+	const batchedID = "c3273af9-218a-11ea-978f-2e728ce88125"
+	s.Require().NoError(s.mgr.SaveJobRun(core.JobRun{JobID: batchedID, Script: "batched", Codes: []string{"g000", "g001"}, StartedAt: core.HTime{Time: start}}))
+	s.Require().NoError(s.mgr.SaveJobRun(core.JobRun{JobID: batchedID, Script: "batched", Codes: []string{"g002"}, StartedAt: core.HTime{Time: start}}))
+	actual, err = s.mgr.ListJobRuns(batchedID, "g001", 10)
+	if assert.NoError(t, err) && assert.Len(t, actual, 1) {
+		assert.Equal(t, []string{"g000", "g001"}, actual[0].Codes)
+	}
+	actual, err = s.mgr.ListJobRuns("b2162fe8-218a-11ea-978f-2e728ce88125", "a000", 10)
+	if assert.NoError(t, err) {
+		assert.Empty(t, actual, "runs of all-at-once jobs are not listed by code")
+	}
+
+	deleted, err := s.mgr.DeleteJobRuns(start.Add(30 * time.Minute))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 5, deleted)
+	}
+	var codes int
+	s.Require().NoError(s.mgr.db.Get(&codes, "SELECT count(*) FROM job_run_codes"))
+	assert.Zero(t, codes, "codes of deleted runs are deleted")
+	actual, err = s.mgr.ListJobRuns(id, "", 10)
+	if assert.NoError(t, err) {
+		assert.Len(t, actual, 1)
+	}
+}
+
+func (s *DbTestSuite) TestListMeasurementIntervals() {
+	t := s.T()
+	s.SetupTest()
+	start := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
+	offsets := map[string][]time.Duration{
+		"i000": {0, 15 * time.Minute, 30 * time.Minute, 45 * time.Minute, 3 * time.Hour}, // outage does not matter
+		"i001": {0, time.Hour, 3 * time.Hour},
+		"i002": {0},
+		"i003": {-time.Hour, 0, 5 * time.Minute}, // older measurements are not used
+	}
+	var ms []core.Measurement
+	for code, offs := range offsets {
+		for _, off := range offs {
+			ms = append(ms, core.Measurement{
+				GaugeID:   core.GaugeID{Script: "one_by_one", Code: code},
+				Timestamp: core.HTime{Time: start.Add(off)},
+				Level:     nulltype.NullFloat64Of(1),
+			})
+		}
+	}
+	_, errCh := s.mgr.SaveMeasurements(context.Background(), core.GenFromSlice(context.Background(), ms))
+	s.Require().NoError(<-errCh)
+
+	actual, err := s.mgr.ListMeasurementIntervals("one_by_one", []string{"i000", "i001", "i002", "i003", "i004"}, start)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]time.Duration{
+			"i000": 15 * time.Minute,
+			"i001": 2 * time.Hour,
+			"i003": 5 * time.Minute,
+		}, actual)
+	}
+	actual, err = s.mgr.ListMeasurementIntervals("one_by_one", nil, start)
+	if assert.NoError(t, err) {
+		assert.Empty(t, actual)
+	}
+}
+
+func (s *DbTestSuite) TestPublicationIntervals() {
+	t := s.T()
+	s.SetupTest()
+	s.Require().NoError(s.mgr.SavePublicationIntervals("one_by_one", map[string]time.Duration{"g000": 5 * time.Minute, "g001": time.Hour}))
+	s.Require().NoError(s.mgr.SavePublicationIntervals("one_by_one", map[string]time.Duration{"g001": 15 * time.Minute}))
+	s.Require().NoError(s.mgr.SavePublicationIntervals("all_at_once", map[string]time.Duration{"g000": 24 * time.Hour}))
+	actual, err := s.mgr.ListPublicationIntervals("one_by_one")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]time.Duration{"g000": 5 * time.Minute, "g001": 15 * time.Minute}, actual)
+	}
+}
@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/whitewater-guide/gorge/core"
+)
+
+const upsertGaugeQuery = `INSERT INTO gauges (script, code, gauge, updated_at) VALUES ($1, $2, $3, $4)
+ON CONFLICT (script, code) DO UPDATE SET gauge = excluded.gauge, updated_at = excluded.updated_at`
+
+// SaveGauges implements DatabaseManager interface
+func (mgr *DbManager) SaveGauges(script string, gauges []core.Gauge) ([]core.GaugeChange, error) {
+	tx, err := mgr.db.Beginx()
+	if err != nil {
+		return nil, core.WrapErr(err, "failed to begin save gauges transaction")
+	}
+	previous, err := listGauges(tx, script)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	now := core.HTime{Time: time.Now().UTC()}
+	changes := core.DiffGauges(previous, gauges)
+
+	for _, g := range gauges {
+		raw, err := json.Marshal(g)
+		if err != nil {
+			tx.Rollback()
+			return nil, core.WrapErr(err, "failed to marshal gauge").With("script", script).With("code", g.Code)
+		}
+		if _, err := tx.Exec(upsertGaugeQuery, script, g.Code, string(raw), now); err != nil {
+			tx.Rollback()
+			return nil, core.WrapErr(err, "failed to save gauge").With("script", script).With("code", g.Code)
+		}
+	}
+	for i := range changes {
+		c := &changes[i]
+		c.Timestamp = now
+		if c.Kind == core.GaugeRemoved {
+			if _, err := tx.Exec("DELETE FROM gauges WHERE script = $1 AND code = $2", script, c.Code); err != nil {
+				tx.Rollback()
+				return nil, core.WrapErr(err, "failed to delete gauge").With("script", script).With("code", c.Code)
+			}
+		}
+		raw, err := json.Marshal(c)
+		if err != nil {
+			tx.Rollback()
+			return nil, core.WrapErr(err, "failed to marshal gauge change").With("script", script).With("code", c.Code)
+		}
+		_, err = tx.Exec(
+			"INSERT INTO gauge_changes (timestamp, script, code, kind, change) VALUES ($1, $2, $3, $4, $5)",
+			now, script, c.Code, string(c.Kind), string(raw),
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, core.WrapErr(err, "failed to save gauge change").With("script", script).With("code", c.Code)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, core.WrapErr(err, "failed to commit save gauges transaction")
+	}
+	return changes, nil
+}
+
+// ListGauges implements DatabaseManager interface
+func (mgr *DbManager) ListGauges(script string) ([]core.Gauge, error) {
+	return listGauges(mgr.db, script)
+}
+
+// GetGauge implements DatabaseManager interface
+func (mgr *DbManager) GetGauge(script, code string) (*core.Gauge, error) {
+	var raw string
+	err := mgr.db.Get(&raw, "SELECT gauge FROM gauges WHERE script = $1 AND code = $2", script, code)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, core.WrapErr(err, "failed to get gauge").With("script", script).With("code", code)
+	}
+	var g core.Gauge
+	if err := json.Unmarshal([]byte(raw), &g); err != nil {
+		return nil, core.WrapErr(err, "failed to unmarshal gauge").With("script", script).With("code", code)
+	}
+	return &g, nil
+}
+
+// GetCatalogRefreshTime implements DatabaseManager interface
+func (mgr *DbManager) GetCatalogRefreshTime(script string) (time.Time, error) {
+	var updated core.HTime
+	err := mgr.db.Get(&updated, "SELECT updated_at FROM gauges WHERE script = $1 ORDER BY updated_at DESC LIMIT 1", script)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, core.WrapErr(err, "failed to get catalog refresh time").With("script", script)
+	}
+	return updated.Time, nil
+}
+
+// ListGaugeChanges implements DatabaseManager interface
+func (mgr *DbManager) ListGaugeChanges(script, code string) ([]core.GaugeChange, error) {
+	var rows []string
+	err := mgr.db.Select(&rows, "SELECT change FROM gauge_changes WHERE script = $1 AND code = $2 ORDER BY timestamp DESC, id DESC", script, code)
+	if err != nil {
+		return nil, core.WrapErr(err, "failed to list gauge changes").With("script", script).With("code", code)
+	}
+	result := make([]core.GaugeChange, len(rows))
+	for i, raw := range rows {
+		if err := json.Unmarshal([]byte(raw), &result[i]); err != nil {
+			return nil, core.WrapErr(err, "failed to unmarshal gauge change").With("script", script).With("code", code)
+		}
+	}
+	return result, nil
+}
+
+func listGauges(q queryer, script string) ([]core.Gauge, error) {
+	var rows []string
+	if err := q.Select(&rows, "SELECT gauge FROM gauges WHERE script = $1", script); err != nil {
+		return nil, core.WrapErr(err, "failed to list gauges").With("script", script)
+	}
+	result := make([]core.Gauge, len(rows))
+	for i, raw := range rows {
+		if err := json.Unmarshal([]byte(raw), &result[i]); err != nil {
+			return nil, core.WrapErr(err, "failed to unmarshal gauge").With("script", script)
+		}
+	}
+	sort.Sort(core.Gauges(result))
+	return result, nil
+}
+
+// queryer is common interface of sqlx.DB and sqlx.Tx
+type queryer interface {
+	Select(dest interface{}, query string, args ...interface{}) error
+}
@@ -22,20 +22,84 @@ type DatabaseManager interface {
 	// DeleteJon stops running job and deletes it
 	// onDelete argument is used to ensure transactional behavior when adding job to scheduler
 	DeleteJob(id string, onDelete func(id string) error) error
+	// UpdateJob replaces description of existing job, paused flag is kept
+	// onUpdate is called with previous and new descriptions, and if it returns error, job is not changed
+	UpdateJob(job core.JobDescription, onUpdate func(prev, job core.JobDescription) error) error
+	// SetJobPaused persists paused flag of job
+	// onChange is called with updated job, and if it returns error, flag is not changed
+	SetJobPaused(id string, paused bool, onChange func(job core.JobDescription) error) error
 
 	// SaveMeasurements saves measurements from the channel in db, until the channel is closed
 	// It supports context cancelation
 	// returns channel where one single int will be written: total number of saved mesurements
 	SaveMeasurements(ctx context.Context, in <-chan *core.Measurement) (<-chan int, <-chan error)
+	// ReviseMeasurements is same as SaveMeasurements, but measurements that are already stored are replaced if upstream has corrected them
+	// Replaced values are recorded in revisions history
+	ReviseMeasurements(ctx context.Context, in <-chan *core.Measurement) (<-chan int, <-chan error)
+	// ListMeasurementRevisions returns revisions of gauge's measurements, newest first
+	ListMeasurementRevisions(script, code string) ([]core.MeasurementRevision, error)
 	// GetMeasurements returns measurements stored in db
 	GetMeasurements(query MeasurementsQuery) ([]core.Measurement, error)
 	// GetNearestMeasurement returns nearest measurement to timestamp (without interpolation)
 	GetNearestMeasurement(script, code string, to time.Time, tolerance time.Duration) (*core.Measurement, error)
 
+	// ListMeasurementIntervals returns median interval between measurements of given gauges of script stored since given time, keyed by gauge code
+	// Gauges with less than two measurements are omitted
+	ListMeasurementIntervals(script string, codes []string, from time.Time) (map[string]time.Duration, error)
+	// SavePublicationIntervals saves publication intervals of script's gauges learned from stored measurements, keyed by gauge code
+	SavePublicationIntervals(script string, intervals map[string]time.Duration) error
+	// ListPublicationIntervals returns learned publication intervals of script's gauges, keyed by gauge code
+	ListPublicationIntervals(script string) (map[string]time.Duration, error)
+
+	// SaveGauges replaces catalog of script's gauges with gauges listed from upstream and records changes in history
+	// returns recorded changes
+	SaveGauges(script string, gauges []core.Gauge) ([]core.GaugeChange, error)
+	// ListGauges returns catalog gauges of script sorted by code
+	ListGauges(script string) ([]core.Gauge, error)
+	// GetGauge returns catalog gauge, or nil if it's not found
+	GetGauge(script, code string) (*core.Gauge, error)
+	// GetCatalogRefreshTime returns time when catalog of script was last saved, or zero time if catalog is empty
+	GetCatalogRefreshTime(script string) (time.Time, error)
+	// ListGaugeChanges returns catalog history of gauge, newest changes first
+	ListGaugeChanges(script, code string) ([]core.GaugeChange, error)
+
+	// ListAliases returns gauge code aliases of script, or of all scripts if script is empty
+	ListAliases(script string) ([]core.GaugeAlias, error)
+	// AddAlias saves gauge code alias and moves stored measurements of old code to new code
+	// returns number of migrated measurements
+	AddAlias(alias core.GaugeAlias) (int, error)
+	// DeleteAlias deletes alias, already migrated measurements are not moved back
+	// returns false if alias was not found
+	DeleteAlias(script, oldCode string) (bool, error)
+
+	// ListRatingCurves returns rating curves of script, or of one gauge if code is not empty
+	ListRatingCurves(script, code string) ([]core.RatingCurve, error)
+	// AddRatingCurve saves rating curve and returns its id
+	AddRatingCurve(curve core.RatingCurve) (int64, error)
+	// DeleteRatingCurve deletes rating curve, returns false if it was not found
+	DeleteRatingCurve(id int64) (bool, error)
+
+	// SaveJobRun records harvest run in job history
+	SaveJobRun(run core.JobRun) error
+	// ListJobRuns returns at most limit latest runs of job, newest first
+	// If code is not empty, only runs that harvested this gauge are returned: runs of one-by-one job gauge and runs of batches that contained it
+	ListJobRuns(jobID, code string, limit int) ([]core.JobRun, error)
+	// DeleteJobRuns deletes runs that started before given time
+	// returns number of deleted runs
+	DeleteJobRuns(before time.Time) (int, error)
+
 	// Close is called when db should be shut down
 	Close() error
 }
 
+// LeaseManager grants time-limited exclusive leases. It's used to coordinate multiple gorge instances
+// that share same database and cache, so that every harvest runs on one instance only
+type LeaseManager interface {
+	// AcquireLease returns true if lease with given key was acquired or extended by holder
+	// returns false if lease is held by another holder and has not expired yet
+	AcquireLease(key, holder string, ttl time.Duration) (bool, error)
+}
+
 // CacheManager manager is used to store latest measurement for each gauge and auxiliary information that is safe to lose
 type CacheManager interface {
 	// Starts cache manager
@@ -49,6 +113,14 @@ type CacheManager interface {
 	// SaveStatus saves harvest status for entire job (if code is empty) or single gauge
 	// count means number of saved measurements
 	SaveStatus(jobID, code string, err error, count int) error
+	// SaveRetry records number of retries made after failed harvest of entire job (if code is empty) or single gauge
+	// It must be called after status of retried harvest is saved, because saving status resets retries
+	SaveRetry(jobID, code string, attempt int) error
+	// DeleteGaugeStatuses deletes statuses of given gauges of the job, statuses of other gauges are kept
+	DeleteGaugeStatuses(jobID string, codes []string) error
+	// MoveGaugeStatus moves status of gauge of the job from old code to new code, used when gauge is renamed in upstream
+	// Status of new code is kept if it already exists, status of old code is deleted anyway
+	MoveGaugeStatus(jobID, oldCode, newCode string) error
 
 	// LoadLatestMeasurements returns latest measurements
 	// it accepts a map where keys are scripts (not job ids!) and values are sets of gauge codes
@@ -57,6 +129,9 @@ type CacheManager interface {
 	// Input measurements are supposed to be filtered against previous latest values from cache
 	// This is done inside job (it also ensures we don't save dupe measurements in db)
 	SaveLatestMeasurements(ctx context.Context, in <-chan *core.Measurement) <-chan error
+	// MoveLatestMeasurement moves latest measurement of gauge from old code to new code, used when gauge is renamed in upstream
+	// Latest measurement of new code is kept if it's not older, latest measurement of old code is deleted anyway
+	MoveLatestMeasurement(script, oldCode, newCode string) error
 
 	// Close is callled when cache must be shut down
 	Close() error
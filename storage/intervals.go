@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// measurementIntervalsQuery selects median gap between consecutive measurements of every gauge
+// Gaps are numbered in ascending order, and gap in the middle (upper one for even count) is selected
+// It has two placeholders: seconds between clause and list of code parameters
+const measurementIntervalsQuery = `WITH gaps AS (
+	SELECT code, %s AS gap FROM (
+		SELECT code, timestamp, LAG(timestamp) OVER (PARTITION BY code ORDER BY timestamp) AS prev
+		FROM measurements WHERE script = $1 AND timestamp >= $2 AND code IN (%s)
+	) t WHERE prev IS NOT NULL
+), ranked AS (
+	SELECT code, gap, ROW_NUMBER() OVER (PARTITION BY code ORDER BY gap) AS rn, COUNT(*) OVER (PARTITION BY code) AS cnt FROM gaps
+)
+SELECT code, gap FROM ranked WHERE rn = cnt / 2 + 1`
+
+// ListMeasurementIntervals implements DatabaseManager interface
+func (mgr *DbManager) ListMeasurementIntervals(script string, codes []string, from time.Time) (map[string]time.Duration, error) {
+	result := make(map[string]time.Duration, len(codes))
+	if len(codes) == 0 {
+		return result, nil
+	}
+	params := make([]string, len(codes))
+	args := []interface{}{script, from.UTC()}
+	for i, code := range codes {
+		params[i] = fmt.Sprintf("$%d", i+3)
+		args = append(args, code)
+	}
+	var rows []struct {
+		Code string  `db:"code"`
+		Gap  float64 `db:"gap"`
+	}
+	q := fmt.Sprintf(measurementIntervalsQuery, fmt.Sprintf(mgr.secondsBetweenClause, "timestamp", "prev"), strings.Join(params, ", "))
+	if err := mgr.db.Select(&rows, q, args...); err != nil {
+		return nil, core.WrapErr(err, "failed to list measurement intervals").With("script", script)
+	}
+	for _, row := range rows {
+		// sqlite computes gaps from julian days, which are not precise
+		if interval := time.Duration(math.Round(row.Gap)) * time.Second; interval > 0 {
+			result[row.Code] = interval
+		}
+	}
+	return result, nil
+}
+
+// SavePublicationIntervals implements DatabaseManager interface
+func (mgr *DbManager) SavePublicationIntervals(script string, intervals map[string]time.Duration) error {
+	tx, err := mgr.db.Beginx()
+	if err != nil {
+		return core.WrapErr(err, "failed to begin publication intervals transaction")
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	for code, interval := range intervals {
+		_, err := tx.Exec(
+			`INSERT INTO publication_intervals (script, code, interval_seconds, learned_at) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (script, code) DO UPDATE SET interval_seconds = excluded.interval_seconds, learned_at = excluded.learned_at`,
+			script, code, int64(interval.Seconds()), now,
+		)
+		if err != nil {
+			tx.Rollback()
+			return core.WrapErr(err, "failed to save publication interval").With("script", script).With("code", code)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return core.WrapErr(err, "failed to commit publication intervals transaction")
+	}
+	return nil
+}
+
+// ListPublicationIntervals implements DatabaseManager interface
+func (mgr *DbManager) ListPublicationIntervals(script string) (map[string]time.Duration, error) {
+	var rows []struct {
+		Code     string `db:"code"`
+		Interval int64  `db:"interval_seconds"`
+	}
+	if err := mgr.db.Select(&rows, "SELECT code, interval_seconds FROM publication_intervals WHERE script = $1", script); err != nil {
+		return nil, core.WrapErr(err, "failed to list publication intervals").With("script", script)
+	}
+	result := make(map[string]time.Duration, len(rows))
+	for _, row := range rows {
+		result[row.Code] = time.Duration(row.Interval) * time.Second
+	}
+	return result, nil
+}
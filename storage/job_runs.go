@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// SaveJobRun implements DatabaseManager interface
+func (mgr *DbManager) SaveJobRun(run core.JobRun) error {
+	run.ID = 0
+	// sqlite stores timestamps as text, which compare correctly only in same zone
+	run.StartedAt = core.HTime{Time: run.StartedAt.UTC()}
+	raw, err := json.Marshal(run)
+	if err != nil {
+		return core.WrapErr(err, "failed to marshal job run").With("jobId", run.JobID)
+	}
+	tx, err := mgr.db.Beginx()
+	if err != nil {
+		return core.WrapErr(err, "failed to begin save job run transaction")
+	}
+	var id int64
+	err = tx.QueryRow(
+		"INSERT INTO job_runs (job_id, code, started_at, run) VALUES ($1, $2, $3, $4) RETURNING id",
+		run.JobID, run.Code, run.StartedAt.Time, string(raw),
+	).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		return core.WrapErr(err, "failed to save job run").With("jobId", run.JobID).With("code", run.Code)
+	}
+	for _, code := range run.Codes {
+		if _, err := tx.Exec("INSERT INTO job_run_codes (run_id, code) VALUES ($1, $2)", id, code); err != nil {
+			tx.Rollback()
+			return core.WrapErr(err, "failed to save job run code").With("jobId", run.JobID).With("code", code)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return core.WrapErr(err, "failed to commit save job run transaction")
+	}
+	return nil
+}
+
+// ListJobRuns implements DatabaseManager interface
+func (mgr *DbManager) ListJobRuns(jobID, code string, limit int) ([]core.JobRun, error) {
+	var rows []struct {
+		ID  int64  `db:"id"`
+		Run string `db:"run"`
+	}
+	q, args := "SELECT id, run FROM job_runs WHERE job_id = $1 ORDER BY started_at DESC, id DESC LIMIT $2", []interface{}{jobID, limit}
+	if code != "" {
+		// runs of batches have empty code, their codes are stored separately
+		q, args = `SELECT id, run FROM job_runs WHERE job_id = $1 AND (
+			code = $2 OR id IN (SELECT run_id FROM job_run_codes WHERE code = $3)
+		) ORDER BY started_at DESC, id DESC LIMIT $4`, []interface{}{jobID, code, code, limit}
+	}
+	if err := mgr.db.Select(&rows, q, args...); err != nil {
+		return nil, core.WrapErr(err, "failed to list job runs").With("jobId", jobID).With("code", code)
+	}
+	result := make([]core.JobRun, len(rows))
+	for i, row := range rows {
+		if err := json.Unmarshal([]byte(row.Run), &result[i]); err != nil {
+			return nil, core.WrapErr(err, "failed to unmarshal job run").With("id", row.ID)
+		}
+		result[i].ID = row.ID
+	}
+	return result, nil
+}
+
+// DeleteJobRuns implements DatabaseManager interface
+func (mgr *DbManager) DeleteJobRuns(before time.Time) (int, error) {
+	tx, err := mgr.db.Beginx()
+	if err != nil {
+		return 0, core.WrapErr(err, "failed to begin delete job runs transaction")
+	}
+	_, err = tx.Exec("DELETE FROM job_run_codes WHERE run_id IN (SELECT id FROM job_runs WHERE started_at < $1)", before.UTC())
+	if err != nil {
+		tx.Rollback()
+		return 0, core.WrapErr(err, "failed to delete job run codes")
+	}
+	res, err := tx.Exec("DELETE FROM job_runs WHERE started_at < $1", before.UTC())
+	if err != nil {
+		tx.Rollback()
+		return 0, core.WrapErr(err, "failed to delete job runs")
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, core.WrapErr(err, "failed to count deleted job runs")
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, core.WrapErr(err, "failed to commit delete job runs transaction")
+	}
+	return int(cnt), nil
+}
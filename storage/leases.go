@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// NSLease is redis namespace prefix for leases
+const NSLease = "lease"
+
+// acquireLeaseScript sets lease if it does not exist, or extends it if it's held by same holder
+// Script is executed atomically, so lease cannot be taken over between check and update
+var acquireLeaseScript = redis.NewScript(1, `
+local current = redis.call('GET', KEYS[1])
+if not current then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return 1
+end
+if current == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// AcquireLease implements LeaseManager interface
+// Expired leases of all holders are cleaned up on the way
+func (mgr *DbManager) AcquireLease(key, holder string, ttl time.Duration) (bool, error) {
+	// sqlite stores timestamps as text, which compare correctly only in same zone and precision
+	now := time.Now().UTC().Truncate(time.Second)
+	res, err := mgr.db.Exec(
+		`INSERT INTO leases (name, holder, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at
+		WHERE leases.holder = excluded.holder OR leases.expires_at <= $4`,
+		key, holder, now.Add(ttl), now,
+	)
+	if err != nil {
+		return false, core.WrapErr(err, "failed to acquire lease").With("lease", key)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, core.WrapErr(err, "failed to acquire lease").With("lease", key)
+	}
+	if _, err := mgr.db.Exec("DELETE FROM leases WHERE expires_at <= $1", now); err != nil {
+		return cnt > 0, core.WrapErr(err, "failed to clean up expired leases")
+	}
+	return cnt > 0, nil
+}
+
+// AcquireLease implements LeaseManager interface
+func (cache *RedisCacheManager) AcquireLease(key, holder string, ttl time.Duration) (bool, error) {
+	conn := cache.pool.Get()
+	defer conn.Close()
+	key = NSLease + ":" + key
+	acquired, err := redis.Bool(acquireLeaseScript.Do(conn, key, holder, ttl.Milliseconds()))
+	if err != nil {
+		return false, core.WrapErr(err, "failed to acquire lease").With("lease", key)
+	}
+	return acquired, nil
+}
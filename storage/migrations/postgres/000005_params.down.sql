@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE measurements DROP COLUMN IF EXISTS params;
+
+COMMIT;
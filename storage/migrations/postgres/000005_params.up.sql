@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Values of parameters other than flow and level, e.g. {"temperature": 10.5}
+ALTER TABLE measurements ADD COLUMN IF NOT EXISTS params jsonb;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS gauge_changes;
+DROP TABLE IF EXISTS gauges;
+
+COMMIT;
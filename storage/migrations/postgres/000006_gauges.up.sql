@@ -0,0 +1,27 @@
+BEGIN;
+
+-- Catalog of gauges listed from upstream, refreshed periodically
+CREATE TABLE IF NOT EXISTS gauges
+(
+    script varchar(255) not null,
+    code varchar(255) not null,
+    gauge jsonb not null,
+    updated_at timestamp with time zone not null,
+    PRIMARY KEY (script, code)
+);
+
+-- History of catalog changes: added, removed and changed gauges
+CREATE TABLE IF NOT EXISTS gauge_changes
+(
+    id bigserial PRIMARY KEY,
+    timestamp timestamp with time zone not null,
+    script varchar(255) not null,
+    code varchar(255) not null,
+    kind varchar(16) not null,
+    change jsonb not null
+);
+
+CREATE INDEX IF NOT EXISTS gauge_changes_script_code_idx
+    ON gauge_changes (script, code, timestamp desc);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS gauge_aliases;
+
+COMMIT;
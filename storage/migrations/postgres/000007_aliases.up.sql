@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Gauge codes renamed in upstream: measurements of old_code are stored under new_code
+CREATE TABLE IF NOT EXISTS gauge_aliases
+(
+    script varchar(255) not null,
+    old_code varchar(255) not null,
+    new_code varchar(255) not null,
+    PRIMARY KEY (script, old_code)
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS measurement_revisions;
+ALTER TABLE measurements DROP COLUMN IF EXISTS provisional;
+ALTER TABLE measurements DROP COLUMN IF EXISTS quality;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Upstream's data quality code and approval flag
+ALTER TABLE measurements ADD COLUMN IF NOT EXISTS quality varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE measurements ADD COLUMN IF NOT EXISTS provisional boolean NOT NULL DEFAULT false;
+
+-- History of stored measurements replaced by values corrected in upstream
+CREATE TABLE IF NOT EXISTS measurement_revisions
+(
+    id bigserial PRIMARY KEY,
+    revised_at timestamp with time zone not null,
+    script varchar(255) not null,
+    code varchar(255) not null,
+    timestamp timestamp with time zone not null,
+    revision jsonb not null
+);
+
+CREATE INDEX IF NOT EXISTS measurement_revisions_script_code_idx
+    ON measurement_revisions (script, code, revised_at desc);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS rating_curves;
+ALTER TABLE measurements DROP COLUMN IF EXISTS flow_derived;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+-- Flow was calculated from level using rating curve
+ALTER TABLE measurements ADD COLUMN IF NOT EXISTS flow_derived boolean NOT NULL DEFAULT false;
+
+-- Level-to-flow rating curves of gauges
+CREATE TABLE IF NOT EXISTS rating_curves
+(
+    id bigserial PRIMARY KEY,
+    script varchar(255) not null,
+    code varchar(255) not null,
+    curve jsonb not null
+);
+
+CREATE INDEX IF NOT EXISTS rating_curves_script_code_idx
+    ON rating_curves (script, code);
+
+COMMIT;
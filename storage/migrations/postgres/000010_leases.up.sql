@@ -0,0 +1,11 @@
+BEGIN;
+
+-- Leases are used to coordinate multiple gorge instances
+CREATE TABLE IF NOT EXISTS leases
+(
+    name varchar(255) PRIMARY KEY,
+    holder varchar(255) not null,
+    expires_at timestamptz not null
+);
+
+COMMIT;
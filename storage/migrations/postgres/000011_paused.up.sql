@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Paused jobs are kept, but not harvested
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS paused boolean NOT NULL DEFAULT false;
+
+COMMIT;
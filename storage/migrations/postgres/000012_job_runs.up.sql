@@ -0,0 +1,19 @@
+BEGIN;
+
+-- History of harvest runs of jobs
+CREATE TABLE IF NOT EXISTS job_runs
+(
+    id bigserial PRIMARY KEY,
+    job_id text not null,
+    code varchar(255) not null default '',
+    started_at timestamp with time zone not null,
+    run jsonb not null
+);
+
+CREATE INDEX IF NOT EXISTS job_runs_job_id_code_idx
+    ON job_runs (job_id, code, started_at desc);
+
+CREATE INDEX IF NOT EXISTS job_runs_started_at_idx
+    ON job_runs (started_at);
+
+COMMIT;
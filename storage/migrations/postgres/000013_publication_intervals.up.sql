@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Publication intervals of gauges learned from stored measurements, they're used by adaptive schedule
+CREATE TABLE IF NOT EXISTS publication_intervals
+(
+    script varchar(255) not null,
+    code varchar(255) not null,
+    interval_seconds integer not null,
+    learned_at timestamp with time zone not null,
+    PRIMARY KEY (script, code)
+);
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+-- Codes of gauges harvested by runs of batched jobs, so that runs can be listed by gauge code
+CREATE TABLE IF NOT EXISTS job_run_codes
+(
+    run_id bigint not null,
+    code varchar(255) not null,
+    PRIMARY KEY (run_id, code)
+);
+
+CREATE INDEX IF NOT EXISTS job_run_codes_code_idx
+    ON job_run_codes (code);
+
+COMMIT;
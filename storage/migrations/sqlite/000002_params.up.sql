@@ -0,0 +1,2 @@
+-- Values of parameters other than flow and level, e.g. {"temperature": 10.5}
+ALTER TABLE measurements ADD COLUMN params TEXT; -- JSON
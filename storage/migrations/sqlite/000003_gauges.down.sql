@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS gauge_changes;
+DROP TABLE IF EXISTS gauges;
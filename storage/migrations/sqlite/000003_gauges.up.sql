@@ -0,0 +1,23 @@
+-- Catalog of gauges listed from upstream, refreshed periodically
+CREATE TABLE IF NOT EXISTS gauges
+(
+    script TEXT NOT NULL,
+    code TEXT NOT NULL,
+    gauge TEXT NOT NULL, -- JSON
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (script, code)
+);
+
+-- History of catalog changes: added, removed and changed gauges
+CREATE TABLE IF NOT EXISTS gauge_changes
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    timestamp TEXT NOT NULL,
+    script TEXT NOT NULL,
+    code TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    change TEXT NOT NULL -- JSON
+);
+
+CREATE INDEX IF NOT EXISTS gauge_changes_script_code_idx
+    ON gauge_changes (script, code, timestamp desc);
@@ -0,0 +1,8 @@
+-- Gauge codes renamed in upstream: measurements of old_code are stored under new_code
+CREATE TABLE IF NOT EXISTS gauge_aliases
+(
+    script TEXT NOT NULL,
+    old_code TEXT NOT NULL,
+    new_code TEXT NOT NULL,
+    PRIMARY KEY (script, old_code)
+);
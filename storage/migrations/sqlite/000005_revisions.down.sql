@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS measurement_revisions;
+ALTER TABLE measurements DROP COLUMN provisional;
+ALTER TABLE measurements DROP COLUMN quality;
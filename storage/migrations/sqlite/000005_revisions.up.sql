@@ -0,0 +1,17 @@
+-- Upstream's data quality code and approval flag
+ALTER TABLE measurements ADD COLUMN quality TEXT NOT NULL DEFAULT '';
+ALTER TABLE measurements ADD COLUMN provisional BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- History of stored measurements replaced by values corrected in upstream
+CREATE TABLE IF NOT EXISTS measurement_revisions
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    revised_at TEXT NOT NULL,
+    script TEXT NOT NULL,
+    code TEXT NOT NULL,
+    timestamp TEXT NOT NULL,
+    revision TEXT NOT NULL -- JSON
+);
+
+CREATE INDEX IF NOT EXISTS measurement_revisions_script_code_idx
+    ON measurement_revisions (script, code, revised_at desc);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS rating_curves;
+ALTER TABLE measurements DROP COLUMN flow_derived;
@@ -0,0 +1,14 @@
+-- Flow was calculated from level using rating curve
+ALTER TABLE measurements ADD COLUMN flow_derived BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Level-to-flow rating curves of gauges
+CREATE TABLE IF NOT EXISTS rating_curves
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    script TEXT NOT NULL,
+    code TEXT NOT NULL,
+    curve TEXT NOT NULL -- JSON
+);
+
+CREATE INDEX IF NOT EXISTS rating_curves_script_code_idx
+    ON rating_curves (script, code);
@@ -0,0 +1,7 @@
+-- Leases are used to coordinate multiple gorge instances
+CREATE TABLE IF NOT EXISTS leases
+(
+    name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    expires_at TEXT NOT NULL
+);
@@ -0,0 +1,2 @@
+-- Paused jobs are kept, but not harvested
+ALTER TABLE jobs ADD COLUMN paused BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,15 @@
+-- History of harvest runs of jobs
+CREATE TABLE IF NOT EXISTS job_runs
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id TEXT NOT NULL,
+    code TEXT NOT NULL DEFAULT '',
+    started_at TEXT NOT NULL,
+    run TEXT NOT NULL -- JSON
+);
+
+CREATE INDEX IF NOT EXISTS job_runs_job_id_code_idx
+    ON job_runs (job_id, code, started_at desc);
+
+CREATE INDEX IF NOT EXISTS job_runs_started_at_idx
+    ON job_runs (started_at);
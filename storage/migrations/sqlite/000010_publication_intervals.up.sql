@@ -0,0 +1,9 @@
+-- Publication intervals of gauges learned from stored measurements, they're used by adaptive schedule
+CREATE TABLE IF NOT EXISTS publication_intervals
+(
+    script TEXT NOT NULL,
+    code TEXT NOT NULL,
+    interval_seconds INTEGER NOT NULL,
+    learned_at TEXT NOT NULL,
+    PRIMARY KEY (script, code)
+);
@@ -72,7 +72,29 @@ func newCacheManager(lc fx.Lifecycle, cfg *config.Config, logger *logrus.Logger)
 	return mgr, nil
 }
 
+// newLeaseManager returns nil if this gorge instance is not coordinated with other instances
+func newLeaseManager(cfg *config.Config, db DatabaseManager, cache CacheManager) (LeaseManager, error) {
+	var mgr interface{}
+	var backend string
+	switch cfg.Scheduler.Leases {
+	case "":
+		return nil, nil
+	case "db":
+		mgr, backend = db, cfg.Db
+	case "redis":
+		mgr, backend = cache, cfg.Cache
+	default:
+		return nil, fmt.Errorf("invalid lease manager")
+	}
+	leases, ok := mgr.(LeaseManager)
+	if !ok {
+		return nil, fmt.Errorf("leases are not supported by %s", backend)
+	}
+	return leases, nil
+}
+
 var Module = fx.Options(
 	fx.Provide(newDatabaseManager),
 	fx.Provide(newCacheManager),
+	fx.Provide(newLeaseManager),
 )
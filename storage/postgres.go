@@ -31,9 +31,10 @@ type PostgresManager struct {
 func newPostgresManager(logger *logrus.Entry, cfg *config.Config) *PostgresManager {
 	return &PostgresManager{
 		DbManager: DbManager{
-			defaultStart:     "NOW() - interval '30 days'",
-			nearestDayClause: "abs(extract(epoch from timestamp - %s::timestamptz))",
-			saveChunkSize:    cfg.DbChunkSize,
+			defaultStart:         "NOW() - interval '30 days'",
+			nearestDayClause:     "abs(extract(epoch from timestamp - %s::timestamptz))",
+			secondsBetweenClause: "extract(epoch from %s - %s)",
+			saveChunkSize:        cfg.DbChunkSize,
 		},
 		pgConnStr: fmt.Sprintf(
 			"postgres://%s:%s@%s/%s?sslmode=disable",
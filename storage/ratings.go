@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"encoding/json"
+
+	"github.com/whitewater-guide/gorge/core"
+)
+
+// ListRatingCurves implements DatabaseManager interface
+func (mgr *DbManager) ListRatingCurves(script, code string) ([]core.RatingCurve, error) {
+	var rows []struct {
+		ID    int64  `db:"id"`
+		Curve string `db:"curve"`
+	}
+	q, args := "SELECT id, curve FROM rating_curves WHERE script = $1 ORDER BY code, id", []interface{}{script}
+	if code != "" {
+		q, args = "SELECT id, curve FROM rating_curves WHERE script = $1 AND code = $2 ORDER BY id", []interface{}{script, code}
+	}
+	if err := mgr.db.Select(&rows, q, args...); err != nil {
+		return nil, core.WrapErr(err, "failed to list rating curves").With("script", script).With("code", code)
+	}
+	result := make([]core.RatingCurve, len(rows))
+	for i, row := range rows {
+		if err := json.Unmarshal([]byte(row.Curve), &result[i]); err != nil {
+			return nil, core.WrapErr(err, "failed to unmarshal rating curve").With("id", row.ID)
+		}
+		result[i].ID = row.ID
+	}
+	return result, nil
+}
+
+// AddRatingCurve implements DatabaseManager interface
+func (mgr *DbManager) AddRatingCurve(curve core.RatingCurve) (int64, error) {
+	curve.ID = 0
+	raw, err := json.Marshal(curve)
+	if err != nil {
+		return 0, core.WrapErr(err, "failed to marshal rating curve")
+	}
+	var id int64
+	err = mgr.db.QueryRow(
+		"INSERT INTO rating_curves (script, code, curve) VALUES ($1, $2, $3) RETURNING id",
+		curve.Script, curve.Code, string(raw),
+	).Scan(&id)
+	if err != nil {
+		return 0, core.WrapErr(err, "failed to save rating curve").With("script", curve.Script).With("code", curve.Code)
+	}
+	return id, nil
+}
+
+// DeleteRatingCurve implements DatabaseManager interface
+func (mgr *DbManager) DeleteRatingCurve(id int64) (bool, error) {
+	res, err := mgr.db.Exec("DELETE FROM rating_curves WHERE id = $1", id)
+	if err != nil {
+		return false, core.WrapErr(err, "failed to delete rating curve").With("id", id)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, core.WrapErr(err, "failed to count deleted rating curves")
+	}
+	return cnt > 0, nil
+}
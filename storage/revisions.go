@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/whitewater-guide/gorge/core"
+)
+
+const updateMeasurementQuery = `UPDATE measurements SET flow = $1, level = $2, params = $3, quality = $4, provisional = $5, flow_derived = $6
+WHERE script = $7 AND code = $8 AND timestamp = $9`
+
+// storedMeasurementsBatch is how many measurements are looked up in db with one query
+// Each measurement takes 3 query parameters, so batch of 300 stays within 999 parameters limit of sqlite older than 3.32
+// even when db chunk size is large or unlimited
+const storedMeasurementsBatch = 300
+
+// measurementKey identifies measurement in measurements table
+type measurementKey struct {
+	core.GaugeID
+	timestamp int64
+}
+
+func keyOf(m *core.Measurement) measurementKey {
+	return measurementKey{GaugeID: m.GaugeID, timestamp: m.Timestamp.UTC().Truncate(time.Microsecond).UnixNano()}
+}
+
+// ReviseMeasurements implements DatabaseManager interface
+func (mgr *DbManager) ReviseMeasurements(ctx context.Context, in <-chan *core.Measurement) (<-chan int, <-chan error) {
+	return mgr.saveMeasurements(ctx, in, mgr.reviseMeasurementsChunk)
+}
+
+// reviseMeasurementsChunk inserts new measurements and replaces stored measurements which were corrected in upstream
+// Returns number of inserted and revised measurements
+func (mgr *DbManager) reviseMeasurementsChunk(chunk []*core.Measurement) (int, error) {
+	tx, err := mgr.db.Beginx()
+	if err != nil {
+		return 0, core.WrapErr(err, "failed to begin revise measurements transaction")
+	}
+	stored, err := getStoredMeasurements(tx, chunk)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	now := core.HTime{Time: time.Now().UTC()}
+	count := 0
+	for _, m := range chunk {
+		key := keyOf(m)
+		prev, ok := stored[key]
+		if !ok {
+			if _, err := tx.NamedExec(saveMeasurementsQuery, m); err != nil {
+				tx.Rollback()
+				return 0, core.WrapErr(err, "failed to insert measurement").With("script", m.Script).With("code", m.Code)
+			}
+			// chunk can contain same measurement twice
+			stored[key] = *m
+			count++
+			continue
+		}
+		if !m.Revises(&prev) {
+			continue
+		}
+		if _, err := tx.Exec(updateMeasurementQuery, m.Flow, m.Level, m.Params, m.Quality, m.Provisional, m.FlowDerived, m.Script, m.Code, m.Timestamp); err != nil {
+			tx.Rollback()
+			return 0, core.WrapErr(err, "failed to update measurement").With("script", m.Script).With("code", m.Code)
+		}
+		raw, err := json.Marshal(core.MeasurementRevision{Previous: prev, Current: *m, RevisedAt: now})
+		if err != nil {
+			tx.Rollback()
+			return 0, core.WrapErr(err, "failed to marshal measurement revision").With("script", m.Script).With("code", m.Code)
+		}
+		_, err = tx.Exec(
+			"INSERT INTO measurement_revisions (revised_at, script, code, timestamp, revision) VALUES ($1, $2, $3, $4, $5)",
+			now, m.Script, m.Code, m.Timestamp, string(raw),
+		)
+		if err != nil {
+			tx.Rollback()
+			return 0, core.WrapErr(err, "failed to save measurement revision").With("script", m.Script).With("code", m.Code)
+		}
+		stored[key] = *m
+		count++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, core.WrapErr(err, "failed to commit revise measurements transaction")
+	}
+	return count, nil
+}
+
+// getStoredMeasurements loads stored measurements with same script, code and timestamp as measurements of chunk
+// Measurements are loaded with one query per storedMeasurementsBatch measurements
+func getStoredMeasurements(tx *sqlx.Tx, chunk []*core.Measurement) (map[measurementKey]core.Measurement, error) {
+	result := make(map[measurementKey]core.Measurement, len(chunk))
+	for start := 0; start < len(chunk); start += storedMeasurementsBatch {
+		end := start + storedMeasurementsBatch
+		if end > len(chunk) {
+			end = len(chunk)
+		}
+		tuples := make([]string, 0, end-start)
+		args := make([]interface{}, 0, 3*(end-start))
+		for i, m := range chunk[start:end] {
+			tuples = append(tuples, fmt.Sprintf("($%d, $%d, $%d)", 3*i+1, 3*i+2, 3*i+3))
+			args = append(args, m.Script, m.Code, m.Timestamp)
+		}
+		rows, err := tx.Queryx("SELECT * FROM measurements WHERE (script, code, timestamp) IN ("+strings.Join(tuples, ", ")+")", args...)
+		if err != nil {
+			return nil, core.WrapErr(err, "failed to get stored measurements")
+		}
+		for rows.Next() {
+			var prev core.Measurement
+			if err := rows.StructScan(&prev); err != nil {
+				rows.Close()
+				return nil, core.WrapErr(err, "failed to get next stored measurement")
+			}
+			prev.Timestamp = core.HTime{Time: prev.Timestamp.UTC()}
+			result[keyOf(&prev)] = prev
+		}
+		if err := rows.Err(); err != nil {
+			return nil, core.WrapErr(err, "failed to get stored measurements")
+		}
+	}
+	return result, nil
+}
+
+// ListMeasurementRevisions implements DatabaseManager interface
+func (mgr *DbManager) ListMeasurementRevisions(script, code string) ([]core.MeasurementRevision, error) {
+	var rows []string
+	err := mgr.db.Select(&rows, "SELECT revision FROM measurement_revisions WHERE script = $1 AND code = $2 ORDER BY revised_at DESC, id DESC", script, code)
+	if err != nil {
+		return nil, core.WrapErr(err, "failed to list measurement revisions").With("script", script).With("code", code)
+	}
+	result := make([]core.MeasurementRevision, len(rows))
+	for i, raw := range rows {
+		if err := json.Unmarshal([]byte(raw), &result[i]); err != nil {
+			return nil, core.WrapErr(err, "failed to unmarshal measurement revision").With("script", script).With("code", code)
+		}
+	}
+	return result, nil
+}
@@ -28,9 +28,10 @@ type SqliteManager struct {
 func NewSqliteDb(logger *logrus.Entry, chunkSize int) *SqliteManager {
 	return &SqliteManager{
 		DbManager: DbManager{
-			defaultStart:     "datetime('now', '-30 days')",
-			nearestDayClause: "ABS(julianday(timestamp) - julianday(%s))",
-			saveChunkSize:    chunkSize,
+			defaultStart:         "datetime('now', '-30 days')",
+			nearestDayClause:     "ABS(julianday(timestamp) - julianday(%s))",
+			secondsBetweenClause: "(julianday(%s) - julianday(%s)) * 86400",
+			saveChunkSize:        chunkSize,
 		},
 		logger: logger,
 	}